@@ -4,26 +4,20 @@ import (
 	"fmt"
 	"log"
 	"os"
-	"strings"
 
 	"github.com/redjax/notetkr/internal/version"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/redjax/notetkr/internal/commands"
 	"github.com/redjax/notetkr/internal/config"
+	"github.com/redjax/notetkr/internal/services"
 	"github.com/redjax/notetkr/internal/tui"
-
-	"github.com/knadh/koanf/parsers/json"
-	"github.com/knadh/koanf/providers/env"
-	"github.com/knadh/koanf/providers/file"
-	"github.com/knadh/koanf/v2"
 	"github.com/spf13/cobra"
 )
 
 var (
 	cfgFile string
 	debug   bool
-	k       = koanf.New(".")
 	cfg     *config.Config
 )
 
@@ -45,7 +39,7 @@ func Execute() {
 }
 
 func init() {
-	rootCmd.PersistentFlags().StringVarP(&cfgFile, "config-file", "c", "", "config file (supports .yml, .json, .toml, .env)")
+	rootCmd.PersistentFlags().StringVarP(&cfgFile, "config-file", "c", "", "config file (.yml, .yaml, .json, .toml or .env)")
 	rootCmd.PersistentFlags().BoolVarP(&debug, "debug", "D", false, "Enable debug logging")
 	rootCmd.PersistentFlags().BoolP("version", "v", false, "Print version and exit")
 
@@ -57,6 +51,9 @@ func init() {
 	rootCmd.AddCommand(commands.NewImportCmd(func() *config.Config { return cfg }))
 	rootCmd.AddCommand(commands.NewSelfCmd(func() *config.Config { return cfg }))
 	rootCmd.AddCommand(commands.NewCleanCmd(func() *config.Config { return cfg }))
+	rootCmd.AddCommand(commands.NewConfigCmd(func() *config.Config { return cfg }))
+	rootCmd.AddCommand(commands.NewMigrateCmd(func() *config.Config { return cfg }))
+	rootCmd.AddCommand(commands.NewPreviewCmd(func() *config.Config { return cfg }))
 
 	// Handle persistent flags
 	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
@@ -78,26 +75,30 @@ func init() {
 }
 
 func initConfig() {
-	// Initialize config with defaults
-	cfg = config.DefaultConfig()
+	var err error
+	cfg, err = config.LoadConfig(cfgFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		os.Exit(1)
+	}
 
-	if cfgFile != "" {
-		if err := k.Load(file.Provider(cfgFile), json.Parser()); err != nil {
-			fmt.Fprintf(os.Stderr, "Error loading config file: %v\n", err)
-			os.Exit(1)
+	// Fall back to the defaults for settings that can't be used
+	if err := services.CheckCodeTheme(cfg.PreviewCodeTheme); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠ %v; using %s\n", err, services.DefaultCodeTheme)
+		cfg.PreviewCodeTheme = services.DefaultCodeTheme
+	}
+	if cfg.PreviewSpellCheck {
+		if _, err := services.NewSpellChecker(cfg.PreviewDictionary); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠ %v; using the bundled word list\n", err)
 		}
 	}
-
-	// Load from environment variables
-	k.Load(env.Provider("NOTETKR_", ".", func(s string) string {
-		return strings.Replace(strings.ToLower(
-			strings.TrimPrefix(s, "NOTETKR_")), "_", ".", -1)
-	}), nil)
-
-	// Unmarshal config values into the struct
-	if err := k.Unmarshal("", cfg); err != nil {
-		fmt.Fprintf(os.Stderr, "Error unmarshaling config: %v\n", err)
-		os.Exit(1)
+	if err := services.CheckJournalFilenameFormat(cfg.JournalFilenameFormat); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠ %v; using %s\n", err, services.DefaultJournalFilenameFormat)
+		cfg.JournalFilenameFormat = services.DefaultJournalFilenameFormat
+	}
+	if _, err := services.ParseWeekStart(cfg.WeekStartsOn); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠ %v; using sunday\n", err)
+		cfg.WeekStartsOn = "sunday"
 	}
 
 	// Ensure data directories exist
@@ -125,7 +126,7 @@ func runDashboard() {
 
 	// Create and run the dashboard TUI
 	app := tui.NewAppModel(cfg)
-	p := tea.NewProgram(app, tea.WithAltScreen())
+	p := tea.NewProgram(app, tea.WithAltScreen(), tea.WithReportFocus())
 
 	if _, err := p.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error running dashboard: %v\n", err)
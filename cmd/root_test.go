@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runRoot runs nt with args as if from the command line and returns what it printed
+func runRoot(t *testing.T, args ...string) string {
+	t.Helper()
+
+	// Flag values outlive a run, so start each one from the defaults
+	cfgFile = ""
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	rootCmd.SetArgs(args)
+	err = rootCmd.Execute()
+	w.Close()
+	os.Stdout = stdout
+	if err != nil {
+		t.Fatalf("nt %s: %v", strings.Join(args, " "), err)
+	}
+
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func TestConfigFileFormats(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	for _, tt := range []struct {
+		name    string
+		content func(notesDir string) string
+	}{
+		{"notetkr.yml", func(notesDir string) string {
+			return "notes:\n  dir: " + notesDir + "\n"
+		}},
+		{"notetkr.toml", func(notesDir string) string {
+			return "[notes]\ndir = " + `"` + filepath.ToSlash(notesDir) + `"` + "\n"
+		}},
+	} {
+		dir := t.TempDir()
+		notesDir := filepath.Join(dir, "notes")
+		if err := os.MkdirAll(notesDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(notesDir, "from-"+tt.name+".md"), []byte("# Hi\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(dir, tt.name)
+		if err := os.WriteFile(path, []byte(tt.content(notesDir)), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		out := runRoot(t, "-c", path, "notes", "ls")
+		if !strings.Contains(out, "from-"+tt.name+".md") {
+			t.Errorf("%s: nt notes ls should list the note in the configured notes.dir, got:\n%s", tt.name, out)
+		}
+		if cfg.ConfigFile != path {
+			t.Errorf("%s: active config file = %s", tt.name, cfg.ConfigFile)
+		}
+	}
+}
+
+func TestDefaultConfigFileIsLoaded(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	notesDir := filepath.Join(t.TempDir(), "elsewhere")
+	if err := os.MkdirAll(notesDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(notesDir, "moved.md"), []byte("# Moved\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without a config file the defaults apply
+	if out := runRoot(t, "notes", "ls"); strings.Contains(out, "moved.md") {
+		t.Errorf("no config file yet, but notes were listed from %s", notesDir)
+	}
+
+	// Settings in ~/.notetkr/notetkr.yml apply without -c
+	path := filepath.Join(home, ".notetkr", "notetkr.yml")
+	if err := os.WriteFile(path, []byte("notes:\n  dir: "+notesDir+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if out := runRoot(t, "notes", "ls"); !strings.Contains(out, "moved.md") {
+		t.Errorf("nt notes ls should use notes.dir from %s, got:\n%s", path, out)
+	}
+	if cfg.ConfigFile != path {
+		t.Errorf("active config file = %s, want %s", cfg.ConfigFile, path)
+	}
+}
+
+func TestMigrateThenReload(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	oldDir := filepath.Join(home, ".notetkr")
+	newDir := filepath.Join(t.TempDir(), "notetkr")
+
+	// The default setup: no config file and no -c
+	runRoot(t, "notes", "ls")
+	for rel, content := range map[string]string{
+		"notes/work/plan.md":  "# Plan\n",
+		"journal_template.md": "# {{date}}\n",
+	} {
+		path := filepath.Join(oldDir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	runRoot(t, "migrate", "--to", newDir)
+
+	// The next run reads the updated config and finds the notes in their new home
+	out := runRoot(t, "notes", "ls", "--category", "work")
+	if !strings.Contains(out, "plan.md") {
+		t.Errorf("nt notes ls after migrating should list plan.md, got:\n%s", out)
+	}
+	if want := filepath.Join(newDir, "notes"); cfg.NotesDir != want {
+		t.Errorf("notes.dir = %s, want %s", cfg.NotesDir, want)
+	}
+	if want := filepath.Join(newDir, "journal_template.md"); cfg.JournalTemplate != want {
+		t.Errorf("journal.template = %s, want %s", cfg.JournalTemplate, want)
+	}
+	if _, err := os.Stat(cfg.JournalTemplate); err != nil {
+		t.Errorf("journal template should have moved: %v", err)
+	}
+}
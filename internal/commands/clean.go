@@ -35,15 +35,22 @@ func NewCleanCmd(getConfig func() *config.Config) *cobra.Command {
 	cmd.AddCommand(imagesCmd)
 
 	// Add notes subcommand
+	var whitespaceOnly bool
 	notesCmd := &cobra.Command{
 		Use:   "notes",
 		Short: "Clean up empty notes",
-		Long:  `Removes notes that only contain the default template with no user content.`,
+		Long: `Removes notes that only contain the default template with no user content.
+With --whitespace, removes notes that contain only whitespace or empty frontmatter instead.`,
 		Run: func(cmd *cobra.Command, args []string) {
 			cfg := getConfig()
+			if whitespaceOnly {
+				runCleanWhitespaceNotes(cfg)
+				return
+			}
 			runCleanNotes(cfg)
 		},
 	}
+	notesCmd.Flags().BoolVar(&whitespaceOnly, "whitespace", false, "Remove whitespace-only notes instead of default-template notes")
 	cmd.AddCommand(notesCmd)
 
 	// Add journals subcommand
@@ -58,6 +65,23 @@ func NewCleanCmd(getConfig func() *config.Config) *cobra.Command {
 	}
 	cmd.AddCommand(journalsCmd)
 
+	// Add normalize-links subcommand
+	var dryRun bool
+	normalizeLinksCmd := &cobra.Command{
+		Use:   "normalize-links",
+		Short: "Rewrite image links to one canonical form",
+		Long: `Rewrites every .attachments image link in notes and journals to an angle-bracketed
+path relative to the file, e.g. ![alt](<.attachments/image.png>). Links written as
+./.attachments/..., absolute paths, or with %20-encoded spaces all converge on it.
+With --dry-run, lists the links that would change without writing anything.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := getConfig()
+			runNormalizeLinks(cfg, dryRun)
+		},
+	}
+	normalizeLinksCmd.Flags().BoolVar(&dryRun, "dry-run", false, "List the links that would change without rewriting them")
+	cmd.AddCommand(normalizeLinksCmd)
+
 	return cmd
 }
 
@@ -101,6 +125,23 @@ func runCleanNotes(cfg *config.Config) {
 	}
 }
 
+func runCleanWhitespaceNotes(cfg *config.Config) {
+	cleanupService := services.NewCleanupService(cfg.NotesDir, cfg.JournalDir)
+
+	fmt.Println("🔍 Scanning for whitespace-only notes...")
+	deleted, err := cleanupService.CleanWhitespaceNotes()
+	if err != nil {
+		fmt.Printf("❌ Error cleaning notes: %v\n", err)
+		return
+	}
+
+	if deleted == 0 {
+		fmt.Println("✓ No whitespace-only notes found")
+	} else {
+		fmt.Printf("✓ Deleted %d whitespace-only note(s)\n", deleted)
+	}
+}
+
 func runCleanJournals(cfg *config.Config) {
 	cleanupService := services.NewCleanupService(cfg.NotesDir, cfg.JournalDir)
 
@@ -117,3 +158,31 @@ func runCleanJournals(cfg *config.Config) {
 		fmt.Printf("✓ Deleted %d empty journal entr(ies)\n", deleted)
 	}
 }
+
+func runNormalizeLinks(cfg *config.Config, dryRun bool) {
+	cleanupService := services.NewCleanupService(cfg.NotesDir, cfg.JournalDir)
+
+	fmt.Println("🔍 Scanning image links...")
+	changed, err := cleanupService.NormalizeImageLinks(dryRun)
+	if err != nil {
+		fmt.Printf("❌ Error normalizing links: %v\n", err)
+		return
+	}
+
+	if len(changed) == 0 {
+		fmt.Println("✓ All image links are already in canonical form")
+		return
+	}
+
+	files := make(map[string]bool)
+	for _, link := range changed {
+		files[link.FilePath] = true
+		fmt.Printf("  → %s:%d: %s → <%s>\n", link.FilePath, link.LineNum, link.OldPath, link.NewPath)
+	}
+
+	if dryRun {
+		fmt.Printf("✓ Would normalize %d image link(s) in %d file(s)\n", len(changed), len(files))
+	} else {
+		fmt.Printf("✓ Normalized %d image link(s) in %d file(s)\n", len(changed), len(files))
+	}
+}
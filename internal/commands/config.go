@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/redjax/notetkr/internal/config"
+	"github.com/redjax/notetkr/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// NewConfigCmd creates the config command
+func NewConfigCmd(getConfig func() *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect Notetkr configuration",
+		Long:  `Commands for inspecting and troubleshooting the Notetkr configuration file.`,
+	}
+
+	// Add validate subcommand
+	validateCmd := &cobra.Command{
+		Use:   "validate [file]",
+		Short: "Validate a config file",
+		Long: `Loads the given config file (or the active one if omitted), reports unknown keys,
+and checks that the configured directories can be created.`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := getConfig()
+			path := cfg.ConfigFile
+			if len(args) > 0 {
+				path = args[0]
+			}
+			if !runConfigValidate(path) {
+				os.Exit(1)
+			}
+		},
+	}
+	cmd.AddCommand(validateCmd)
+
+	// Add edit subcommand
+	editCmd := &cobra.Command{
+		Use:   "edit",
+		Short: "Open the config file in $EDITOR",
+		Long: `Opens the active config file (~/.notetkr/notetkr.yml unless -c is given) in $EDITOR,
+creating it from the defaults if it does not exist yet, then validates and reloads it once the
+editor exits. Later runs of nt pick up the changes without -c.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := getConfig()
+			if !runConfigEdit(cfg) {
+				os.Exit(1)
+			}
+		},
+	}
+	cmd.AddCommand(editCmd)
+
+	return cmd
+}
+
+// runConfigEdit opens the active config file in $EDITOR, then validates it and
+// reloads cfg from it
+func runConfigEdit(cfg *config.Config) bool {
+	path := cfg.ConfigFile
+	created, err := config.EnsureConfigFile(path)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return false
+	}
+	if created {
+		fmt.Printf("✓ Created default config file: %s\n", path)
+	}
+
+	editor := exec.Command(utils.EditorCommand(), path)
+	editor.Stdin = os.Stdin
+	editor.Stdout = os.Stdout
+	editor.Stderr = os.Stderr
+	if err := editor.Run(); err != nil {
+		fmt.Printf("❌ failed to open editor: %v\n", err)
+		return false
+	}
+
+	if !runConfigValidate(path) {
+		return false
+	}
+
+	reloaded, err := config.LoadConfig(path)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return false
+	}
+	*cfg = *reloaded
+	fmt.Println("✓ Reloaded config")
+	return true
+}
+
+// runConfigValidate prints a validation report and returns true if the file is valid
+func runConfigValidate(path string) bool {
+	fmt.Printf("🔍 Validating config file: %s\n", path)
+
+	result, err := config.ValidateConfigFile(path)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return false
+	}
+
+	for _, key := range result.UnknownKeys {
+		fmt.Printf("⚠ Unknown key: %s\n", key)
+	}
+
+	for key, pathErr := range result.PathErrors {
+		fmt.Printf("❌ %s: %v\n", key, pathErr)
+	}
+
+	if !result.IsValid() {
+		fmt.Printf("❌ Found %d unknown key(s) and %d path problem(s)\n", len(result.UnknownKeys), len(result.PathErrors))
+		return false
+	}
+
+	fmt.Println("✓ Config file is valid")
+	fmt.Printf("  - data.dir:    %s\n", result.Config.DataDir)
+	fmt.Printf("  - notes.dir:   %s\n", result.Config.NotesDir)
+	fmt.Printf("  - journal.dir: %s\n", result.Config.JournalDir)
+
+	return true
+}
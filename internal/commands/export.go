@@ -1,7 +1,9 @@
 package commands
 
 import (
+	"archive/tar"
 	"archive/zip"
+	"compress/gzip"
 	"fmt"
 	"io"
 	"os"
@@ -10,55 +12,175 @@ import (
 	"time"
 
 	"github.com/redjax/notetkr/internal/config"
+	"github.com/redjax/notetkr/internal/services"
 	"github.com/spf13/cobra"
 )
 
+// Archive formats supported by the export command
+const (
+	formatZip   = "zip"
+	formatTarGz = "tar.gz"
+)
+
 // NewExportCmd creates the export command
 func NewExportCmd(getConfig func() *config.Config) *cobra.Command {
 	var outputPath string
 	var exportTypes []string
+	var format string
+	var tag string
 
 	cmd := &cobra.Command{
 		Use:   "export",
-		Short: "Export notes and journals to a ZIP archive",
-		Long: `Export your notes and journals to a ZIP archive. By default, exports the entire data directory.
-Use -t/--export-type to specify what to export (notes, journals, or both).`,
+		Short: "Export notes and journals to a ZIP or tar.gz archive",
+		Long: `Export your notes and journals to a ZIP or tar.gz archive. By default, exports the entire data directory.
+Use -t/--export-type to specify what to export (notes, journals, or both).
+Use --format to choose zip or tar.gz; without it the format follows the output file's extension (.tar.gz/.tgz or .zip), defaulting to zip.
+Use --tag to export only the notes with that tag, plus the images they link to. Journals are left out unless -t journals is also given.`,
 		Run: func(cmd *cobra.Command, args []string) {
 			cfg := getConfig()
-			if err := runExport(cfg, outputPath, exportTypes); err != nil {
+			if err := runExport(cfg, outputPath, exportTypes, format, tag); err != nil {
 				fmt.Fprintf(os.Stderr, "Error exporting data: %v\n", err)
 				os.Exit(1)
 			}
 		},
 	}
 
-	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "Output path for the ZIP file")
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "Output path for the archive")
 	cmd.Flags().StringSliceVarP(&exportTypes, "export-type", "t", []string{}, "What to export: notes, journals (default: both)")
+	cmd.Flags().StringVar(&tag, "tag", "", "Only export notes with this tag (and their attachments)")
+	cmd.Flags().StringVar(&format, "format", "", "Archive format: zip or tar.gz (default: from the output extension, else zip)")
+
+	// Add note subcommand
+	var noteOutputPath string
+	var asHTML bool
+	noteCmd := &cobra.Command{
+		Use:   "note <name or path>",
+		Short: "Export one note as a self-contained file with embedded images",
+		Long: `Exports a single note for sharing, with its images embedded as base64 data URIs so the
+recipient doesn't need the attachment files. The note can be a path or a name in the
+notes directory. Writes markdown by default, or the styled preview HTML with --html.
+The output defaults to <name>-standalone.md (or .html) in the current directory.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := getConfig()
+			if err := runExportNote(cfg, args[0], noteOutputPath, asHTML); err != nil {
+				fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	noteCmd.Flags().StringVarP(&noteOutputPath, "output", "o", "", "Output path for the exported note")
+	noteCmd.Flags().BoolVar(&asHTML, "html", false, "Export the rendered HTML instead of markdown")
+	cmd.AddCommand(noteCmd)
 
 	return cmd
 }
 
-func runExport(cfg *config.Config, outputPath string, exportTypes []string) error {
-	// Determine output path
+// runExportNote writes a single note with its images embedded as data URIs
+func runExportNote(cfg *config.Config, note, outputPath string, asHTML bool) error {
+	notePath := note
+	if info, err := os.Stat(note); err != nil || info.IsDir() {
+		resolved, err := newNotesService(cfg).ResolveNotePath(note)
+		if err != nil {
+			return err
+		}
+		notePath = resolved
+	}
+	notePath, err := filepath.Abs(notePath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", note, err)
+	}
+
+	content, err := os.ReadFile(notePath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", notePath, err)
+	}
+
+	ext := ".md"
+	if asHTML {
+		ext = ".html"
+	}
+	if outputPath == "" {
+		outputPath = strings.TrimSuffix(filepath.Base(notePath), filepath.Ext(notePath)) + "-standalone" + ext
+	}
+
+	inlined, embedded := services.InlineImages(string(content), filepath.Dir(notePath))
+	output := inlined
+	if asHTML {
+		output, err = newPreviewService(cfg).RenderHTML(notePath, inlined)
+		if err != nil {
+			return err
+		}
+	}
+
+	if dir := filepath.Dir(outputPath); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(outputPath, []byte(output), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outputPath, err)
+	}
+
+	fmt.Printf("✓ Exported %s with %d embedded image(s) to: %s\n", filepath.Base(notePath), embedded, outputPath)
+	return nil
+}
+
+// exportFormat returns the archive format to write: the --format flag if set,
+// otherwise whatever the output path's extension implies, falling back to zip
+func exportFormat(outputPath, format string) (string, error) {
+	switch strings.ToLower(format) {
+	case "zip":
+		return formatZip, nil
+	case "tar.gz", "tgz":
+		return formatTarGz, nil
+	case "":
+	default:
+		return "", fmt.Errorf("invalid format: %s (valid options: zip, tar.gz)", format)
+	}
+
+	lower := strings.ToLower(outputPath)
+	if strings.HasSuffix(lower, ".tar.gz") || strings.HasSuffix(lower, ".tgz") {
+		return formatTarGz, nil
+	}
+	return formatZip, nil
+}
+
+// exportOutputPath returns where to write the archive, adding the format's
+// extension if the path doesn't already have it
+func exportOutputPath(outputPath, format string) (string, error) {
+	ext := "." + format
 	if outputPath == "" {
 		homeDir, err := os.UserHomeDir()
 		if err != nil {
-			return fmt.Errorf("failed to get home directory: %w", err)
+			return "", fmt.Errorf("failed to get home directory: %w", err)
 		}
 		timestamp := time.Now().Format("2006-01-02")
-		outputPath = filepath.Join(homeDir, fmt.Sprintf("%s-notetkr-data.zip", timestamp))
-	} else {
-		// Ensure output path ends with .zip
-		if !strings.HasSuffix(strings.ToLower(outputPath), ".zip") {
-			outputPath += ".zip"
-		}
+		return filepath.Join(homeDir, fmt.Sprintf("%s-notetkr-data%s", timestamp, ext)), nil
+	}
+
+	lower := strings.ToLower(outputPath)
+	if strings.HasSuffix(lower, ext) || (format == formatTarGz && strings.HasSuffix(lower, ".tgz")) {
+		return outputPath, nil
+	}
+	return outputPath + ext, nil
+}
+
+func runExport(cfg *config.Config, outputPath string, exportTypes []string, format, tag string) error {
+	format, err := exportFormat(outputPath, format)
+	if err != nil {
+		return err
+	}
+	outputPath, err = exportOutputPath(outputPath, format)
+	if err != nil {
+		return err
 	}
 
 	// Determine what to export
 	exportNotes := true
 	exportJournals := true
 
-	if len(exportTypes) > 0 {
+	if len(exportTypes) > 0 || tag != "" {
 		exportNotes = false
 		exportJournals = false
 
@@ -74,22 +196,69 @@ func runExport(cfg *config.Config, outputPath string, exportTypes []string) erro
 		}
 	}
 
-	// Create ZIP file
-	zipFile, err := os.Create(outputPath)
+	// With a tag, only the tagged notes (and their attachments) are exported
+	var taggedNotes []services.Note
+	if tag != "" {
+		exportNotes = true
+		taggedNotes, err = newNotesService(cfg).FilterByTag(tag)
+		if err != nil {
+			return fmt.Errorf("failed to find notes tagged %q: %w", tag, err)
+		}
+		if len(taggedNotes) == 0 && !exportJournals {
+			return fmt.Errorf("no notes are tagged %q", tag)
+		}
+	}
+
+	// Create the archive file
+	archiveFile, err := os.Create(outputPath)
 	if err != nil {
-		return fmt.Errorf("failed to create ZIP file: %w", err)
+		return fmt.Errorf("failed to create archive file: %w", err)
 	}
-	defer zipFile.Close()
+	defer archiveFile.Close()
+
+	// addFile adds one file to the archive; finalize flushes the archive
+	var addFile func(path, archivePath string, info os.FileInfo) error
+	var finalize func() error
 
-	zipWriter := zip.NewWriter(zipFile)
-	defer zipWriter.Close()
+	switch format {
+	case formatTarGz:
+		gzipWriter := gzip.NewWriter(archiveFile)
+		defer gzipWriter.Close()
+		tarWriter := tar.NewWriter(gzipWriter)
+		defer tarWriter.Close()
+
+		addFile = func(path, archivePath string, info os.FileInfo) error {
+			return addFileToTarGz(tarWriter, path, archivePath, info)
+		}
+		finalize = func() error {
+			if err := tarWriter.Close(); err != nil {
+				return err
+			}
+			return gzipWriter.Close()
+		}
+	default:
+		zipWriter := zip.NewWriter(archiveFile)
+		defer zipWriter.Close()
+
+		addFile = func(path, archivePath string, info os.FileInfo) error {
+			return addFileToZip(zipWriter, path, archivePath, info)
+		}
+		finalize = zipWriter.Close
+	}
 
 	// Track files added
 	filesAdded := 0
 
 	// Export notes if requested
-	if exportNotes {
-		count, err := addDirToZip(zipWriter, cfg.NotesDir, "notes")
+	if exportNotes && tag != "" {
+		notes, attachments, err := addTaggedNotes(addFile, cfg.NotesDir, taggedNotes)
+		if err != nil {
+			return fmt.Errorf("failed to add notes to archive: %w", err)
+		}
+		fmt.Printf("🔍 %d note(s) tagged %q, with %d attachment(s)\n", notes, tag, attachments)
+		filesAdded += notes + attachments
+	} else if exportNotes {
+		count, err := addDirToArchive(addFile, cfg.NotesDir, "notes")
 		if err != nil {
 			return fmt.Errorf("failed to add notes to archive: %w", err)
 		}
@@ -98,16 +267,16 @@ func runExport(cfg *config.Config, outputPath string, exportTypes []string) erro
 
 	// Export journals if requested
 	if exportJournals {
-		count, err := addDirToZip(zipWriter, cfg.JournalDir, "journals")
+		count, err := addDirToArchive(addFile, cfg.JournalDir, "journals")
 		if err != nil {
 			return fmt.Errorf("failed to add journals to archive: %w", err)
 		}
 		filesAdded += count
 	}
 
-	// Close the ZIP writer to flush everything
-	if err := zipWriter.Close(); err != nil {
-		return fmt.Errorf("failed to finalize ZIP file: %w", err)
+	// Close the archive writers to flush everything
+	if err := finalize(); err != nil {
+		return fmt.Errorf("failed to finalize archive: %w", err)
 	}
 
 	fmt.Printf("✓ Successfully exported %d file(s) to: %s\n", filesAdded, outputPath)
@@ -115,53 +284,125 @@ func runExport(cfg *config.Config, outputPath string, exportTypes []string) erro
 	return nil
 }
 
-// addDirToZip adds all files from a directory to the ZIP archive
-func addDirToZip(zipWriter *zip.Writer, sourceDir, basePath string) (int, error) {
+// addDirToArchive adds all files from a directory to the archive under basePath
+func addDirToArchive(addFile func(path, archivePath string, info os.FileInfo) error, sourceDir, basePath string) (int, error) {
 	filesAdded := 0
 
-	err := filepath.Walk(sourceDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			// Skip files we can't access
-			return nil
+	err := services.WalkExportFiles(sourceDir, func(path, relPath string, info os.FileInfo) error {
+		// Use forward slashes in archive paths
+		if err := addFile(path, filepath.ToSlash(filepath.Join(basePath, relPath)), info); err != nil {
+			return err
 		}
+		filesAdded++
+		return nil
+	})
 
-		// Skip directories
-		if info.IsDir() {
-			return nil
-		}
+	return filesAdded, err
+}
 
-		// Get relative path from source directory
-		relPath, err := filepath.Rel(sourceDir, path)
-		if err != nil {
-			return err
-		}
+// addTaggedNotes adds the given notes and the .attachments images they link to under
+// "notes/", keeping their paths relative to notesDir so links still resolve. Images
+// outside notesDir are skipped. Returns the number of notes and attachments added.
+func addTaggedNotes(addFile func(path, archivePath string, info os.FileInfo) error, notesDir string, notes []services.Note) (int, int, error) {
+	notesAdded, attachmentsAdded := 0, 0
+	added := make(map[string]bool)
 
-		// Create ZIP path (use forward slashes for ZIP standard)
-		zipPath := filepath.Join(basePath, relPath)
-		zipPath = filepath.ToSlash(zipPath)
+	add := func(path string) (bool, error) {
+		relPath, err := filepath.Rel(notesDir, path)
+		if err != nil || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+			fmt.Printf("⚠ Skipping %s: outside the notes directory\n", path)
+			return false, nil
+		}
+		if added[relPath] {
+			return false, nil
+		}
 
-		// Create file in ZIP
-		writer, err := zipWriter.Create(zipPath)
+		info, err := os.Stat(path)
 		if err != nil {
-			return fmt.Errorf("failed to create file in ZIP: %w", err)
+			return false, fmt.Errorf("failed to read %s: %w", relPath, err)
 		}
+		if err := addFile(path, filepath.ToSlash(filepath.Join("notes", relPath)), info); err != nil {
+			return false, err
+		}
+		added[relPath] = true
+		return true, nil
+	}
 
-		// Open source file
-		file, err := os.Open(path)
-		if err != nil {
-			return fmt.Errorf("failed to open source file: %w", err)
+	for _, note := range notes {
+		if ok, err := add(note.FilePath); err != nil {
+			return notesAdded, attachmentsAdded, err
+		} else if ok {
+			notesAdded++
 		}
-		defer file.Close()
 
-		// Copy file contents to ZIP
-		_, err = io.Copy(writer, file)
+		attachments, err := services.NoteAttachments(note.FilePath)
 		if err != nil {
-			return fmt.Errorf("failed to write file to ZIP: %w", err)
+			return notesAdded, attachmentsAdded, err
+		}
+		for _, attachment := range attachments {
+			if ok, err := add(attachment); err != nil {
+				return notesAdded, attachmentsAdded, err
+			} else if ok {
+				attachmentsAdded++
+			}
 		}
+	}
 
-		filesAdded++
-		return nil
+	return notesAdded, attachmentsAdded, nil
+}
+
+// addFileToZip copies a file into the ZIP archive at archivePath, keeping its
+// modification time so import can tell which copy is newer
+func addFileToZip(zipWriter *zip.Writer, path, archivePath string, info os.FileInfo) error {
+	// Create file in ZIP
+	writer, err := zipWriter.CreateHeader(&zip.FileHeader{
+		Name:     archivePath,
+		Method:   zip.Deflate,
+		Modified: info.ModTime(),
 	})
+	if err != nil {
+		return fmt.Errorf("failed to create file in ZIP: %w", err)
+	}
 
-	return filesAdded, err
+	// Open source file
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open source file: %w", err)
+	}
+	defer file.Close()
+
+	// Copy file contents to ZIP
+	if _, err := io.Copy(writer, file); err != nil {
+		return fmt.Errorf("failed to write file to ZIP: %w", err)
+	}
+
+	return nil
+}
+
+// addFileToTarGz copies a file into the tar archive at archivePath, keeping its
+// mode and modification time
+func addFileToTarGz(tarWriter *tar.Writer, path, archivePath string, info os.FileInfo) error {
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return fmt.Errorf("failed to create tar header: %w", err)
+	}
+	header.Name = archivePath
+
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write tar header: %w", err)
+	}
+
+	// Open source file
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open source file: %w", err)
+	}
+	defer file.Close()
+
+	// Copy file contents to the archive
+	if _, err := io.Copy(tarWriter, file); err != nil {
+		return fmt.Errorf("failed to write file to tar: %w", err)
+	}
+
+	return nil
 }
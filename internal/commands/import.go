@@ -2,11 +2,13 @@ package commands
 
 import (
 	"archive/zip"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/redjax/notetkr/internal/config"
 	"github.com/spf13/cobra"
@@ -16,13 +18,17 @@ import (
 func NewImportCmd(getConfig func() *config.Config) *cobra.Command {
 	var filePath string
 	var importTypes []string
+	var dryRun bool
+	var manifestPath string
 
 	cmd := &cobra.Command{
 		Use:   "import",
 		Short: "Import notes and journals from a ZIP archive",
 		Long: `Import notes and journals from a ZIP archive created by the export command.
 Merges with existing data, keeping the newer version of any duplicate files.
-Use -t/--import-type to specify what to import (notes, journals, or both).`,
+Use -t/--import-type to specify what to import (notes, journals, or both).
+Use --dry-run to see what would change without writing anything, and --manifest to
+save a JSON record of every imported, updated, and skipped file.`,
 		Run: func(cmd *cobra.Command, args []string) {
 			cfg := getConfig()
 			if filePath == "" {
@@ -30,7 +36,7 @@ Use -t/--import-type to specify what to import (notes, journals, or both).`,
 				cmd.Usage()
 				os.Exit(1)
 			}
-			if err := runImport(cfg, filePath, importTypes); err != nil {
+			if err := runImport(cfg, filePath, importTypes, dryRun, manifestPath); err != nil {
 				fmt.Fprintf(os.Stderr, "Error importing data: %v\n", err)
 				os.Exit(1)
 			}
@@ -39,12 +45,30 @@ Use -t/--import-type to specify what to import (notes, journals, or both).`,
 
 	cmd.Flags().StringVarP(&filePath, "file", "f", "", "Path to the ZIP file to import (required)")
 	cmd.Flags().StringSliceVarP(&importTypes, "import-type", "t", []string{}, "What to import: notes, journals (default: both)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report what would be imported without writing any files")
+	cmd.Flags().StringVar(&manifestPath, "manifest", "", "Write a JSON list of the imported, updated, and skipped files to this path")
 	cmd.MarkFlagRequired("file")
 
 	return cmd
 }
 
-func runImport(cfg *config.Config, zipPath string, importTypes []string) error {
+// importManifest records what an import did (or, in a dry run, would do)
+type importManifest struct {
+	Archive  string                `json:"archive"`
+	DryRun   bool                  `json:"dry_run"`
+	Imported []importManifestEntry `json:"imported"`
+	Updated  []importManifestEntry `json:"updated"`
+	Skipped  []importManifestEntry `json:"skipped"`
+}
+
+// importManifestEntry is one file from the archive and where it was extracted to
+type importManifestEntry struct {
+	Path            string     `json:"path"`
+	ArchiveModTime  time.Time  `json:"archive_mod_time"`
+	ExistingModTime *time.Time `json:"existing_mod_time,omitempty"` // Unset for new files
+}
+
+func runImport(cfg *config.Config, zipPath string, importTypes []string, dryRun bool, manifestPath string) error {
 	// Determine what to import
 	importNotes := true
 	importJournals := true
@@ -72,9 +96,13 @@ func runImport(cfg *config.Config, zipPath string, importTypes []string) error {
 	}
 	defer reader.Close()
 
-	filesImported := 0
-	filesSkipped := 0
-	filesUpdated := 0
+	manifest := importManifest{
+		Archive:  zipPath,
+		DryRun:   dryRun,
+		Imported: []importManifestEntry{},
+		Updated:  []importManifestEntry{},
+		Skipped:  []importManifestEntry{},
+	}
 
 	// Process each file in the ZIP
 	for _, file := range reader.File {
@@ -110,38 +138,71 @@ func runImport(cfg *config.Config, zipPath string, importTypes []string) error {
 
 		// Check if file exists and compare modification times
 		shouldExtract := true
+		entry := importManifestEntry{Path: destPath, ArchiveModTime: file.Modified}
 
 		if stat, err := os.Stat(destPath); err == nil {
 			// File exists, compare modification times
 			existingModTime := stat.ModTime()
 			zipModTime := file.Modified
+			entry.ExistingModTime = &existingModTime
 
 			if zipModTime.Before(existingModTime) || zipModTime.Equal(existingModTime) {
 				// Existing file is newer or same age, skip
 				shouldExtract = false
-				filesSkipped++
+				manifest.Skipped = append(manifest.Skipped, entry)
 			} else {
-				filesUpdated++
+				manifest.Updated = append(manifest.Updated, entry)
 			}
 		} else {
-			filesImported++
+			manifest.Imported = append(manifest.Imported, entry)
 		}
 
-		if shouldExtract {
+		if shouldExtract && !dryRun {
 			if err := extractFile(file, destPath); err != nil {
 				return fmt.Errorf("failed to extract %s: %w", file.Name, err)
 			}
 		}
 	}
 
-	fmt.Printf("✓ Import complete:\n")
-	fmt.Printf("  - %d new file(s) imported\n", filesImported)
-	fmt.Printf("  - %d file(s) updated (newer version)\n", filesUpdated)
-	fmt.Printf("  - %d file(s) skipped (existing version is newer)\n", filesSkipped)
+	if dryRun {
+		fmt.Printf("🔍 Dry run, no files were written:\n")
+		fmt.Printf("  - %d new file(s) would be imported\n", len(manifest.Imported))
+		fmt.Printf("  - %d file(s) would be updated (newer version)\n", len(manifest.Updated))
+		fmt.Printf("  - %d file(s) would be skipped (existing version is newer)\n", len(manifest.Skipped))
+	} else {
+		fmt.Printf("✓ Import complete:\n")
+		fmt.Printf("  - %d new file(s) imported\n", len(manifest.Imported))
+		fmt.Printf("  - %d file(s) updated (newer version)\n", len(manifest.Updated))
+		fmt.Printf("  - %d file(s) skipped (existing version is newer)\n", len(manifest.Skipped))
+	}
+
+	if manifestPath != "" {
+		if err := writeImportManifest(manifest, manifestPath); err != nil {
+			return err
+		}
+		fmt.Printf("  → Manifest written to %s\n", manifestPath)
+	}
 
 	return nil
 }
 
+// writeImportManifest saves the manifest as indented JSON
+func writeImportManifest(manifest importManifest, path string) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create manifest directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
+
 // extractFile extracts a single file from the ZIP archive
 func extractFile(zipFile *zip.File, destPath string) error {
 	// Create destination directory if it doesn't exist
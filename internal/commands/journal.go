@@ -1,11 +1,16 @@
 package commands
 
 import (
+	"bufio"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-isatty"
 	"github.com/redjax/notetkr/internal/config"
+	"github.com/redjax/notetkr/internal/services"
 	"github.com/redjax/notetkr/internal/tui"
 	"github.com/spf13/cobra"
 )
@@ -23,23 +28,84 @@ func NewJournalCmd(getConfig func() *config.Config) *cobra.Command {
 	}
 
 	// Add today subcommand
+	var readOnly bool
 	todayCmd := &cobra.Command{
 		Use:   "today",
 		Short: "Open today's journal entry",
-		Long:  `Opens today's journal entry directly in the editor.`,
+		Long:  `Opens today's journal entry directly in the editor, or in the read-only view with --read-only (or journal.readonly in the config).`,
 		Run: func(cmd *cobra.Command, args []string) {
 			cfg := getConfig()
+			if cmd.Flags().Changed("read-only") {
+				cfg.JournalTodayReadOnly = readOnly
+			}
 			runTodayJournal(cfg)
 		},
 	}
+	todayCmd.Flags().BoolVarP(&readOnly, "read-only", "r", false, "Open today's journal in the read-only view first")
 	cmd.AddCommand(todayCmd)
 
+	// Add add subcommand
+	addCmd := &cobra.Command{
+		Use:   "add [text]",
+		Short: "Add a task to today's journal entry",
+		Long: `Appends text as a bullet at the end of the ## Tasks section of today's journal entry,
+creating the entry if it doesn't exist yet. With no text, each line piped to stdin is added.`,
+		Example: `  nt journal add "Review the quarterly report"
+  git log --oneline -3 | nt journal add`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := getConfig()
+			if err := runJournalAdd(cfg, args); err != nil {
+				fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	cmd.AddCommand(addCmd)
+
+	// Add rm subcommand
+	var skipConfirm bool
+	rmCmd := &cobra.Command{
+		Use:   "rm <date>",
+		Short: "Delete a journal entry",
+		Long:  `Deletes the journal entry for a date (YYYY-MM-DD). Asks for confirmation unless --yes is given.`,
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := getConfig()
+			if err := runJournalRemove(cfg, args[0], skipConfirm); err != nil {
+				fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	rmCmd.Flags().BoolVarP(&skipConfirm, "yes", "y", false, "Delete without asking for confirmation")
+	cmd.AddCommand(rmCmd)
+
+	// Add template subcommand
+	templateCmd := &cobra.Command{
+		Use:   "template",
+		Short: "Create a journal template to edit",
+		Long: `Writes the default journal template to the journal.template path and prints it. New journal
+entries start from that file once it exists. An existing template is never overwritten.
+
+Placeholders: {{date}} (2006-01-02), {{weekday}} (Monday), {{longdate}} (January 2, 2006)
+and {{links}} (links to the previous and next day's entries).`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := getConfig()
+			if err := runJournalTemplate(cfg); err != nil {
+				fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	cmd.AddCommand(templateCmd)
+
 	return cmd
 }
 
 func runJournal(cfg *config.Config) {
 	// Open directly to journals view
-	app := tui.NewJournalBrowserApp(cfg.JournalDir, cfg.NotesDir)
+	app := tui.NewJournalBrowserApp(cfg)
 
 	// Start the TUI
 	p := tea.NewProgram(app, tea.WithAltScreen())
@@ -52,7 +118,7 @@ func runJournal(cfg *config.Config) {
 
 func runTodayJournal(cfg *config.Config) {
 	// Open directly to today's journal entry
-	app := tui.NewTodayJournalApp(cfg.JournalDir, cfg.NotesDir)
+	app := tui.NewTodayJournalApp(cfg)
 
 	// Start the TUI
 	p := tea.NewProgram(app, tea.WithAltScreen())
@@ -62,3 +128,90 @@ func runTodayJournal(cfg *config.Config) {
 		os.Exit(1)
 	}
 }
+
+func runJournalAdd(cfg *config.Config, args []string) error {
+	var items []string
+	if len(args) > 0 {
+		items = []string{strings.Join(args, " ")}
+	} else if !isatty.IsTerminal(os.Stdin.Fd()) && !isatty.IsCygwinTerminal(os.Stdin.Fd()) {
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			items = append(items, scanner.Text())
+		}
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("failed to read stdin: %w", err)
+		}
+	}
+
+	hasText := false
+	for _, item := range items {
+		if strings.TrimSpace(item) != "" {
+			hasText = true
+			break
+		}
+	}
+	if !hasText {
+		return fmt.Errorf("nothing to add: pass the text as an argument or pipe it to stdin")
+	}
+
+	journalService := newJournalService(cfg)
+
+	journalPath, err := journalService.AddTasks(time.Now(), items)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Added to today's journal: %s\n", journalPath)
+	return nil
+}
+
+func runJournalRemove(cfg *config.Config, dateStr string, skipConfirm bool) error {
+	date, err := time.Parse("2006-01-02", dateStr)
+	if err != nil {
+		return fmt.Errorf("invalid date '%s': expected YYYY-MM-DD", dateStr)
+	}
+
+	journalService := newJournalService(cfg)
+	journalPath := journalService.GetJournalPathForDate(date)
+
+	if _, err := os.Stat(journalPath); os.IsNotExist(err) {
+		return fmt.Errorf("no journal entry exists for %s", date.Format("2006-01-02"))
+	}
+
+	if !skipConfirm {
+		fmt.Printf("Delete journal entry %s? [y/N]: ", journalPath)
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		if answer != "y" && answer != "yes" {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	removed, err := journalService.DeleteJournalForDate(date)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Deleted %s\n", removed)
+	return nil
+}
+
+func runJournalTemplate(cfg *config.Config) error {
+	if cfg.JournalTemplate == "" {
+		return fmt.Errorf("no journal template path: set journal.template in the config")
+	}
+
+	journalService := newJournalService(cfg)
+	if err := journalService.WriteDefaultTemplate(cfg.JournalTemplate); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Created journal template: %s\n", cfg.JournalTemplate)
+	return nil
+}
+
+// newJournalService creates a journal service with the journal settings cfg sets
+func newJournalService(cfg *config.Config) *services.JournalService {
+	return services.NewJournalServiceWithOptions(cfg.JournalDir, services.JournalOptionsFromConfig(cfg))
+}
@@ -0,0 +1,129 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/redjax/notetkr/internal/config"
+	"github.com/redjax/notetkr/internal/services"
+	"github.com/spf13/cobra"
+)
+
+// NewMigrateCmd creates the migrate command
+func NewMigrateCmd(getConfig func() *config.Config) *cobra.Command {
+	var fromDir string
+	var toDir string
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Move your notes and journals to a new data directory",
+		Long: `Moves everything under the old data directory (notes, journals and attachments) to the new one,
+keeping the same layout, then points data.dir (and notes.dir, journal.dir, journal.template, preview.dir and
+preview.dictionary if they were inside it) at the new location in the config file, which later runs read.
+Nothing is moved if a NOTETKR_ environment variable would override the new paths.
+--from defaults to the current data directory. Works across filesystems.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := getConfig()
+			if fromDir == "" {
+				fromDir = cfg.DataDir
+			}
+			if err := runMigrate(cfg, fromDir, toDir); err != nil {
+				fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&fromDir, "from", "", "Old data directory (default: the configured data directory)")
+	cmd.Flags().StringVar(&toDir, "to", "", "New data directory (required)")
+	cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+func runMigrate(cfg *config.Config, fromDir, toDir string) error {
+	fromDir, err := filepath.Abs(fromDir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", fromDir, err)
+	}
+	toDir, err = filepath.Abs(toDir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", toDir, err)
+	}
+
+	// Check the new directories can be recorded, and will be used, before moving anything
+	values := migratedConfigValues(cfg, fromDir, toDir)
+	if err := checkMigratedConfig(cfg.ConfigFile, values); err != nil {
+		return err
+	}
+
+	fmt.Printf("🔍 Moving data from %s to %s\n", fromDir, toDir)
+
+	// Leave the config file in place; it's updated below
+	summary, err := services.MigrateDataDir(fromDir, toDir, []string{cfg.ConfigFile}, func(relPath string) {
+		fmt.Printf("  → %s\n", relPath)
+	})
+	if err != nil {
+		return fmt.Errorf("migration stopped after %d file(s): %w", summary.Files, err)
+	}
+	fmt.Printf("✓ Moved %d file(s) (%d bytes)\n", summary.Files, summary.Bytes)
+
+	if err := config.UpdateConfigFile(cfg.ConfigFile, values); err != nil {
+		return fmt.Errorf("files were moved but the config could not be updated: %w", err)
+	}
+	fmt.Printf("✓ Updated %s\n", cfg.ConfigFile)
+
+	return nil
+}
+
+// checkMigratedConfig makes sure the config file can be updated with values and that
+// the next run will read them from it: the file must be in a supported format, and no
+// NOTETKR_ environment variable may override them
+func checkMigratedConfig(configFile string, values map[string]interface{}) error {
+	if _, err := config.EnsureConfigFile(configFile); err != nil {
+		return fmt.Errorf("nothing was moved: %w", err)
+	}
+	if _, err := config.LoadConfig(configFile); err != nil {
+		return fmt.Errorf("nothing was moved: %w", err)
+	}
+
+	for key := range values {
+		if name := config.EnvVarFor(key); os.Getenv(name) != "" {
+			return fmt.Errorf("nothing was moved: %s is set and would override %s in %s; unset it first", name, key, configFile)
+		}
+	}
+	return nil
+}
+
+// migratedConfigValues returns the path settings pointing at toDir. Paths other than
+// data.dir only change if they were inside fromDir.
+func migratedConfigValues(cfg *config.Config, fromDir, toDir string) map[string]interface{} {
+	values := map[string]interface{}{"data.dir": toDir}
+
+	dirs := map[string]string{
+		"notes.dir":          cfg.NotesDir,
+		"journal.dir":        cfg.JournalDir,
+		"journal.template":   cfg.JournalTemplate,
+		"preview.dir":        cfg.PreviewDir,
+		"preview.dictionary": cfg.PreviewDictionary,
+	}
+	for key, dir := range dirs {
+		if dir == "" {
+			continue // Unset, e.g. preview.dir using the system temp directory
+		}
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			continue
+		}
+		rel, err := filepath.Rel(fromDir, abs)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			fmt.Printf("⚠ %s (%s) is outside %s and was not moved\n", key, dir, fromDir)
+			continue
+		}
+		values[key] = filepath.Join(toDir, rel)
+	}
+
+	return values
+}
@@ -1,12 +1,23 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-isatty"
 	"github.com/redjax/notetkr/internal/config"
+	"github.com/redjax/notetkr/internal/services"
 	"github.com/redjax/notetkr/internal/tui"
+	"github.com/redjax/notetkr/internal/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -22,18 +33,572 @@ func NewNotesCmd(getConfig func() *config.Config) *cobra.Command {
 		},
 	}
 
+	// Add new subcommand
+	var category, templateName string
+	var tags []string
+	var openEditor bool
+	newCmd := &cobra.Command{
+		Use:   "new <name>",
+		Short: "Create a note without opening the notes browser",
+		Long: `Creates a note (.md optional) and prints its path. Use --category to put it in a
+subdirectory of the notes directory, --template to start from one of your templates,
+--tag to fill in its tags, and --edit to open it in $EDITOR.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := getConfig()
+			if err := runNewNote(cfg, args[0], category, templateName, tags, openEditor); err != nil {
+				fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	newCmd.Flags().StringVar(&category, "category", "", "Subdirectory of the notes directory to create the note in")
+	newCmd.Flags().StringVar(&templateName, "template", "", "Name of the template to create the note from")
+	newCmd.Flags().StringSliceVar(&tags, "tag", []string{}, "Tags to add to the note's frontmatter (comma-separated or repeated)")
+	newCmd.Flags().BoolVarP(&openEditor, "edit", "e", false, "Open the new note in $EDITOR")
+	cmd.AddCommand(newCmd)
+
+	// Add ls subcommand
+	var lsCategory string
+	var asJSON bool
+	lsCmd := &cobra.Command{
+		Use:   "ls",
+		Short: "List notes as plain text",
+		Long: `Lists the notes in the notes directory (or in --category) newest first, one per line:
+name, comma-separated tags, and modification time, separated by tabs. Use --json for a JSON array
+of the notes with all their metadata.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := getConfig()
+			if err := runNotesList(cfg, lsCategory, asJSON); err != nil {
+				fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	lsCmd.Flags().StringVar(&lsCategory, "category", "", "Subdirectory of the notes directory to list")
+	lsCmd.Flags().BoolVar(&asJSON, "json", false, "Print the notes as a JSON array")
+	cmd.AddCommand(lsCmd)
+
+	// Add recent subcommand
+	var recentLimit int
+	recentCmd := &cobra.Command{
+		Use:   "recent",
+		Short: "List the most recently modified notes",
+		Long: `Lists the most recently modified notes from every category, newest first, in the same
+format as ls: name (path relative to the notes directory), comma-separated tags, and modification time.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := getConfig()
+			if err := runRecentNotes(cfg, recentLimit); err != nil {
+				fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	recentCmd.Flags().IntVarP(&recentLimit, "number", "n", 10, "How many notes to list")
+	cmd.AddCommand(recentCmd)
+
+	// Add search subcommand
+	var useRegex, caseSensitive bool
+	searchCmd := &cobra.Command{
+		Use:   "search <name> <query>",
+		Short: "Find matching lines in a single note",
+		Long:  `Lists the lines of one note (path relative to the notes directory, .md optional) that match a query, with their line numbers.`,
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := getConfig()
+			opts := services.SearchOptions{Regex: useRegex, CaseSensitive: caseSensitive}
+			if err := runNoteSearch(cfg, args[0], args[1], opts); err != nil {
+				fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	searchCmd.Flags().BoolVar(&useRegex, "regex", false, "Treat the query as a regular expression")
+	searchCmd.Flags().BoolVar(&caseSensitive, "case-sensitive", false, "Match case exactly")
+	cmd.AddCommand(searchCmd)
+
+	// Add fix-frontmatter subcommand
+	var dryRun bool
+	fixCmd := &cobra.Command{
+		Use:   "fix-frontmatter",
+		Short: "Rewrite every note's frontmatter in a consistent format",
+		Long: `Parses each note's YAML frontmatter and writes it back in canonical form: --- delimiters,
+tags and keywords keys always present as comma-separated lists, and two-space indentation.
+The note body is left exactly as it was. Notes whose frontmatter can't be parsed are reported and skipped.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := getConfig()
+			if err := runFixFrontMatter(cfg, dryRun); err != nil {
+				fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	fixCmd.Flags().BoolVar(&dryRun, "dry-run", false, "List the notes that would change without writing them")
+	cmd.AddCommand(fixCmd)
+
+	// Add check-links subcommand
+	var online bool
+	var timeout, delay time.Duration
+	checkLinksCmd := &cobra.Command{
+		Use:   "check-links",
+		Short: "List the web links in your notes and find broken ones",
+		Long: `Lists every http(s) link in your notes with its file and line, flagging malformed ones.
+With --online, each link is also requested (HEAD, falling back to GET) and dead links are reported.
+Requests are made one at a time, --delay apart, and give up after --timeout.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := getConfig()
+			if err := runCheckLinks(cfg, online, timeout, delay); err != nil {
+				fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	checkLinksCmd.Flags().BoolVar(&online, "online", false, "Request each link and report the dead ones")
+	checkLinksCmd.Flags().DurationVar(&timeout, "timeout", 10*time.Second, "How long to wait for each link to respond")
+	checkLinksCmd.Flags().DurationVar(&delay, "delay", 250*time.Millisecond, "Pause between requests")
+	cmd.AddCommand(checkLinksCmd)
+
+	cmd.AddCommand(newTemplateCmd(getConfig))
+	cmd.AddCommand(newTagCmd(getConfig))
+
+	return cmd
+}
+
+// newTagCmd creates the notes tag command and its subcommands
+func newTagCmd(getConfig func() *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tag",
+		Short: "Manage tags across all notes",
+		Long:  `Change the tags used across every note at once, without opening the notes browser.`,
+	}
+
+	// Add rename subcommand
+	var dryRun bool
+	renameCmd := &cobra.Command{
+		Use:   "rename <old> <new>",
+		Short: "Rename or merge a tag in every note",
+		Long: `Renames a tag in every note's frontmatter tags (inline or as a YAML list) and in #tags in the text,
+leaving code blocks alone. Renaming onto a tag a note already has merges the two. Each changed note is
+listed with its tags before and after. Templates and archived notes are left as they are.`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := getConfig()
+			if err := runRenameTag(cfg, args[0], args[1], dryRun); err != nil {
+				fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	renameCmd.Flags().BoolVar(&dryRun, "dry-run", false, "List the notes that would change without writing them")
+	cmd.AddCommand(renameCmd)
+
+	return cmd
+}
+
+// newTemplateCmd creates the notes template command and its subcommands
+func newTemplateCmd(getConfig func() *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "template",
+		Short: "Manage note templates",
+		Long:  `Create and list the templates new notes can start from, without opening the notes browser.`,
+	}
+
+	// Add new subcommand
+	newCmd := &cobra.Command{
+		Use:   "new <name>",
+		Short: "Create a template",
+		Long: `Creates a template (.md optional) in the templates directory and prints its path.
+The template is empty unless its content is piped to stdin. Existing templates are never overwritten.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := getConfig()
+			if err := runNewTemplate(cfg, args[0]); err != nil {
+				fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	cmd.AddCommand(newCmd)
+
+	// Add ls subcommand
+	lsCmd := &cobra.Command{
+		Use:   "ls",
+		Short: "List templates as plain text",
+		Long:  `Lists the templates, including the built-in ones, one per line: name and modification time, separated by a tab.`,
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := getConfig()
+			if err := runTemplateList(cfg); err != nil {
+				fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	cmd.AddCommand(lsCmd)
+
 	return cmd
 }
 
 func runNotes(cfg *config.Config) {
 	// Open directly to notes view
-	app := tui.NewNotesBrowserApp(cfg.JournalDir, cfg.NotesDir)
+	app := tui.NewNotesBrowserApp(cfg)
 
 	// Start the TUI
-	p := tea.NewProgram(app, tea.WithAltScreen())
+	p := tea.NewProgram(app, tea.WithAltScreen(), tea.WithReportFocus())
 
 	if _, err := p.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error running notes TUI: %v\n", err)
 		os.Exit(1)
 	}
 }
+
+func runNoteSearch(cfg *config.Config, name, query string, opts services.SearchOptions) error {
+	notesService := newNotesService(cfg)
+
+	matches, err := notesService.SearchInNote(name, query, opts)
+	if err != nil {
+		return err
+	}
+
+	if len(matches) == 0 {
+		fmt.Printf("🔍 No lines in %s match %q\n", name, query)
+		return nil
+	}
+
+	// Pad line numbers to the widest one so the text lines up
+	width := len(fmt.Sprint(matches[len(matches)-1].Line))
+	fmt.Printf("🔍 %d matching line(s) in %s:\n\n", len(matches), name)
+	for _, m := range matches {
+		fmt.Printf("  %*d: %s\n", width, m.Line, m.Text)
+	}
+
+	return nil
+}
+
+func runFixFrontMatter(cfg *config.Config, dryRun bool) error {
+	notesService := newNotesService(cfg)
+
+	fmt.Printf("🔍 Checking frontmatter in %s\n", cfg.NotesDir)
+	result, err := notesService.FixFrontMatter(dryRun)
+	if err != nil {
+		return err
+	}
+
+	for _, relPath := range result.Changed {
+		fmt.Printf("  → %s\n", relPath)
+	}
+
+	failed := make([]string, 0, len(result.Failed))
+	for relPath := range result.Failed {
+		failed = append(failed, relPath)
+	}
+	sort.Strings(failed)
+	for _, relPath := range failed {
+		fmt.Printf("⚠ %s: %v\n", relPath, result.Failed[relPath])
+	}
+
+	if dryRun {
+		fmt.Printf("✓ %d of %d note(s) would be rewritten (dry run)\n", len(result.Changed), result.Checked)
+	} else {
+		fmt.Printf("✓ Rewrote %d of %d note(s)\n", len(result.Changed), result.Checked)
+	}
+	return nil
+}
+
+func runRenameTag(cfg *config.Config, oldTag, newTag string, dryRun bool) error {
+	notesService := newNotesService(cfg)
+
+	result, err := notesService.RenameTagWithReport(oldTag, newTag, dryRun)
+	if err != nil {
+		return err
+	}
+
+	for _, change := range result.Changed {
+		fmt.Printf("  → %s: %s → %s\n", change.RelPath, strings.Join(change.Before, ", "), strings.Join(change.After, ", "))
+	}
+
+	failed := make([]string, 0, len(result.Failed))
+	for relPath := range result.Failed {
+		failed = append(failed, relPath)
+	}
+	sort.Strings(failed)
+	for _, relPath := range failed {
+		fmt.Printf("⚠ %s: %v\n", relPath, result.Failed[relPath])
+	}
+
+	if dryRun {
+		fmt.Printf("✓ %d of %d note(s) would be changed (dry run)\n", len(result.Changed), result.Checked)
+	} else {
+		fmt.Printf("✓ Changed %d of %d note(s)\n", len(result.Changed), result.Checked)
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to update %d note(s)", len(failed))
+	}
+	return nil
+}
+
+func runNotesList(cfg *config.Config, category string, asJSON bool) error {
+	notesService := newNotesService(cfg)
+
+	notes, _, err := notesService.ListNotesInPath(category)
+	switch {
+	case os.IsNotExist(err) && category != "":
+		return fmt.Errorf("category not found: %s", category)
+	case os.IsNotExist(err):
+		// No notes directory yet, so no notes
+	case err != nil:
+		return fmt.Errorf("failed to list notes: %w", err)
+	}
+
+	if asJSON {
+		if notes == nil {
+			notes = []services.Note{}
+		}
+		data, err := json.MarshalIndent(notes, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode notes: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	for _, note := range notes {
+		fmt.Printf("%s\t%s\t%s\n", note.Name, strings.Join(note.Tags, ","), note.ModTime.Format("2006-01-02 15:04"))
+	}
+	return nil
+}
+
+func runRecentNotes(cfg *config.Config, limit int) error {
+	if limit < 1 {
+		return fmt.Errorf("--number must be at least 1")
+	}
+
+	notesService := newNotesService(cfg)
+	notes, err := notesService.ListRecentNotes(limit)
+	if err != nil {
+		return fmt.Errorf("failed to list notes: %w", err)
+	}
+
+	for _, note := range notes {
+		fmt.Printf("%s\t%s\t%s\n", note.Name, strings.Join(note.Tags, ","), note.ModTime.Format("2006-01-02 15:04"))
+	}
+	return nil
+}
+
+func runNewNote(cfg *config.Config, name, category, templateName string, tags []string, openEditor bool) error {
+	notesService := newNotesService(cfg)
+
+	if !strings.HasSuffix(name, ".md") {
+		name += ".md"
+	}
+	category = filepath.Clean(category)
+	if category == "." {
+		category = ""
+	}
+	if filepath.IsAbs(category) || strings.HasPrefix(category, "..") {
+		return fmt.Errorf("category must be a path inside the notes directory: %s", category)
+	}
+	if _, err := os.Stat(filepath.Join(cfg.NotesDir, category, name)); err == nil {
+		return fmt.Errorf("a note named '%s' already exists", filepath.Join(category, name))
+	}
+
+	var filePath string
+	var err error
+	if templateName != "" {
+		templatePath, err := findTemplate(notesService, templateName)
+		if err != nil {
+			return err
+		}
+		filePath, err = notesService.CreateNoteFromTemplateInPath(name, templatePath, category)
+		if err != nil {
+			return fmt.Errorf("failed to create note: %w", err)
+		}
+	} else {
+		filePath, err = notesService.CreateNoteInPath(name, category)
+		if err != nil {
+			return fmt.Errorf("failed to create note: %w", err)
+		}
+	}
+
+	if len(tags) > 0 {
+		content, err := notesService.ReadNote(filePath)
+		if err != nil {
+			return err
+		}
+		tagged, err := services.AddFrontMatterTags(content, tags)
+		if err != nil {
+			return fmt.Errorf("failed to add tags to %s: %w", filePath, err)
+		}
+		if err := notesService.WriteNote(filePath, tagged); err != nil {
+			return fmt.Errorf("failed to add tags to %s: %w", filePath, err)
+		}
+	}
+
+	fmt.Println(filePath)
+
+	if openEditor {
+		editor := exec.Command(utils.EditorCommand(), filePath)
+		editor.Stdin = os.Stdin
+		editor.Stdout = os.Stdout
+		editor.Stderr = os.Stderr
+		if err := editor.Run(); err != nil {
+			return fmt.Errorf("failed to open editor: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// findTemplate returns the path of the template called name (.md optional)
+func runNewTemplate(cfg *config.Config, name string) error {
+	notesService := newNotesService(cfg)
+
+	fileName, err := services.TemplateFileName(name)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(notesService.GetTemplatesDir(), fileName)); err == nil {
+		return fmt.Errorf("a template named '%s' already exists", fileName)
+	}
+
+	var content string
+	if !isatty.IsTerminal(os.Stdin.Fd()) && !isatty.IsCygwinTerminal(os.Stdin.Fd()) {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read stdin: %w", err)
+		}
+		content = string(data)
+	}
+
+	filePath, err := notesService.CreateTemplate(fileName, content)
+	if err != nil {
+		return fmt.Errorf("failed to create template: %w", err)
+	}
+
+	fmt.Println(filePath)
+	return nil
+}
+
+func runTemplateList(cfg *config.Config) error {
+	notesService := newNotesService(cfg)
+
+	// Like the notes browser, make sure the built-in templates are available
+	if err := notesService.InitializeDefaultTemplates(); err != nil {
+		return fmt.Errorf("failed to set up default templates: %w", err)
+	}
+
+	templates, err := notesService.ListTemplates()
+	if err != nil {
+		return fmt.Errorf("failed to list templates: %w", err)
+	}
+
+	for _, template := range templates {
+		fmt.Printf("%s\t%s\n", strings.TrimSuffix(template.Name, ".md"), template.ModTime.Format("2006-01-02 15:04"))
+	}
+	return nil
+}
+
+func findTemplate(notesService *services.NotesService, name string) (string, error) {
+	// Like the notes browser, make sure the built-in templates are available
+	if err := notesService.InitializeDefaultTemplates(); err != nil {
+		return "", fmt.Errorf("failed to set up default templates: %w", err)
+	}
+
+	templates, err := notesService.ListTemplates()
+	if err != nil {
+		return "", fmt.Errorf("failed to list templates: %w", err)
+	}
+
+	var names []string
+	for _, template := range templates {
+		if template.Name == name || strings.TrimSuffix(template.Name, ".md") == name {
+			return template.FilePath, nil
+		}
+		names = append(names, strings.TrimSuffix(template.Name, ".md"))
+	}
+
+	if len(names) == 0 {
+		return "", fmt.Errorf("template not found: %s", name)
+	}
+	return "", fmt.Errorf("template not found: %s (available: %s)", name, strings.Join(names, ", "))
+}
+
+func runCheckLinks(cfg *config.Config, online bool, timeout, delay time.Duration) error {
+	notesService := newNotesService(cfg)
+
+	links, err := notesService.FindExternalLinks()
+	if err != nil {
+		return err
+	}
+	if len(links) == 0 {
+		fmt.Println("🔍 No links found in your notes")
+		return nil
+	}
+
+	location := func(link services.ExternalLink) string {
+		relPath, err := filepath.Rel(cfg.NotesDir, link.FilePath)
+		if err != nil {
+			relPath = link.FilePath
+		}
+		return fmt.Sprintf("%s:%d", relPath, link.Line)
+	}
+
+	malformed := 0
+	fmt.Printf("🔍 %d link(s) in your notes:\n\n", len(links))
+	for _, link := range links {
+		if link.Problem != "" {
+			malformed++
+			fmt.Printf("  ⚠ %s  %s (%s)\n", location(link), link.URL, link.Problem)
+		} else if !online {
+			fmt.Printf("  %s  %s\n", location(link), link.URL)
+		}
+	}
+
+	if !online {
+		fmt.Printf("\n✓ Found %d link(s), %d malformed\n", len(links), malformed)
+		return nil
+	}
+
+	// Request each distinct URL once, one at a time
+	client := &http.Client{Timeout: timeout}
+	results := make(map[string]string) // URL -> problem, "" if it's alive
+	dead := 0
+	for _, link := range links {
+		if link.Problem != "" {
+			continue
+		}
+
+		problem, checked := results[link.URL]
+		if !checked {
+			if len(results) > 0 {
+				time.Sleep(delay)
+			}
+			status, err := services.CheckURL(client, link.URL)
+			switch {
+			case err != nil:
+				problem = err.Error()
+			case status >= 400:
+				problem = fmt.Sprintf("%d %s", status, http.StatusText(status))
+			}
+			results[link.URL] = problem
+		}
+
+		if problem != "" {
+			dead++
+			fmt.Printf("  ❌ %s  %s (%s)\n", location(link), link.URL, problem)
+		}
+	}
+
+	fmt.Printf("\n✓ Checked %d link(s): %d dead, %d malformed\n", len(results), dead, malformed)
+	return nil
+}
+
+// newNotesService creates a notes service with the notes settings cfg sets
+func newNotesService(cfg *config.Config) *services.NotesService {
+	return services.NewNotesServiceWithOptions(cfg.NotesDir, services.NotesOptionsFromConfig(cfg))
+}
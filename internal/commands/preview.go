@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/redjax/notetkr/internal/config"
+	"github.com/redjax/notetkr/internal/services"
+	"github.com/spf13/cobra"
+)
+
+// NewPreviewCmd creates the preview command
+func NewPreviewCmd(getConfig func() *config.Config) *cobra.Command {
+	var outputPath string
+	var embedImages bool
+
+	cmd := &cobra.Command{
+		Use:   "preview <file>",
+		Short: "Convert a markdown file to HTML",
+		Long: `Renders a markdown file to the same styled HTML used by the in-app preview and writes it to a file, without opening a browser.
+The output defaults to the markdown file's name with an .html extension.
+With --embed-images, images are embedded as data URIs so the HTML file works on its own.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg := getConfig()
+			if err := runPreview(cfg, args[0], outputPath, embedImages); err != nil {
+				fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "Output path for the HTML file")
+	cmd.Flags().BoolVar(&embedImages, "embed-images", false, "Embed images as data URIs instead of linking to them")
+
+	return cmd
+}
+
+func runPreview(cfg *config.Config, markdownPath, outputPath string, embedImages bool) error {
+	// Use an absolute path so relative images resolve from the note's directory
+	markdownPath, err := filepath.Abs(markdownPath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", markdownPath, err)
+	}
+	if ext := strings.ToLower(filepath.Ext(markdownPath)); ext != ".md" && ext != ".markdown" {
+		return fmt.Errorf("%s is not a markdown file (.md or .markdown)", markdownPath)
+	}
+
+	content, err := os.ReadFile(markdownPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", markdownPath, err)
+	}
+
+	if outputPath == "" {
+		outputPath = strings.TrimSuffix(markdownPath, filepath.Ext(markdownPath)) + ".html"
+	}
+
+	// Turn [[name]] links into links to the notes they name
+	resolved := newNotesService(cfg).ResolveWikiLinks(string(content), markdownPath)
+	if embedImages {
+		resolved, _ = services.InlineImages(resolved, filepath.Dir(markdownPath))
+	}
+
+	previewService := newPreviewService(cfg)
+	if err := previewService.PreviewMarkdownToFile(markdownPath, resolved, outputPath); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Wrote HTML preview to: %s\n", outputPath)
+	return nil
+}
+
+// newPreviewService creates a preview service with the preview settings cfg sets
+func newPreviewService(cfg *config.Config) *services.PreviewService {
+	return services.NewPreviewService(services.PreviewOptionsFromConfig(cfg))
+}
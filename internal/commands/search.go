@@ -12,6 +12,8 @@ import (
 
 // NewSearchCmd creates the search command
 func NewSearchCmd(getConfig func() *config.Config) *cobra.Command {
+	var fullFile bool
+
 	cmd := &cobra.Command{
 		Use:   "search [query]",
 		Short: "Search notes and journals",
@@ -23,16 +25,18 @@ func NewSearchCmd(getConfig func() *config.Config) *cobra.Command {
 			if len(args) > 0 {
 				query = args[0]
 			}
-			runSearch(cfg, query)
+			runSearch(cfg, query, fullFile)
 		},
 	}
 
+	cmd.Flags().BoolVar(&fullFile, "full", false, "Match note content including frontmatter")
+
 	return cmd
 }
 
-func runSearch(cfg *config.Config, query string) {
+func runSearch(cfg *config.Config, query string, fullFile bool) {
 	// Open directly to search view
-	app := tui.NewSearchBrowserApp(cfg.JournalDir, cfg.NotesDir, query)
+	app := tui.NewSearchBrowserApp(cfg, query, fullFile)
 
 	// Start the TUI
 	p := tea.NewProgram(app, tea.WithAltScreen())
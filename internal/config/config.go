@@ -2,9 +2,10 @@ package config
 
 import (
 	"fmt"
-	"log"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/knadh/koanf/parsers/dotenv"
@@ -13,19 +14,103 @@ import (
 	"github.com/knadh/koanf/parsers/yaml"
 	"github.com/knadh/koanf/providers/env"
 	"github.com/knadh/koanf/providers/file"
-	"github.com/knadh/koanf/providers/posflag"
 	"github.com/knadh/koanf/v2"
-	"github.com/spf13/pflag"
 )
 
-var K = koanf.New(".")
-
 // Config holds the application configuration
 type Config struct {
 	ConfigFile string `koanf:"config.file"`
 	NotesDir   string `koanf:"notes.dir"`
 	JournalDir string `koanf:"journal.dir"`
 	DataDir    string `koanf:"data.dir"`
+
+	// SearchSummaries includes saved weekly and monthly journal summaries in search results
+	SearchSummaries bool `koanf:"search.summaries"`
+
+	// ImageJPEGQuality is the quality (1-100) JPEG images pasted from the clipboard are saved at
+	ImageJPEGQuality int `koanf:"images.jpegquality"`
+
+	// MaxImageWidth scales pasted images wider than this many pixels down to it; 0 keeps their size
+	MaxImageWidth int `koanf:"images.maxwidth"`
+
+	// PreviewAttendees renders a note's attendees front matter as a table in the HTML preview
+	PreviewAttendees bool `koanf:"preview.attendees"`
+
+	// PreviewCodeTheme is the chroma style for code blocks in the HTML preview, e.g. "github" or "monokai"
+	PreviewCodeTheme string `koanf:"preview.codetheme"`
+
+	// PreviewSkipShortTOC leaves the table of contents out of previews with fewer than 3 headings
+	PreviewSkipShortTOC bool `koanf:"preview.skipshorttoc"`
+
+	// PreviewEnableMath renders $...$ and $$...$$ math in the HTML preview using MathJax from a CDN
+	PreviewEnableMath bool `koanf:"preview.math"`
+
+	// PreviewStripEmptyHeadings leaves headings with no text, like a new note's "# ", out of the HTML preview
+	PreviewStripEmptyHeadings bool `koanf:"preview.stripemptyheadings"`
+
+	// PreviewSpellCheck underlines words the spell checker doesn't recognise in the HTML preview
+	PreviewSpellCheck bool `koanf:"preview.spellcheck"`
+
+	// PreviewDictionary is a file of extra words for the spell checker to accept, one per line
+	PreviewDictionary string `koanf:"preview.dictionary"`
+
+	// PreviewDir is where browser previews are written; empty uses the system temp directory
+	PreviewDir string `koanf:"preview.dir"`
+
+	// Browser navigation key bindings (see tui.KeyMap)
+	UpKeys   []string `koanf:"keys.up"`
+	PrevKeys []string `koanf:"keys.prev"`
+	NextKeys []string `koanf:"keys.next"`
+	OpenKeys []string `koanf:"keys.open"`
+	QuitKeys []string `koanf:"keys.quit"`
+
+	// CtrlC controls ctrl+c in the editors: "confirm" (ask about unsaved changes) or "quit"
+	CtrlC string `koanf:"editor.ctrlc"`
+
+	// EditorKeymap is the editors' key binding style: "vim" (modal) or "emacs"
+	EditorKeymap string `koanf:"editor.keymap"`
+
+	// PersistUndo saves each note's undo history in a .undo file next to it, so undo survives reopening
+	PersistUndo bool `koanf:"editor.persistundo"`
+
+	// IdleLockMinutes blanks the screen after this many minutes without input, saving and closing an open editor; 0 turns it off
+	IdleLockMinutes int `koanf:"lock.idleminutes"`
+
+	// JournalFilenameFormat is the Go time layout for journal filenames, e.g. "2006.01.02.md"
+	JournalFilenameFormat string `koanf:"journal.format"`
+
+	// NewNoteCancel is where esc at the new-note name prompt returns to: "browser" or "dashboard"
+	NewNoteCancel string `koanf:"notes.cancelto"`
+
+	// NewNoteHeading starts new blank notes with an empty "# " title heading to fill in
+	NewNoteHeading bool `koanf:"notes.titleheading"`
+
+	// NotesSuffixOnClash saves a moved or renamed note as "name-2.md", "name-3.md", ... when its name is taken, instead of failing
+	NotesSuffixOnClash bool `koanf:"notes.suffixonclash"`
+
+	// NotesQuickDelete deletes notes from the notes browser without asking, offering a few seconds to undo instead
+	NotesQuickDelete bool `koanf:"notes.quickdelete"`
+
+	// NotesRefreshOnFocus reloads the notes browser when the terminal window regains focus
+	NotesRefreshOnFocus bool `koanf:"notes.refreshonfocus"`
+
+	// NotesRefreshInterval reloads the notes browser every this many seconds; 0 turns it off
+	NotesRefreshInterval int `koanf:"notes.refreshinterval"`
+
+	// JournalJumpToLatest puts the cursor on the last time section (e.g. "## 14:30") when opening today's journal
+	JournalJumpToLatest bool `koanf:"journal.jumplatest"`
+
+	// WeekStartsOn is the first day of the week for journal week folders and summaries: "sunday" or "monday"
+	WeekStartsOn string `koanf:"journal.weekstart"`
+
+	// JournalTodayReadOnly opens "nt journal today" in the read-only view instead of the editor
+	JournalTodayReadOnly bool `koanf:"journal.readonly"`
+
+	// JournalDailyLinks starts new journal entries with links to the previous and next day's entries
+	JournalDailyLinks bool `koanf:"journal.dailylinks"`
+
+	// JournalTemplate is a file new journal entries start from, with placeholders like {{date}}; entries use the built-in layout while it doesn't exist
+	JournalTemplate string `koanf:"journal.template"`
 }
 
 func DefaultConfig() *Config {
@@ -37,32 +122,74 @@ func DefaultConfig() *Config {
 	dataDir := filepath.Join(homeDir, ".notetkr")
 
 	return &Config{
-		ConfigFile: filepath.Join(dataDir, "notetkr.yml"),
-		DataDir:    dataDir,
-		NotesDir:   filepath.Join(dataDir, "notes"),
-		JournalDir: filepath.Join(dataDir, "journal"),
+		ConfigFile:                filepath.Join(dataDir, "notetkr.yml"),
+		DataDir:                   dataDir,
+		NotesDir:                  filepath.Join(dataDir, "notes"),
+		JournalDir:                filepath.Join(dataDir, "journal"),
+		JournalTemplate:           filepath.Join(dataDir, "journal_template.md"),
+		UpKeys:                    []string{"esc", "h"},
+		PrevKeys:                  []string{"up", "k"},
+		NextKeys:                  []string{"down", "j"},
+		OpenKeys:                  []string{"enter", "l"},
+		QuitKeys:                  []string{"q"},
+		CtrlC:                     "confirm",
+		EditorKeymap:              "vim",
+		JournalFilenameFormat:     "2006-01-02.md",
+		ImageJPEGQuality:          90,
+		NewNoteCancel:             "browser",
+		WeekStartsOn:              "sunday",
+		PreviewCodeTheme:          "github",
+		PreviewSkipShortTOC:       true,
+		PreviewStripEmptyHeadings: true,
+		NotesSuffixOnClash:        true,
+		NewNoteHeading:            true,
 	}
 }
 
-func LoadConfig(flagSet *pflag.FlagSet, configFile string) {
+// LoadConfig builds the configuration from the defaults, then the config file at
+// configFile, then NOTETKR_ environment variables, each overriding the last. Without
+// a configFile the default one (~/.notetkr/notetkr.yml) is read if it exists. The
+// file's format is picked by its extension.
+func LoadConfig(configFile string) (*Config, error) {
+	k := koanf.New(".")
+	cfg := DefaultConfig()
+
+	if configFile == "" {
+		if _, err := os.Stat(cfg.ConfigFile); err == nil {
+			configFile = cfg.ConfigFile
+		}
+	}
 	if configFile != "" {
 		parser, err := parserForFile(configFile)
 		if err != nil {
-			log.Fatalf("unsupported config file format: %v", err)
+			return nil, fmt.Errorf("cannot load %s: %w (supported: .yml, .yaml, .json, .toml, .env)", configFile, err)
 		}
-
-		if err := K.Load(file.Provider(configFile), parser); err != nil {
-			log.Printf("error loading config file: %v", err)
+		if err := k.Load(file.Provider(configFile), parser); err != nil {
+			return nil, fmt.Errorf("failed to parse config file %s: %w", configFile, err)
 		}
 	}
 
 	// Load from environment variables
-	K.Load(env.Provider("NOTETKR_", ".", func(s string) string {
+	k.Load(env.Provider("NOTETKR_", ".", func(s string) string {
 		return strings.Replace(strings.ToLower(strings.TrimPrefix(s, "NOTETKR_")), "_", ".", -1)
 	}), nil)
 
-	// Load from CLI args (highest precedence)
-	K.Load(posflag.Provider(flagSet, ".", K), nil)
+	if err := k.UnmarshalWithConf("", cfg, koanf.UnmarshalConf{FlatPaths: true}); err != nil {
+		return nil, fmt.Errorf("failed to read config values: %w", err)
+	}
+
+	// Remember which config file is active
+	if configFile != "" {
+		cfg.ConfigFile = configFile
+	}
+
+	return cfg, nil
+}
+
+// EnvVarFor returns the environment variable that overrides a config key, e.g.
+// NOTETKR_NOTES_DIR for notes.dir
+func EnvVarFor(key string) string {
+	return "NOTETKR_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
 }
 
 func parserForFile(path string) (koanf.Parser, error) {
@@ -81,3 +208,192 @@ func parserForFile(path string) (koanf.Parser, error) {
 		return nil, fmt.Errorf("unsupported file extension: %s", ext)
 	}
 }
+
+// EnsureConfigFile writes the default configuration to path if no file exists
+// there yet. It reports whether a new file was created.
+func EnsureConfigFile(path string) (bool, error) {
+	if _, err := os.Stat(path); err == nil {
+		return false, nil
+	} else if !os.IsNotExist(err) {
+		return false, fmt.Errorf("failed to access config file %s: %w", path, err)
+	}
+
+	parser, err := parserForFile(path)
+	if err != nil {
+		return false, fmt.Errorf("cannot create %s: %w (supported: .yml, .yaml, .json, .toml, .env)", path, err)
+	}
+
+	// Build the defaults as a koanf tree so each parser can marshal them
+	k := koanf.New(".")
+	defaults := reflect.ValueOf(*DefaultConfig())
+	t := defaults.Type()
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("koanf")
+		if tag == "" || tag == "config.file" {
+			continue
+		}
+		if err := k.Set(tag, defaults.Field(i).Interface()); err != nil {
+			return false, fmt.Errorf("failed to build default config: %w", err)
+		}
+	}
+
+	data, err := k.Marshal(parser)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode default config: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return false, fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return false, fmt.Errorf("failed to write config file %s: %w", path, err)
+	}
+
+	return true, nil
+}
+
+// UpdateConfigFile sets the given keys in the config file at path, creating the
+// file from the defaults first if it doesn't exist. Other settings are kept.
+func UpdateConfigFile(path string, values map[string]interface{}) error {
+	if _, err := EnsureConfigFile(path); err != nil {
+		return err
+	}
+
+	parser, err := parserForFile(path)
+	if err != nil {
+		return fmt.Errorf("cannot update %s: %w", path, err)
+	}
+
+	k := koanf.New(".")
+	if err := k.Load(file.Provider(path), parser); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	for key, value := range values {
+		if err := k.Set(key, value); err != nil {
+			return fmt.Errorf("failed to set %s: %w", key, err)
+		}
+	}
+
+	data, err := k.Marshal(parser)
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write config file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// ValidationResult describes the outcome of validating a config file
+type ValidationResult struct {
+	File        string
+	Config      *Config
+	UnknownKeys []string
+	PathErrors  map[string]error
+}
+
+// IsValid reports whether the config file had no unknown keys or path problems
+func (r *ValidationResult) IsValid() bool {
+	return len(r.UnknownKeys) == 0 && len(r.PathErrors) == 0
+}
+
+// ValidateConfigFile loads a config file on its own, reports any keys that
+// Config does not recognise, and checks that the configured directories can
+// be created. An error is returned if the file cannot be read or parsed.
+func ValidateConfigFile(path string) (*ValidationResult, error) {
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("config file %s does not exist", path)
+		}
+		return nil, fmt.Errorf("failed to access config file %s: %w", path, err)
+	}
+
+	parser, err := parserForFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot validate %s: %w (supported: .yml, .yaml, .json, .toml, .env)", path, err)
+	}
+
+	k := koanf.New(".")
+	if err := k.Load(file.Provider(path), parser); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	cfg := DefaultConfig()
+	if err := k.UnmarshalWithConf("", cfg, koanf.UnmarshalConf{FlatPaths: true}); err != nil {
+		return nil, fmt.Errorf("failed to read values from config file %s: %w", path, err)
+	}
+
+	result := &ValidationResult{
+		File:       path,
+		Config:     cfg,
+		PathErrors: make(map[string]error),
+	}
+
+	// Report keys that don't map onto a Config field
+	known := knownKeys()
+	for _, key := range k.Keys() {
+		if !known[key] {
+			result.UnknownKeys = append(result.UnknownKeys, key)
+		}
+	}
+	sort.Strings(result.UnknownKeys)
+
+	// Verify the configured directories could be created
+	dirs := map[string]string{
+		"data.dir":    cfg.DataDir,
+		"notes.dir":   cfg.NotesDir,
+		"journal.dir": cfg.JournalDir,
+	}
+	// An empty preview.dir means the system temp directory
+	if cfg.PreviewDir != "" {
+		dirs["preview.dir"] = cfg.PreviewDir
+	}
+	for key, dir := range dirs {
+		if err := checkDirCreatable(dir); err != nil {
+			result.PathErrors[key] = err
+		}
+	}
+
+	return result, nil
+}
+
+// knownKeys returns the set of koanf keys recognised by Config
+func knownKeys() map[string]bool {
+	keys := make(map[string]bool)
+	t := reflect.TypeOf(Config{})
+	for i := 0; i < t.NumField(); i++ {
+		if tag := t.Field(i).Tag.Get("koanf"); tag != "" {
+			keys[tag] = true
+		}
+	}
+	return keys
+}
+
+// checkDirCreatable verifies a directory exists or that its nearest existing
+// parent is a directory it could be created under
+func checkDirCreatable(dir string) error {
+	if dir == "" {
+		return fmt.Errorf("path is empty")
+	}
+
+	current := filepath.Clean(dir)
+	for {
+		info, err := os.Stat(current)
+		if err == nil {
+			if !info.IsDir() {
+				return fmt.Errorf("%s exists and is not a directory", current)
+			}
+			return nil
+		}
+		if !os.IsNotExist(err) {
+			return fmt.Errorf("cannot access %s: %w", current, err)
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return fmt.Errorf("no existing parent directory for %s", dir)
+		}
+		current = parent
+	}
+}
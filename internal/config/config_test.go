@@ -0,0 +1,110 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateConfigFileMalformed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notetkr.json")
+	if err := os.WriteFile(path, []byte(`{"notes": {"dir": `), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := ValidateConfigFile(path)
+	if err == nil {
+		t.Fatal("expected an error for malformed config, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "failed to parse config file") || !strings.Contains(err.Error(), path) {
+		t.Errorf("error should describe the parse failure and name the file, got: %v", err)
+	}
+}
+
+func TestValidateConfigFileUnknownKeys(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "notetkr.yml")
+	content := "notes:\n  dir: " + filepath.Join(dir, "notes") + "\nnotse:\n  dri: typo\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := ValidateConfigFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.UnknownKeys) != 1 || result.UnknownKeys[0] != "notse.dri" {
+		t.Errorf("expected unknown key notse.dri, got %v", result.UnknownKeys)
+	}
+	if result.Config.NotesDir != filepath.Join(dir, "notes") {
+		t.Errorf("notes.dir not loaded, got %s", result.Config.NotesDir)
+	}
+}
+
+func TestEnsureConfigFileCreatesMissingFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "nested", "notetkr.yml")
+
+	created, err := EnsureConfigFile(path)
+	if err != nil {
+		t.Fatalf("EnsureConfigFile returned error: %v", err)
+	}
+	if !created {
+		t.Error("expected EnsureConfigFile to report the file as created")
+	}
+
+	result, err := ValidateConfigFile(path)
+	if err != nil {
+		t.Fatalf("created config file failed to load: %v", err)
+	}
+	if !result.IsValid() {
+		t.Errorf("created config file should be valid, got unknown keys %v and path errors %v", result.UnknownKeys, result.PathErrors)
+	}
+	if want := DefaultConfig().CtrlC; result.Config.CtrlC != want {
+		t.Errorf("editor.ctrlc = %q, want default %q", result.Config.CtrlC, want)
+	}
+
+	// An existing file is left untouched
+	if err := os.WriteFile(path, []byte("notes:\n  dir: "+dir+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	created, err = EnsureConfigFile(path)
+	if err != nil || created {
+		t.Fatalf("EnsureConfigFile on existing file = (%v, %v), want (false, nil)", created, err)
+	}
+	content, _ := os.ReadFile(path)
+	if !strings.Contains(string(content), "notes:\n  dir: "+dir) {
+		t.Errorf("existing config file was modified: %q", content)
+	}
+}
+
+func TestUpdateConfigFileKeepsOtherSettings(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "notetkr.yml")
+	if err := os.WriteFile(path, []byte("data:\n  dir: /old\nnotes:\n  dir: /old/notes\n  quickdelete: true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := UpdateConfigFile(path, map[string]interface{}{
+		"data.dir":  "/new",
+		"notes.dir": "/new/notes",
+	})
+	if err != nil {
+		t.Fatalf("UpdateConfigFile returned error: %v", err)
+	}
+
+	result, err := ValidateConfigFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := result.Config
+	if cfg.DataDir != "/new" || cfg.NotesDir != "/new/notes" {
+		t.Errorf("dirs not updated: data.dir=%s notes.dir=%s", cfg.DataDir, cfg.NotesDir)
+	}
+	if !cfg.NotesQuickDelete {
+		t.Error("notes.quickdelete = false, want true to be kept")
+	}
+}
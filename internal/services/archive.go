@@ -0,0 +1,101 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// archiveDirName is the hidden directory in the notes directory that archived notes
+// are moved into, so the browser, search and tags no longer list them
+const archiveDirName = ".archive"
+
+// GetArchiveDir returns the directory archived notes are kept in
+func (s *NotesService) GetArchiveDir() string {
+	return filepath.Join(s.notesDir, archiveDirName)
+}
+
+// ArchiveNote moves a note into the archive at the same path relative to the notes
+// directory, hiding it without deleting it. An archived note with the same name is
+// never overwritten: the newer one gets a numeric suffix.
+func (s *NotesService) ArchiveNote(filePath string) error {
+	relPath, err := filepath.Rel(s.notesDir, filePath)
+	if err != nil || strings.HasPrefix(relPath, "..") {
+		return fmt.Errorf("note is outside the notes directory: %s", filePath)
+	}
+	if relPath == archiveDirName || strings.HasPrefix(relPath, archiveDirName+string(filepath.Separator)) {
+		return fmt.Errorf("note is already archived: %s", filepath.Base(filePath))
+	}
+
+	destDir := filepath.Join(s.GetArchiveDir(), filepath.Dir(relPath))
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return fmt.Errorf("failed to create archive directory: %w", err)
+	}
+	if err := moveNoteFile(filePath, uniqueDestPath(destDir, filepath.Base(filePath))); err != nil {
+		return fmt.Errorf("failed to archive note: %w", err)
+	}
+	return nil
+}
+
+// ListArchivedNotes returns the archived notes, most recently modified first. Each
+// note's Name is its path relative to the archive, which is where it's restored to.
+func (s *NotesService) ListArchivedNotes() ([]Note, error) {
+	archiveDir := s.GetArchiveDir()
+	var notes []Note
+
+	err := filepath.Walk(archiveDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == archiveDir {
+				return filepath.SkipDir // Nothing archived yet
+			}
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(info.Name(), ".md") {
+			return nil
+		}
+
+		relPath, _ := filepath.Rel(archiveDir, path)
+		notes = append(notes, noteFromFile(path, relPath, info.ModTime()))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archived notes: %w", err)
+	}
+
+	sort.SliceStable(notes, func(i, j int) bool {
+		return notes[i].ModTime.After(notes[j].ModTime)
+	})
+	return notes, nil
+}
+
+// RestoreNote moves an archived note back to the category it was archived from and
+// returns its restored path. A name taken there in the meantime is handled like a
+// move: a numeric suffix, or with SuffixOnClash turned off, an error.
+func (s *NotesService) RestoreNote(archivedPath string) (string, error) {
+	relPath, err := filepath.Rel(s.GetArchiveDir(), archivedPath)
+	if err != nil || strings.HasPrefix(relPath, "..") {
+		return "", fmt.Errorf("note is not archived: %s", filepath.Base(archivedPath))
+	}
+
+	destDir := filepath.Join(s.notesDir, filepath.Dir(relPath))
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create category directory: %w", err)
+	}
+	newPath, err := s.destPath(destDir, filepath.Base(archivedPath))
+	if err != nil {
+		return "", err
+	}
+	if err := moveNoteFile(archivedPath, newPath); err != nil {
+		return "", fmt.Errorf("failed to restore note: %w", err)
+	}
+
+	// Tidy up archive directories the restore left empty
+	for dir := filepath.Dir(archivedPath); dir != s.GetArchiveDir(); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			break
+		}
+	}
+	return newPath, nil
+}
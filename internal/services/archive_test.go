@@ -0,0 +1,91 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestArchiveAndRestoreNote(t *testing.T) {
+	notesDir := t.TempDir()
+	s := NewNotesService(notesDir)
+	write := func(rel, content string) string {
+		t.Helper()
+		path := filepath.Join(notesDir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	note := write("work/old-plan.md", "# Old plan #work\n")
+	write("work/current.md", "# Current\n")
+
+	if err := s.ArchiveNote(note); err != nil {
+		t.Fatal(err)
+	}
+	archived := filepath.Join(notesDir, ".archive", "work", "old-plan.md")
+	if _, err := os.Stat(archived); err != nil {
+		t.Fatalf("archived note should be at %s: %v", archived, err)
+	}
+
+	// Archived notes are hidden from listings
+	listed, _, err := s.ListNotesInPath("work")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(listed) != 1 || listed[0].Name != "current.md" {
+		t.Errorf("ListNotesInPath(work) = %v, want only current.md", listed)
+	}
+	all, err := s.ListNotes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 1 {
+		t.Errorf("ListNotes should skip archived notes, got %d notes", len(all))
+	}
+
+	// A second note with the same name gets a suffix in the archive
+	if err := s.ArchiveNote(write("work/old-plan.md", "# Another old plan\n")); err != nil {
+		t.Fatal(err)
+	}
+	archivedNotes, err := s.ListArchivedNotes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(archivedNotes) != 2 {
+		t.Fatalf("ListArchivedNotes = %d notes, want 2", len(archivedNotes))
+	}
+	if err := s.ArchiveNote(archived); err == nil {
+		t.Error("ArchiveNote should refuse an already archived note")
+	}
+
+	// Restoring puts the note back where it was
+	restored, err := s.RestoreNote(archived)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored != note {
+		t.Errorf("RestoreNote = %s, want %s", restored, note)
+	}
+	if got, _ := os.ReadFile(restored); string(got) != "# Old plan #work\n" {
+		t.Errorf("restored note = %q", got)
+	}
+	restored, err = s.RestoreNote(filepath.Join(notesDir, ".archive", "work", "old-plan-2.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(restored) != "old-plan-2.md" {
+		t.Errorf("restoring onto a taken name = %s, want old-plan-2.md", filepath.Base(restored))
+	}
+
+	// Emptied archive directories are removed
+	if _, err := os.Stat(filepath.Join(notesDir, ".archive", "work")); !os.IsNotExist(err) {
+		t.Error("empty archive directory should be removed after restoring")
+	}
+	if archivedNotes, err := s.ListArchivedNotes(); err != nil || len(archivedNotes) != 0 {
+		t.Errorf("ListArchivedNotes = %v, %v; want none", archivedNotes, err)
+	}
+}
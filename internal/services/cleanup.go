@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -26,6 +27,14 @@ type CleanupStats struct {
 	BytesFreed             int64
 }
 
+// markdownLinkPattern matches a markdown link, [text](target), capturing the text
+// and the target. The target may be wrapped in angle brackets: [text](<target>)
+const markdownLinkPattern = `\[([^\]]*)\]\(<?\s*([^)>]+?)\s*>?\)`
+
+// imageRefRegex matches markdown image syntax: ![alt](.attachments/path/to/image.png)
+// Also handles angle brackets: ![alt](<.attachments/path/to/image.png>)
+var imageRefRegex = regexp.MustCompile(`!` + markdownLinkPattern)
+
 // ImageReference tracks where an image is referenced
 type ImageReference struct {
 	FilePath  string
@@ -172,10 +181,6 @@ func (s *CleanupService) findAllImages() ([]string, error) {
 func (s *CleanupService) findAllImageReferences() ([]ImageReference, error) {
 	var references []ImageReference
 
-	// Regex to match markdown image syntax: ![alt](.attachments/path/to/image.png)
-	// Also handles angle brackets: ![alt](<.attachments/path/to/image.png>)
-	imageRegex := regexp.MustCompile(`!\[([^\]]*)\]\(<?\s*([^)>]+?)\s*>?\)`)
-
 	walkFunc := func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return nil
@@ -197,7 +202,7 @@ func (s *CleanupService) findAllImageReferences() ([]ImageReference, error) {
 			lineNum++
 			line := scanner.Text()
 
-			matches := imageRegex.FindAllStringSubmatch(line, -1)
+			matches := imageRefRegex.FindAllStringSubmatch(line, -1)
 			for _, match := range matches {
 				if len(match) > 2 {
 					imagePath := strings.TrimSpace(match[2])
@@ -226,6 +231,116 @@ func (s *CleanupService) findAllImageReferences() ([]ImageReference, error) {
 	return references, nil
 }
 
+// FindBrokenImageReferences returns the .attachments image references in notes and
+// journals whose target file doesn't exist, e.g. after notes were moved by another tool
+func (s *CleanupService) FindBrokenImageReferences() ([]ImageReference, error) {
+	references, err := s.findAllImageReferences()
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan image references: %w", err)
+	}
+
+	var broken []ImageReference
+	for _, ref := range references {
+		if _, err := os.Stat(s.imageRefTarget(ref)); err != nil {
+			broken = append(broken, ref)
+		}
+	}
+
+	return broken, nil
+}
+
+// imageRefTarget returns the absolute path of the image a reference points to. Links
+// may be URL-encoded, e.g. spaces written as %20, so the decoded path is used when
+// only it exists.
+func (s *CleanupService) imageRefTarget(ref ImageReference) string {
+	target := s.normalizeImagePath(ref.ImagePath, filepath.Dir(ref.FilePath))
+	if _, err := os.Stat(target); err == nil {
+		return target
+	}
+
+	if unescaped, err := url.PathUnescape(ref.ImagePath); err == nil && unescaped != ref.ImagePath {
+		decoded := s.normalizeImagePath(unescaped, filepath.Dir(ref.FilePath))
+		if _, err := os.Stat(decoded); err == nil {
+			return decoded
+		}
+	}
+
+	return target
+}
+
+// NormalizedImageLink is an image reference that was (or would be) rewritten to the
+// canonical form
+type NormalizedImageLink struct {
+	FilePath string
+	LineNum  int
+	OldPath  string // The path as it was written, e.g. ./.attachments/a.png
+	NewPath  string // The canonical path, relative to the file, e.g. .attachments/a.png
+}
+
+// NormalizeImageLinks rewrites every .attachments image reference in notes and journals
+// to the canonical form: an angle-bracketed path relative to the referencing file, like
+// ![alt](<.attachments/a.png>). Absolute, ./-prefixed and URL-encoded paths all converge
+// on it, so cleanup and dedup compare links reliably. With dryRun nothing is written;
+// the links that would change are returned either way.
+func (s *CleanupService) NormalizeImageLinks(dryRun bool) ([]NormalizedImageLink, error) {
+	references, err := s.findAllImageReferences()
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan image references: %w", err)
+	}
+
+	var changed []NormalizedImageLink
+	fileLines := make(map[string][]string)
+	// Referencing file -> image file -> the references to rewrite
+	rewrites := make(map[string]map[string][]ImageReference)
+
+	for _, ref := range references {
+		target := s.imageRefTarget(ref)
+		relPath, err := filepath.Rel(filepath.Dir(ref.FilePath), target)
+		if err != nil {
+			continue
+		}
+		relPath = filepath.ToSlash(relPath)
+
+		lines, ok := fileLines[ref.FilePath]
+		if !ok {
+			content, err := os.ReadFile(ref.FilePath)
+			if err != nil {
+				continue
+			}
+			lines = strings.Split(string(content), "\n")
+			fileLines[ref.FilePath] = lines
+		}
+		if ref.ImagePath == relPath && strings.Contains(lines[ref.LineNum-1], "](<"+relPath+">)") {
+			continue // Already canonical
+		}
+
+		changed = append(changed, NormalizedImageLink{
+			FilePath: ref.FilePath,
+			LineNum:  ref.LineNum,
+			OldPath:  ref.ImagePath,
+			NewPath:  relPath,
+		})
+		if rewrites[ref.FilePath] == nil {
+			rewrites[ref.FilePath] = make(map[string][]ImageReference)
+		}
+		rewrites[ref.FilePath][target] = append(rewrites[ref.FilePath][target], ref)
+	}
+
+	if dryRun {
+		return changed, nil
+	}
+
+	for filePath, targets := range rewrites {
+		for target, refs := range targets {
+			if err := s.updateFileReferences(filePath, refs, "", target); err != nil {
+				return changed, fmt.Errorf("failed to update %s: %w", filePath, err)
+			}
+		}
+	}
+
+	return changed, nil
+}
+
 // normalizeImagePath converts a relative image path to an absolute path
 func (s *CleanupService) normalizeImagePath(imagePath, baseDir string) string {
 	// If already absolute, return as-is
@@ -299,25 +414,22 @@ func (s *CleanupService) updateFileReferences(filePath string, refs []ImageRefer
 		return err
 	}
 
-	// Convert to forward slashes for markdown
-	relPath = filepath.ToSlash(relPath)
+	// Convert to forward slashes for markdown, and escape $ for the replacement template
+	relPath = strings.ReplaceAll(filepath.ToSlash(relPath), "$", "$$")
 
-	// Replace all occurrences
+	// Replace all occurrences, with or without angle brackets, with the canonical
+	// angle-bracketed form
 	contentStr := string(content)
+	replacement := fmt.Sprintf(`![$1](<%s>)`, relPath)
 	for _, ref := range refs {
 		// Create a regex to match this specific image reference
-		// Handle both with and without angle brackets
 		escapedPath := regexp.QuoteMeta(ref.ImagePath)
 
-		// Try with angle brackets first
 		patternWithBrackets := regexp.MustCompile(fmt.Sprintf(`!\[([^\]]*)\]\(<\s*%s\s*>\)`, escapedPath))
-		if patternWithBrackets.MatchString(contentStr) {
-			contentStr = patternWithBrackets.ReplaceAllString(contentStr, fmt.Sprintf(`![$1](<%s>)`, relPath))
-		} else {
-			// Try without angle brackets
-			pattern := regexp.MustCompile(fmt.Sprintf(`!\[([^\]]*)\]\(%s\)`, escapedPath))
-			contentStr = pattern.ReplaceAllString(contentStr, fmt.Sprintf(`![$1](%s)`, relPath))
-		}
+		contentStr = patternWithBrackets.ReplaceAllString(contentStr, replacement)
+
+		pattern := regexp.MustCompile(fmt.Sprintf(`!\[([^\]]*)\]\(\s*%s\s*\)`, escapedPath))
+		contentStr = pattern.ReplaceAllString(contentStr, replacement)
 	}
 
 	// Write back to file
@@ -326,6 +438,19 @@ func (s *CleanupService) updateFileReferences(filePath string, refs []ImageRefer
 
 // CleanEmptyNotes removes notes that only contain the default template
 func (s *CleanupService) CleanEmptyNotes() (int, error) {
+	return s.cleanNotesMatching(s.isDefaultNoteTemplate)
+}
+
+// CleanWhitespaceNotes removes notes that were cleared by hand and now contain
+// only whitespace, optionally wrapped in frontmatter with no values
+func (s *CleanupService) CleanWhitespaceNotes() (int, error) {
+	return s.cleanNotesMatching(func(content, filename string) bool {
+		return isWhitespaceOnlyNote(content)
+	})
+}
+
+// cleanNotesMatching removes every note whose content matches isEmpty
+func (s *CleanupService) cleanNotesMatching(isEmpty func(content, filename string) bool) (int, error) {
 	deleted := 0
 
 	// Walk through all notes
@@ -350,8 +475,8 @@ func (s *CleanupService) CleanEmptyNotes() (int, error) {
 			return nil // Skip files we can't read
 		}
 
-		// Check if it matches the default template
-		if s.isDefaultNoteTemplate(string(content), info.Name()) {
+		// Check if it's empty by the caller's definition
+		if isEmpty(string(content), info.Name()) {
 			if err := os.Remove(path); err == nil {
 				deleted++
 			}
@@ -406,6 +531,47 @@ func (s *CleanupService) isDefaultNoteTemplate(content, filename string) bool {
 	return strings.TrimSpace(content) == strings.TrimSpace(defaultTemplate)
 }
 
+// isWhitespaceOnlyNote checks if a note has no content besides whitespace and
+// a frontmatter block whose keys are all empty (e.g. "tags:" with no value)
+func isWhitespaceOnlyNote(content string) bool {
+	lines := strings.Split(strings.TrimSpace(content), "\n")
+	if len(lines) == 1 && strings.TrimSpace(lines[0]) == "" {
+		return true
+	}
+
+	// Otherwise the note must start with a frontmatter fence
+	fence := strings.TrimSpace(lines[0])
+	if fence != "---" && fence != "+++" {
+		return false
+	}
+
+	for i := 1; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == fence {
+			// Everything after the closing fence must be blank
+			return strings.TrimSpace(strings.Join(lines[i+1:], "\n")) == ""
+		}
+		if line == "" {
+			continue
+		}
+
+		// Each frontmatter line must be a key with no value
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			key, value, found = strings.Cut(line, "=")
+		}
+		if !found || strings.TrimSpace(key) == "" {
+			return false
+		}
+		if v := strings.TrimSpace(value); v != "" && v != "[]" && v != `""` {
+			return false
+		}
+	}
+
+	// No closing fence
+	return false
+}
+
 // isDefaultJournalTemplate checks if content matches the default journal template
 func (s *CleanupService) isDefaultJournalTemplate(content string) bool {
 	// Journal template pattern: "# Journal Entry - [Date]\n\n## Tasks\n\n- \n"
@@ -427,6 +593,11 @@ func (s *CleanupService) isDefaultJournalTemplate(content string) bool {
 		return false
 	}
 
+	// Entries created with daily links have them between the title and ## Tasks
+	if len(lines) >= 7 && strings.TrimSpace(lines[1]) == "" && dailyLinksRegex.MatchString(strings.TrimSpace(lines[2])) {
+		lines = append(lines[:1], lines[3:]...)
+	}
+
 	if strings.TrimSpace(lines[1]) != "" {
 		return false
 	}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestIsWhitespaceOnlyNote(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{"empty", "", true},
+		{"whitespace", "  \n\n\t\n", true},
+		{"empty frontmatter", "---\ntags:\nkeywords:\n---\n\n   \n", true},
+		{"one word", "hello\n", false},
+		{"frontmatter with one word", "---\ntags:\nkeywords:\n---\n\nhello\n", false},
+		{"frontmatter with values", "---\ntags: [work]\n---\n\n", false},
+		{"default template heading", "---\ntags:\nkeywords:\n---\n\n# \n", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isWhitespaceOnlyNote(tt.content); got != tt.want {
+				t.Errorf("isWhitespaceOnlyNote(%q) = %v, want %v", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCleanWhitespaceNotes(t *testing.T) {
+	notesDir := t.TempDir()
+	s := NewCleanupService(notesDir, t.TempDir())
+
+	blank := filepath.Join(notesDir, "blank.md")
+	word := filepath.Join(notesDir, "word.md")
+	if err := os.WriteFile(blank, []byte("---\ntags:\n---\n\n  \n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(word, []byte("idea\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	deleted, err := s.CleanWhitespaceNotes()
+	if err != nil {
+		t.Fatalf("CleanWhitespaceNotes returned error: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("deleted %d note(s), want 1", deleted)
+	}
+	if _, err := os.Stat(blank); !os.IsNotExist(err) {
+		t.Error("whitespace-only note was not removed")
+	}
+	if _, err := os.Stat(word); err != nil {
+		t.Error("one-word note should be kept")
+	}
+}
+
+func TestFindBrokenImageReferences(t *testing.T) {
+	notesDir := t.TempDir()
+	journalDir := t.TempDir()
+	s := NewCleanupService(notesDir, journalDir)
+
+	attachments := filepath.Join(notesDir, "work", ".attachments")
+	if err := os.MkdirAll(attachments, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(attachments, "my chart.png"), []byte("png"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	note := filepath.Join(notesDir, "work", "report.md")
+	content := "# Report\n\n![Pasted image](<.attachments/my chart.png>)\n![Chart](.attachments/my%20chart.png)\n\n![Gone](.attachments/missing.png)\n![Web](https://example.com/x.png)\n"
+	if err := os.WriteFile(note, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	journal := filepath.Join(journalDir, "2025-01-02.md")
+	if err := os.WriteFile(journal, []byte("![Moved](<../.attachments/old.png>)\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	broken, err := s.FindBrokenImageReferences()
+	if err != nil {
+		t.Fatalf("FindBrokenImageReferences returned error: %v", err)
+	}
+
+	want := []ImageReference{
+		{FilePath: note, LineNum: 6, ImagePath: ".attachments/missing.png"},
+		{FilePath: journal, LineNum: 1, ImagePath: "../.attachments/old.png"},
+	}
+	if !reflect.DeepEqual(broken, want) {
+		t.Errorf("broken references = %+v, want %+v", broken, want)
+	}
+}
+
+func TestNormalizeImageLinks(t *testing.T) {
+	notesDir := t.TempDir()
+	journalDir := t.TempDir()
+	s := NewCleanupService(notesDir, journalDir)
+
+	attachments := filepath.Join(notesDir, "work", ".attachments")
+	if err := os.MkdirAll(attachments, 0755); err != nil {
+		t.Fatal(err)
+	}
+	chart := filepath.Join(attachments, "my chart.png")
+	if err := os.WriteFile(chart, []byte("png"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	note := filepath.Join(notesDir, "work", "report.md")
+	content := "# Report\n\n" +
+		"![a](<.attachments/my chart.png>)\n" +
+		"![b](./.attachments/my%20chart.png)\n" +
+		"![c](<./.attachments/my chart.png>)\n" +
+		"![d](" + filepath.ToSlash(chart) + ")\n" +
+		"![web](https://example.com/x.png)\n"
+	if err := os.WriteFile(note, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	journal := filepath.Join(journalDir, "2025-01-02.md")
+	if err := os.WriteFile(journal, []byte("![e](../notes/.attachments/x.png)\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// A dry run reports the changes without writing them
+	changed, err := s.NormalizeImageLinks(true)
+	if err != nil {
+		t.Fatalf("NormalizeImageLinks returned error: %v", err)
+	}
+	if len(changed) != 4 {
+		t.Errorf("dry run found %d links to change, want 4: %+v", len(changed), changed)
+	}
+	if data, _ := os.ReadFile(note); string(data) != content {
+		t.Error("dry run modified the note")
+	}
+
+	if _, err := s.NormalizeImageLinks(false); err != nil {
+		t.Fatalf("NormalizeImageLinks returned error: %v", err)
+	}
+	data, err := os.ReadFile(note)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "# Report\n\n" +
+		"![a](<.attachments/my chart.png>)\n" +
+		"![b](<.attachments/my chart.png>)\n" +
+		"![c](<.attachments/my chart.png>)\n" +
+		"![d](<.attachments/my chart.png>)\n" +
+		"![web](https://example.com/x.png)\n"
+	if string(data) != want {
+		t.Errorf("normalized note =\n%s\nwant\n%s", data, want)
+	}
+
+	// Broken links are still put in the canonical form
+	if data, _ := os.ReadFile(journal); string(data) != "![e](<../notes/.attachments/x.png>)\n" {
+		t.Errorf("normalized journal = %q", data)
+	}
+
+	// Running again finds nothing left to do
+	changed, err = s.NormalizeImageLinks(false)
+	if err != nil || len(changed) != 0 {
+		t.Errorf("second run changed %+v (err %v), want nothing", changed, err)
+	}
+}
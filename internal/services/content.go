@@ -0,0 +1,88 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrBinaryContent is returned when a note or journal file doesn't look like text
+var ErrBinaryContent = errors.New("file does not look like text (binary or non-UTF-8 content)")
+
+// binarySniffLen is how many leading bytes are inspected when checking for binary content
+const binarySniffLen = 8000
+
+// looksBinary reports whether data looks like binary or non-UTF-8 content that
+// would be corrupted by loading it into the editor
+func looksBinary(data []byte) bool {
+	sample := data
+	if len(sample) > binarySniffLen {
+		sample = sample[:binarySniffLen]
+		// Don't flag a multi-byte rune that was cut off at the sample boundary
+		for i := 0; i < utf8.UTFMax && len(sample) > 0 && !utf8.Valid(sample); i++ {
+			sample = sample[:len(sample)-1]
+		}
+	}
+
+	// NUL bytes never appear in text files
+	if bytes.IndexByte(sample, 0) >= 0 {
+		return true
+	}
+
+	if !utf8.Valid(sample) {
+		return true
+	}
+
+	// Lots of control characters (other than whitespace) means it isn't text
+	control := 0
+	for _, b := range sample {
+		if b < 0x20 && b != '\n' && b != '\r' && b != '\t' && b != '\f' && b != 0x1b {
+			control++
+		}
+	}
+	return len(sample) > 0 && control*10 > len(sample)
+}
+
+// DetectLineEnding returns "\r\n" if content's first line ends in CRLF, otherwise "\n"
+func DetectLineEnding(content string) string {
+	if i := strings.IndexByte(content, '\n'); i > 0 && content[i-1] == '\r' {
+		return "\r\n"
+	}
+	return "\n"
+}
+
+// NormalizeLineEndings converts CRLF line endings to "\n" so the editors' line math holds
+func NormalizeLineEndings(content string) string {
+	return strings.ReplaceAll(content, "\r\n", "\n")
+}
+
+// RestoreLineEndings converts "\n" line endings back to ending before writing
+func RestoreLineEndings(content, ending string) string {
+	if ending == "\n" || ending == "" {
+		return content
+	}
+	return strings.ReplaceAll(NormalizeLineEndings(content), "\n", ending)
+}
+
+// wordsPerMinute is the reading speed ReadingMinutes assumes
+const wordsPerMinute = 200
+
+// CountWords returns the number of words in markdown content, ignoring front matter.
+// Tokens without a letter or digit, like list bullets and heading markers, aren't words.
+func CountWords(content string) int {
+	words := 0
+	for _, field := range strings.Fields(StripFrontMatter(content)) {
+		if strings.IndexFunc(field, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) >= 0 {
+			words++
+		}
+	}
+	return words
+}
+
+// ReadingMinutes estimates how long words take to read at 200 words per minute,
+// rounded up so any text takes at least a minute
+func ReadingMinutes(words int) int {
+	return (words + wordsPerMinute - 1) / wordsPerMinute
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLooksBinary(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want bool
+	}{
+		{"empty", []byte{}, false},
+		{"markdown", []byte("# Title\n\n- [ ] task\n\tindented ✓ ünïcode\n"), false},
+		{"png header", []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"), true},
+		{"nul byte", []byte("text\x00more"), true},
+		{"invalid utf-8", []byte("caf\xe9 latin-1"), true},
+		{"control chars", []byte("\x01\x02\x03\x04\x05abc"), true},
+		{"long text with rune at sniff boundary", []byte(strings.Repeat("a", binarySniffLen-1) + "é"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := looksBinary(tt.data); got != tt.want {
+				t.Errorf("looksBinary() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadNoteRefusesBinary(t *testing.T) {
+	notesDir := t.TempDir()
+	s := NewNotesService(notesDir)
+
+	path := filepath.Join(notesDir, "image.md")
+	if err := os.WriteFile(path, []byte("\x89PNG\r\n\x1a\n\x00\x00"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.ReadNote(path); err == nil {
+		t.Error("ReadNote should refuse binary content")
+	}
+}
+
+func TestLineEndingRoundTrip(t *testing.T) {
+	crlf := "# Title\r\n\r\nbody\r\n"
+	if got := DetectLineEnding(crlf); got != "\r\n" {
+		t.Errorf("DetectLineEnding(crlf) = %q, want CRLF", got)
+	}
+	if got := DetectLineEnding("# Title\nbody\r\n"); got != "\n" {
+		t.Errorf("DetectLineEnding(lf) = %q, want LF", got)
+	}
+
+	normalized := NormalizeLineEndings(crlf)
+	if normalized != "# Title\n\nbody\n" {
+		t.Errorf("NormalizeLineEndings() = %q", normalized)
+	}
+	if got := RestoreLineEndings(normalized, "\r\n"); got != crlf {
+		t.Errorf("RestoreLineEndings() = %q, want %q", got, crlf)
+	}
+	if got := RestoreLineEndings(normalized, "\n"); got != normalized {
+		t.Errorf("RestoreLineEndings(lf) changed content: %q", got)
+	}
+}
+
+func TestCountWords(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    int
+	}{
+		{"empty", "", 0},
+		{"plain", "one two  three\nfour", 4},
+		{"markdown markers", "# Title\n\n- [ ] buy milk\n- item\n\n---\n", 4},
+		{"front matter", "---\ntags: [a, b]\ncreated: today\n---\n# Hello world", 2},
+	}
+	for _, tt := range tests {
+		if got := CountWords(tt.content); got != tt.want {
+			t.Errorf("%s: CountWords = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+
+	for words, want := range map[int]int{0: 0, 1: 1, 200: 1, 201: 2, 1000: 5} {
+		if got := ReadingMinutes(words); got != want {
+			t.Errorf("ReadingMinutes(%d) = %d, want %d", words, got, want)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ExportSummary describes how many files and bytes an export will include
+type ExportSummary struct {
+	Files int
+	Bytes int64
+}
+
+// WalkExportFiles calls fn for every regular file under sourceDir that would be
+// included in an export archive, passing the file's path relative to sourceDir
+func WalkExportFiles(sourceDir string, fn func(path, relPath string, info os.FileInfo) error) error {
+	return filepath.Walk(sourceDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			// Skip files we can't access
+			return nil
+		}
+
+		// Skip directories
+		if info.IsDir() {
+			return nil
+		}
+
+		// Get relative path from source directory
+		relPath, err := filepath.Rel(sourceDir, path)
+		if err != nil {
+			return err
+		}
+
+		return fn(path, relPath, info)
+	})
+}
+
+// NoteAttachments returns the absolute paths of the existing .attachments images
+// a note links to, each listed once in the order they first appear
+func NoteAttachments(notePath string) ([]string, error) {
+	content, err := os.ReadFile(notePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read note: %w", err)
+	}
+
+	var attachments []string
+	seen := make(map[string]bool)
+	for _, match := range imageRefRegex.FindAllStringSubmatch(string(content), -1) {
+		imagePath := strings.TrimSpace(match[2])
+		if !strings.Contains(imagePath, ".attachments") {
+			continue
+		}
+
+		if !filepath.IsAbs(imagePath) {
+			imagePath = filepath.Join(filepath.Dir(notePath), imagePath)
+		}
+		imagePath = filepath.Clean(imagePath)
+		if seen[imagePath] {
+			continue
+		}
+		seen[imagePath] = true
+
+		if info, err := os.Stat(imagePath); err == nil && !info.IsDir() {
+			attachments = append(attachments, imagePath)
+		}
+	}
+
+	return attachments, nil
+}
+
+// embeddableImageTypes maps the image extensions InlineImages embeds to their MIME
+// types; they're the data URI types the HTML preview lets through
+var embeddableImageTypes = map[string]string{
+	".png":  "image/png",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".gif":  "image/gif",
+	".webp": "image/webp",
+}
+
+// InlineImages replaces the local image references in markdown content with base64
+// data URIs, resolving relative paths from sourceDir, so a note can be shared without
+// its attachment files. Web images, files that can't be read and image syntax in code
+// blocks or inline code are left as they are. Returns the new content and how many
+// references were embedded.
+func InlineImages(content, sourceDir string) (string, int) {
+	embedded := 0
+	lines := strings.Split(content, "\n")
+	inFence := false
+
+	for i, line := range lines {
+		if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence || !strings.Contains(line, "![") {
+			continue
+		}
+
+		var b strings.Builder
+		last := 0
+		for _, match := range imageRefRegex.FindAllStringSubmatchIndex(blankCodeSpans(line), -1) {
+			imagePath := strings.TrimSpace(line[match[4]:match[5]])
+			if strings.Contains(imagePath, "://") || strings.HasPrefix(imagePath, "data:") {
+				continue
+			}
+
+			mimeType, ok := embeddableImageTypes[strings.ToLower(filepath.Ext(imagePath))]
+			if !ok {
+				continue
+			}
+
+			data, err := readImageRef(imagePath, sourceDir)
+			if err != nil {
+				continue
+			}
+
+			b.WriteString(line[last:match[0]])
+			fmt.Fprintf(&b, "![%s](data:%s;base64,%s)", line[match[2]:match[3]], mimeType, base64.StdEncoding.EncodeToString(data))
+			last = match[1]
+			embedded++
+		}
+		b.WriteString(line[last:])
+		lines[i] = b.String()
+	}
+
+	return strings.Join(lines, "\n"), embedded
+}
+
+// readImageRef reads the image a markdown reference points to, trying the URL-decoded
+// path (e.g. %20 for spaces) if the path as written doesn't exist
+func readImageRef(imagePath, sourceDir string) ([]byte, error) {
+	resolve := func(p string) string {
+		if filepath.IsAbs(p) {
+			return filepath.Clean(p)
+		}
+		return filepath.Join(sourceDir, p)
+	}
+
+	data, err := os.ReadFile(resolve(imagePath))
+	if err == nil {
+		return data, nil
+	}
+	if unescaped, unescapeErr := url.PathUnescape(imagePath); unescapeErr == nil && unescaped != imagePath {
+		return os.ReadFile(resolve(unescaped))
+	}
+	return nil, err
+}
+
+// SummarizeExport counts the files and total bytes an export would include
+func SummarizeExport(notesDir, journalDir string, includeNotes, includeJournals bool) (ExportSummary, error) {
+	var summary ExportSummary
+
+	countFile := func(path, relPath string, info os.FileInfo) error {
+		summary.Files++
+		summary.Bytes += info.Size()
+		return nil
+	}
+
+	if includeNotes {
+		if err := WalkExportFiles(notesDir, countFile); err != nil {
+			return summary, err
+		}
+	}
+
+	if includeJournals {
+		if err := WalkExportFiles(journalDir, countFile); err != nil {
+			return summary, err
+		}
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSummarizeExport(t *testing.T) {
+	root := t.TempDir()
+	notesDir := filepath.Join(root, "notes")
+	journalDir := filepath.Join(root, "journal")
+
+	files := map[string]string{
+		filepath.Join(notesDir, "a.md"):                     "hello",      // 5 bytes
+		filepath.Join(notesDir, "work", "b.md"):             "world!!",    // 7 bytes
+		filepath.Join(journalDir, "2025", "01", "entry.md"): "journaling", // 10 bytes
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name            string
+		includeNotes    bool
+		includeJournals bool
+		want            ExportSummary
+	}{
+		{"both", true, true, ExportSummary{Files: 3, Bytes: 22}},
+		{"notes only", true, false, ExportSummary{Files: 2, Bytes: 12}},
+		{"journals only", false, true, ExportSummary{Files: 1, Bytes: 10}},
+	}
+
+	for _, tt := range tests {
+		got, err := SummarizeExport(notesDir, journalDir, tt.includeNotes, tt.includeJournals)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: SummarizeExport() = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNoteAttachments(t *testing.T) {
+	dir := t.TempDir()
+	attachments := filepath.Join(dir, ".attachments")
+	if err := os.MkdirAll(attachments, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"chart.png", "unused.png"} {
+		if err := os.WriteFile(filepath.Join(attachments, name), []byte("png"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	note := filepath.Join(dir, "report.md")
+	content := "![Chart](<.attachments/chart.png>)\n![Again](.attachments/chart.png)\n![Gone](.attachments/missing.png)\n![Web](https://example.com/x.png)\n"
+	if err := os.WriteFile(note, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := NoteAttachments(note)
+	if err != nil {
+		t.Fatalf("NoteAttachments returned error: %v", err)
+	}
+	want := []string{filepath.Join(attachments, "chart.png")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NoteAttachments = %v, want %v", got, want)
+	}
+}
+
+func TestInlineImages(t *testing.T) {
+	noteDir := t.TempDir()
+	attachments := filepath.Join(noteDir, ".attachments")
+	if err := os.MkdirAll(attachments, 0755); err != nil {
+		t.Fatal(err)
+	}
+	png := []byte("\x89PNG fake image")
+	if err := os.WriteFile(filepath.Join(attachments, "my chart.png"), png, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	content := "# Report\n\n" +
+		"![Chart](<.attachments/my chart.png>)\n" +
+		"![Again](.attachments/my%20chart.png)\n" +
+		"![Web](https://example.com/x.png)\n" +
+		"![Gone](.attachments/missing.png)\n" +
+		"Write `![Chart](.attachments/my chart.png)` to embed, then ![Chart](.attachments/my%20chart.png)\n" +
+		"```\n![Chart](.attachments/my chart.png)\n```\n"
+
+	got, embedded := InlineImages(content, noteDir)
+	dataURI := "data:image/png;base64," + base64.StdEncoding.EncodeToString(png)
+	want := "# Report\n\n" +
+		"![Chart](" + dataURI + ")\n" +
+		"![Again](" + dataURI + ")\n" +
+		"![Web](https://example.com/x.png)\n" +
+		"![Gone](.attachments/missing.png)\n" +
+		"Write `![Chart](.attachments/my chart.png)` to embed, then ![Chart](" + dataURI + ")\n" +
+		"```\n![Chart](.attachments/my chart.png)\n```\n"
+	if got != want || embedded != 3 {
+		t.Errorf("InlineImages() = %q (%d embedded), want %q (3 embedded)", got, embedded, want)
+	}
+}
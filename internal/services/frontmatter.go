@@ -0,0 +1,333 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// frontMatterListKeys are always present in canonical frontmatter, written as the
+// comma-separated lists parseTags and parseKeywords read
+var frontMatterListKeys = []string{"tags", "keywords"}
+
+// FrontMatterFixResult describes the notes checked by FixFrontMatter
+type FrontMatterFixResult struct {
+	Checked int
+	Changed []string         // Relative paths whose frontmatter was (or, in a dry run, would be) rewritten
+	Failed  map[string]error // Relative paths whose frontmatter couldn't be parsed, left untouched
+}
+
+// FixFrontMatter rewrites every note's frontmatter into canonical form (see
+// CanonicalFrontMatter), skipping templates. With dryRun nothing is written.
+func (s *NotesService) FixFrontMatter(dryRun bool) (FrontMatterFixResult, error) {
+	result := FrontMatterFixResult{Failed: make(map[string]error)}
+
+	err := filepath.Walk(s.notesDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() && path == s.templatesDir {
+			return filepath.SkipDir
+		}
+		if info.IsDir() || !strings.HasSuffix(info.Name(), ".md") {
+			return nil
+		}
+
+		relPath, _ := filepath.Rel(s.notesDir, path)
+		result.Checked++
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			result.Failed[relPath] = err
+			return nil
+		}
+		fixed, err := CanonicalFrontMatter(string(content))
+		if err != nil {
+			result.Failed[relPath] = err
+			return nil
+		}
+		if fixed == string(content) {
+			return nil
+		}
+
+		if !dryRun {
+			if err := os.WriteFile(path, []byte(fixed), info.Mode().Perm()); err != nil {
+				result.Failed[relPath] = fmt.Errorf("failed to write note: %w", err)
+				return nil
+			}
+		}
+		result.Changed = append(result.Changed, relPath)
+		return nil
+	})
+	if err != nil {
+		return result, fmt.Errorf("failed to scan notes: %w", err)
+	}
+
+	sort.Strings(result.Changed)
+	return result, nil
+}
+
+// CanonicalFrontMatter returns content with its YAML frontmatter reserialized: ---
+// delimiters, tags and keywords keys present as comma-separated lists, and other keys
+// in their original order with two-space indentation. Everything after the closing
+// delimiter is kept byte-for-byte. A note without frontmatter gets an empty block.
+func CanonicalFrontMatter(content string) (string, error) {
+	frontMatter, body, ok, err := splitFrontMatter(content)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return defaultNoteFrontMatter + content, nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(frontMatter), &doc); err != nil {
+		return "", fmt.Errorf("invalid frontmatter: %w", err)
+	}
+
+	mapping := &yaml.Node{Kind: yaml.MappingNode}
+	if len(doc.Content) > 0 {
+		mapping = doc.Content[0]
+	}
+	if mapping.Kind == yaml.ScalarNode && mapping.Tag == "!!null" {
+		mapping = &yaml.Node{Kind: yaml.MappingNode}
+	}
+	if mapping.Kind != yaml.MappingNode {
+		return "", fmt.Errorf("frontmatter is not a list of key: value pairs")
+	}
+
+	var out strings.Builder
+	out.WriteString("---\n")
+
+	seen := make(map[string]bool)
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i], mapping.Content[i+1]
+
+		if isFrontMatterListKey(key.Value) {
+			items, err := frontMatterListItems(value)
+			if err != nil {
+				return "", fmt.Errorf("invalid %s: %w", key.Value, err)
+			}
+			writeFrontMatterList(&out, key.Value, items)
+			seen[key.Value] = true
+			continue
+		}
+
+		pair, err := encodeFrontMatterPair(key, value)
+		if err != nil {
+			return "", fmt.Errorf("failed to write %s: %w", key.Value, err)
+		}
+		out.WriteString(pair)
+	}
+
+	for _, key := range frontMatterListKeys {
+		if !seen[key] {
+			writeFrontMatterList(&out, key, nil)
+		}
+	}
+
+	out.WriteString("---\n")
+	return out.String() + body, nil
+}
+
+// splitFrontMatter returns the text between a note's opening and closing --- lines and
+// everything after the closing line. ok is false if the note has no frontmatter.
+func splitFrontMatter(content string) (frontMatter, body string, ok bool, err error) {
+	firstEnd := strings.IndexByte(content, '\n')
+	if firstEnd < 0 {
+		firstEnd = len(content)
+	}
+	switch strings.TrimSpace(content[:firstEnd]) {
+	case "---":
+	case "+++":
+		return "", "", false, fmt.Errorf("TOML frontmatter is not supported")
+	default:
+		return "", "", false, nil
+	}
+
+	// Look for the closing delimiter line by line
+	start := firstEnd + 1
+	for pos := start; pos <= len(content); {
+		end := strings.IndexByte(content[pos:], '\n')
+		next := len(content)
+		if end >= 0 {
+			next = pos + end + 1
+		}
+		if strings.TrimSpace(content[pos:next]) == "---" {
+			return content[start:pos], content[next:], true, nil
+		}
+		if end < 0 {
+			break
+		}
+		pos = next
+	}
+
+	return "", "", false, fmt.Errorf("frontmatter has no closing ---")
+}
+
+func isFrontMatterListKey(key string) bool {
+	for _, k := range frontMatterListKeys {
+		if key == k {
+			return true
+		}
+	}
+	return false
+}
+
+// frontMatterListItems reads a tags/keywords value written as "a, b", [a, b], or a "- a" list
+func frontMatterListItems(value *yaml.Node) ([]string, error) {
+	var raw []string
+	switch value.Kind {
+	case yaml.ScalarNode:
+		raw = strings.Split(value.Value, ",")
+	case yaml.SequenceNode:
+		for _, item := range value.Content {
+			if item.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("list items must be plain values")
+			}
+			raw = append(raw, item.Value)
+		}
+	default:
+		return nil, fmt.Errorf("expected a list of values")
+	}
+
+	var items []string
+	for _, item := range raw {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func writeFrontMatterList(out *strings.Builder, key string, items []string) {
+	out.WriteString(key + ":")
+	if len(items) > 0 {
+		out.WriteString(" " + strings.Join(items, ", "))
+	}
+	out.WriteString("\n")
+}
+
+// encodeFrontMatterPair serializes one key: value pair as YAML with two-space indentation
+func encodeFrontMatterPair(key, value *yaml.Node) (string, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{key, value}}); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// AddFrontMatterTags returns content with tags merged into its frontmatter tags: line,
+// written as a comma-separated list. Existing tags are kept and duplicates dropped. A
+// note without frontmatter gets the default block first; other keys are left as written.
+func AddFrontMatterTags(content string, tags []string) (string, error) {
+	frontMatter, body, ok, err := splitFrontMatter(content)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return AddFrontMatterTags(defaultNoteFrontMatter+content, tags)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(frontMatter), &doc); err != nil {
+		return "", fmt.Errorf("invalid frontmatter: %w", err)
+	}
+
+	// Start from the tags already there, however they were written
+	var merged []string
+	seen := make(map[string]bool)
+	addTag := func(tag string) {
+		tag = strings.TrimSpace(tag)
+		if tag != "" && !seen[strings.ToLower(tag)] {
+			seen[strings.ToLower(tag)] = true
+			merged = append(merged, tag)
+		}
+	}
+	if len(doc.Content) > 0 && doc.Content[0].Kind == yaml.MappingNode {
+		mapping := doc.Content[0]
+		for i := 0; i+1 < len(mapping.Content); i += 2 {
+			if mapping.Content[i].Value != "tags" {
+				continue
+			}
+			existing, err := frontMatterListItems(mapping.Content[i+1])
+			if err != nil {
+				return "", fmt.Errorf("invalid tags: %w", err)
+			}
+			for _, tag := range existing {
+				addTag(tag)
+			}
+		}
+	}
+	for _, tag := range tags {
+		addTag(tag)
+	}
+
+	// Replace the tags key (and any list lines under it) in place, or add it first
+	var out strings.Builder
+	out.WriteString("---\n")
+	lines := strings.SplitAfter(frontMatter, "\n")
+	replaced := false
+	for i := 0; i < len(lines); i++ {
+		if lines[i] == "" {
+			continue
+		}
+		if !strings.HasPrefix(lines[i], "tags:") {
+			out.WriteString(lines[i])
+			continue
+		}
+		for i+1 < len(lines) && (strings.HasPrefix(lines[i+1], " ") || strings.HasPrefix(lines[i+1], "\t") || strings.HasPrefix(lines[i+1], "-")) {
+			i++
+		}
+		if !replaced {
+			writeFrontMatterList(&out, "tags", merged)
+			replaced = true
+		}
+	}
+	result := out.String()
+	if !replaced {
+		var tagsLine strings.Builder
+		writeFrontMatterList(&tagsLine, "tags", merged)
+		result = "---\n" + tagsLine.String() + strings.TrimPrefix(result, "---\n")
+	}
+
+	return result + "---\n" + body, nil
+}
+
+// SetFrontMatterPinned returns content with its frontmatter's pinned: key set to true,
+// or removed when unpinning. A note without frontmatter gets the default block first
+// when it's pinned; other keys are left as written.
+func SetFrontMatterPinned(content string, pinned bool) (string, error) {
+	frontMatter, body, ok, err := splitFrontMatter(content)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		if !pinned {
+			return content, nil
+		}
+		return SetFrontMatterPinned(defaultNoteFrontMatter+content, pinned)
+	}
+
+	var out strings.Builder
+	out.WriteString("---\n")
+	for _, line := range strings.SplitAfter(frontMatter, "\n") {
+		if line != "" && !strings.HasPrefix(line, "pinned:") {
+			out.WriteString(line)
+		}
+	}
+	if pinned {
+		out.WriteString("pinned: true\n")
+	}
+	return out.String() + "---\n" + body, nil
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCanonicalFrontMatterNormalizesLists(t *testing.T) {
+	body := "# Standup\n\n---\n\nNotes with  trailing spaces  \n"
+	content := "---\ntitle: Standup\ntags:\n    - work\n    - meetings\nattendees:\n    alice:\n    bob:\n        email: bob@example.com\n---\n" + body
+
+	got, err := CanonicalFrontMatter(content)
+	if err != nil {
+		t.Fatalf("CanonicalFrontMatter returned error: %v", err)
+	}
+
+	want := "---\ntitle: Standup\ntags: work, meetings\nattendees:\n  alice:\n  bob:\n    email: bob@example.com\nkeywords:\n---\n" + body
+	if got != want {
+		t.Errorf("CanonicalFrontMatter =\n%s\nwant\n%s", got, want)
+	}
+	if !strings.HasSuffix(got, body) {
+		t.Error("note body changed")
+	}
+
+	// The parsers read the rewritten frontmatter
+	wantAttendees := []Attendee{{Name: "alice"}, {Name: "bob", Email: "bob@example.com"}}
+	if attendees := parseAttendees(got); !reflect.DeepEqual(attendees, wantAttendees) {
+		t.Errorf("parseAttendees = %#v, want %#v", attendees, wantAttendees)
+	}
+
+	// Flow lists and notes without frontmatter
+	got, err = CanonicalFrontMatter("---\nkeywords: [go, ' tui ']\n---\nBody")
+	if err != nil {
+		t.Fatalf("CanonicalFrontMatter returned error: %v", err)
+	}
+	if want := "---\nkeywords: go, tui\ntags:\n---\nBody"; got != want {
+		t.Errorf("CanonicalFrontMatter = %q, want %q", got, want)
+	}
+	if got, _ := CanonicalFrontMatter("Just text\n"); got != defaultNoteFrontMatter+"Just text\n" {
+		t.Errorf("note without frontmatter = %q", got)
+	}
+
+	if _, err := CanonicalFrontMatter("---\ntags: [unclosed\n---\nBody"); err == nil {
+		t.Error("expected an error for invalid YAML")
+	}
+	if _, err := CanonicalFrontMatter("---\ntags: a\nBody"); err == nil {
+		t.Error("expected an error for frontmatter without a closing ---")
+	}
+}
+
+func TestCanonicalFrontMatterIdempotent(t *testing.T) {
+	for _, content := range []string{
+		defaultNoteFrontMatter + "# \n\n",
+		"---\ntags: work, meetings\nkeywords: go\ntitle: \"Plan: Q1\"\nweight: 2\n---\n\n# Plan\n",
+		"---\ntags: a\nkeywords:\n---\nno trailing newline",
+	} {
+		got, err := CanonicalFrontMatter(content)
+		if err != nil {
+			t.Fatalf("CanonicalFrontMatter(%q) returned error: %v", content, err)
+		}
+		if got != content {
+			t.Errorf("clean note changed:\n%q\nbecame\n%q", content, got)
+		}
+	}
+}
+
+func TestFixFrontMatterDryRun(t *testing.T) {
+	notesDir := t.TempDir()
+	s := NewNotesService(notesDir)
+
+	messy := "---\ntags: [a, b]\n---\nBody\n"
+	clean := defaultNoteFrontMatter + "Clean\n"
+	files := map[string]string{
+		"messy.md":           messy,
+		"sub/clean.md":       clean,
+		"broken.md":          "---\ntags: [a\n---\n",
+		".templates/tmpl.md": "---\ntags: [x]\n---\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(notesDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := s.FixFrontMatter(true)
+	if err != nil {
+		t.Fatalf("FixFrontMatter returned error: %v", err)
+	}
+	if result.Checked != 3 || !reflect.DeepEqual(result.Changed, []string{"messy.md"}) || result.Failed["broken.md"] == nil {
+		t.Errorf("dry run result = %+v", result)
+	}
+	if data, _ := os.ReadFile(filepath.Join(notesDir, "messy.md")); string(data) != messy {
+		t.Error("dry run modified a note")
+	}
+
+	if _, err := s.FixFrontMatter(false); err != nil {
+		t.Fatalf("FixFrontMatter returned error: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(notesDir, "messy.md")); string(data) != "---\ntags: a, b\nkeywords:\n---\nBody\n" {
+		t.Errorf("fixed note = %q", data)
+	}
+	if data, _ := os.ReadFile(filepath.Join(notesDir, ".templates", "tmpl.md")); string(data) != files[".templates/tmpl.md"] {
+		t.Error("templates should not be rewritten")
+	}
+}
+
+func TestAddFrontMatterTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"empty tags", defaultNoteFrontMatter + "# \n\n", "---\ntags: work, ideas\nkeywords:\n---\n\n# \n\n"},
+		{"merged", "---\ntitle: Plan\ntags: Work, q1\n---\nBody\n", "---\ntitle: Plan\ntags: Work, q1, ideas\n---\nBody\n"},
+		{"block list", "---\ntags:\n  - q1\nkeywords: go\n---\nBody\n", "---\ntags: q1, work, ideas\nkeywords: go\n---\nBody\n"},
+		{"no tags key", "---\ntitle: Plan\n---\nBody\n", "---\ntags: work, ideas\ntitle: Plan\n---\nBody\n"},
+		{"no frontmatter", "Body\n", "---\ntags: work, ideas\nkeywords:\n---\n\nBody\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AddFrontMatterTags(tt.content, []string{"work", " ideas", ""})
+			if err != nil {
+				t.Fatalf("AddFrontMatterTags returned error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("AddFrontMatterTags = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetFrontMatterPinned(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		pinned  bool
+		want    string
+	}{
+		{"pin", "---\ntitle: Plan\ntags: work\n---\nBody\n", true, "---\ntitle: Plan\ntags: work\npinned: true\n---\nBody\n"},
+		{"pin again", "---\npinned: true\ntitle: Plan\n---\nBody\n", true, "---\ntitle: Plan\npinned: true\n---\nBody\n"},
+		{"unpin", "---\ntitle: Plan\npinned: true\ntags: work\n---\nBody\n", false, "---\ntitle: Plan\ntags: work\n---\nBody\n"},
+		{"pin without frontmatter", "Body\n", true, "---\ntags:\nkeywords:\npinned: true\n---\n\nBody\n"},
+		{"unpin without frontmatter", "Body\n", false, "Body\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SetFrontMatterPinned(tt.content, tt.pinned)
+			if err != nil {
+				t.Fatalf("SetFrontMatterPinned returned error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("SetFrontMatterPinned = %q, want %q", got, tt.want)
+			}
+			if parsePinned(got) != tt.pinned {
+				t.Errorf("parsePinned(%q) = %v, want %v", got, !tt.pinned, tt.pinned)
+			}
+		})
+	}
+}
@@ -4,20 +4,132 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
+
+	"github.com/redjax/notetkr/internal/config"
 )
 
+// DefaultJournalFilenameFormat is the Go time layout used for journal filenames
+const DefaultJournalFilenameFormat = "2006-01-02.md"
+
+// CheckJournalFilenameFormat verifies a layout can be written and parsed back to the same day
+func CheckJournalFilenameFormat(layout string) error {
+	if !strings.HasSuffix(layout, ".md") {
+		layout += ".md"
+	}
+
+	sample := time.Date(2025, time.December, 31, 0, 0, 0, 0, time.UTC)
+	parsed, err := time.Parse(layout, sample.Format(layout))
+	if err != nil {
+		return fmt.Errorf("journal filename format %q cannot be parsed back: %w", layout, err)
+	}
+	if !parsed.Equal(sample) {
+		return fmt.Errorf("journal filename format %q must include the year, month and day", layout)
+	}
+
+	return nil
+}
+
+// ParseWeekStart parses a week start day setting ("sunday" or "monday").
+// An empty value is Sunday.
+func ParseWeekStart(day string) (time.Weekday, error) {
+	switch strings.ToLower(strings.TrimSpace(day)) {
+	case "", "sunday":
+		return time.Sunday, nil
+	case "monday":
+		return time.Monday, nil
+	default:
+		return time.Sunday, fmt.Errorf("week start %q must be \"sunday\" or \"monday\"", day)
+	}
+}
+
 // JournalService handles journal-related operations
 type JournalService struct {
-	journalDir string
+	journalDir     string
+	filenameFormat string
+	weekStart      time.Weekday
+	dailyLinks     bool   // New entries link to the previous and next day
+	templatePath   string // Template file new entries start from, if it exists
 }
 
-// NewJournalService creates a new journal service
-func NewJournalService(journalDir string) *JournalService {
+// JournalOptions controls how a JournalService names and starts entries
+type JournalOptions struct {
+	// WeekStart is the day weeks begin on
+	WeekStart time.Weekday
+
+	// FilenameFormat is the Go time layout entries are named with. A missing .md
+	// extension is added; empty uses DefaultJournalFilenameFormat.
+	FilenameFormat string
+
+	// DailyLinks starts new entries with links to the previous and next day's entries
+	DailyLinks bool
+
+	// Template is the file new entries start from. While it doesn't exist, entries
+	// get the built-in layout.
+	Template string
+}
+
+// JournalOptionsFromConfig returns the journal options cfg sets. An unknown week
+// start falls back to Sunday, as ParseWeekStart does.
+func JournalOptionsFromConfig(cfg *config.Config) JournalOptions {
+	weekStart, _ := ParseWeekStart(cfg.WeekStartsOn)
+
+	return JournalOptions{
+		WeekStart:      weekStart,
+		FilenameFormat: cfg.JournalFilenameFormat,
+		DailyLinks:     cfg.JournalDailyLinks,
+		Template:       cfg.JournalTemplate,
+	}
+}
+
+// NewJournalService creates a new journal service whose weeks begin on weekStart
+func NewJournalService(journalDir string, weekStart time.Weekday) *JournalService {
+	return NewJournalServiceWithOptions(journalDir, JournalOptions{WeekStart: weekStart})
+}
+
+// NewJournalServiceWithOptions creates a new journal service set up with opts
+func NewJournalServiceWithOptions(journalDir string, opts JournalOptions) *JournalService {
+	layout := opts.FilenameFormat
+	if layout == "" {
+		layout = DefaultJournalFilenameFormat
+	}
+	if !strings.HasSuffix(layout, ".md") {
+		layout += ".md"
+	}
+
 	return &JournalService{
-		journalDir: journalDir,
+		journalDir:     journalDir,
+		filenameFormat: layout,
+		weekStart:      opts.WeekStart,
+		dailyLinks:     opts.DailyLinks,
+		templatePath:   opts.Template,
+	}
+}
+
+// WeekStart returns the day weeks begin on
+func (j *JournalService) WeekStart() time.Weekday {
+	return j.weekStart
+}
+
+// startOfWeek moves date back to the first day of its week
+func (j *JournalService) startOfWeek(date time.Time) time.Time {
+	for date.Weekday() != j.weekStart {
+		date = date.AddDate(0, 0, -1)
 	}
+	return date
+}
+
+// JournalFilename returns the journal filename for a date using the configured layout
+func (j *JournalService) JournalFilename(date time.Time) string {
+	return date.Format(j.filenameFormat)
+}
+
+// ParseJournalFilename parses a journal filename written with the configured layout
+func (j *JournalService) ParseJournalFilename(filename string) (time.Time, error) {
+	return time.Parse(j.filenameFormat, filename)
 }
 
 // GetJournalDir returns the journal directory path
@@ -30,38 +142,73 @@ func (j *JournalService) GetTodayJournalPath() string {
 	return j.GetJournalPathForDate(time.Now())
 }
 
-// GetJournalPathForDate returns the journal path for a specific date
+// GetJournalPathForDate returns the journal path for a specific date. An existing
+// entry is found in any week folder of its month, since changing the week start day
+// moves dates between weeks; otherwise it's where a new entry for the date goes.
 func (j *JournalService) GetJournalPathForDate(date time.Time) string {
+	journalPath := j.weekJournalPath(date)
+	if _, err := os.Stat(journalPath); err == nil {
+		return journalPath
+	}
+	if existing, ok := j.findJournalEntry(date); ok {
+		return existing
+	}
+	return journalPath
+}
+
+// weekJournalPath returns where the entry for date goes under the current week start day
+func (j *JournalService) weekJournalPath(date time.Time) string {
 	year := date.Format("2006")
 	month := date.Format("01")
-	weekNum := getWeekOfMonth(date)
-	filename := date.Format("2006-01-02.md")
+	weekNum := getWeekOfMonth(date, j.weekStart)
+	filename := j.JournalFilename(date)
 
 	return filepath.Join(j.journalDir, year, month, fmt.Sprintf("Week%d", weekNum), filename)
 }
 
+// findJournalEntry looks for the entry for date in each week folder of its month
+func (j *JournalService) findJournalEntry(date time.Time) (string, bool) {
+	monthDir := filepath.Join(j.journalDir, date.Format("2006"), date.Format("01"))
+	entries, err := os.ReadDir(monthDir)
+	if err != nil {
+		return "", false
+	}
+
+	filename := j.JournalFilename(date)
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), "Week") {
+			continue
+		}
+		candidate := filepath.Join(monthDir, entry.Name(), filename)
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
 // getWeekOfMonth calculates which week of the month the date falls in
-// Weeks start on Sunday
-func getWeekOfMonth(date time.Time) int {
+// Weeks start on weekStart
+func getWeekOfMonth(date time.Time, weekStart time.Weekday) int {
 	// Get the first day of the month
 	firstDay := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
 
-	// Find the first Sunday of the month (or if 1st is Sunday, that's week 1)
-	firstSunday := firstDay
-	for firstSunday.Weekday() != time.Sunday {
-		firstSunday = firstSunday.AddDate(0, 0, 1)
+	// Find the first week start day of the month (or if the 1st is one, that's week 1)
+	firstStart := firstDay
+	for firstStart.Weekday() != weekStart {
+		firstStart = firstStart.AddDate(0, 0, 1)
 	}
 
-	// If the date is before the first Sunday, it's in Week 1
-	if date.Before(firstSunday) {
+	// If the date is before the first week start day, it's in Week 1
+	if date.Before(firstStart) {
 		return 1
 	}
 
-	// Calculate days since first Sunday
-	daysSinceFirstSunday := int(date.Sub(firstSunday).Hours() / 24)
+	// Calculate days since the first week start day
+	daysSinceFirstStart := int(date.Sub(firstStart).Hours() / 24)
 
-	// Week number is (days since first Sunday / 7) + 1
-	weekNum := (daysSinceFirstSunday / 7) + 1
+	// Week number is (days since first week start day / 7) + 1
+	weekNum := (daysSinceFirstStart / 7) + 1
 
 	return weekNum
 }
@@ -89,9 +236,12 @@ func (j *JournalService) CreateOrOpenJournal(date time.Time) (string, bool, erro
 
 	// Check if file exists
 	if _, err := os.Stat(journalPath); os.IsNotExist(err) {
-		// Create new journal entry with header
-		header := fmt.Sprintf("# Journal Entry - %s\n\n## Tasks\n\n- \n", date.Format("Monday, January 2, 2006"))
-		if err := os.WriteFile(journalPath, []byte(header), 0644); err != nil {
+		// Create new journal entry from the template
+		content, err := j.newEntryContent(date)
+		if err != nil {
+			return "", false, err
+		}
+		if err := os.WriteFile(journalPath, []byte(content), 0644); err != nil {
 			return "", false, fmt.Errorf("failed to create journal file: %w", err)
 		}
 		return journalPath, true, nil // Was newly created
@@ -100,11 +250,55 @@ func (j *JournalService) CreateOrOpenJournal(date time.Time) (string, bool, erro
 	return journalPath, false, nil // Already existed
 }
 
+// DailyLinks returns a line linking the journal entry for date to the entries for the
+// day before and the day after, relative to its own file. The next day's link is a
+// placeholder until that entry is written.
+func (j *JournalService) DailyLinks(date time.Time) string {
+	entryDir := filepath.Dir(j.GetJournalPathForDate(date))
+
+	link := func(label string, day time.Time) string {
+		target, err := filepath.Rel(entryDir, j.GetJournalPathForDate(day))
+		if err != nil {
+			target = j.GetJournalPathForDate(day)
+		}
+		target = filepath.ToSlash(target)
+		if strings.Contains(target, " ") {
+			target = "<" + target + ">"
+		}
+		return fmt.Sprintf("[%s](%s)", label, target)
+	}
+
+	prev, next := date.AddDate(0, 0, -1), date.AddDate(0, 0, 1)
+	return link("← "+prev.Format("Monday, January 2"), prev) + " | " + link(next.Format("Monday, January 2")+" →", next)
+}
+
+// dailyLinksRegex matches a line written by DailyLinks
+var dailyLinksRegex = regexp.MustCompile(`^\[← [^\]]*\]\([^)]*\) \| \[[^\]]* →\]\([^)]*\)$`)
+
 // DeleteJournal deletes a journal file
 func (j *JournalService) DeleteJournal(filePath string) error {
 	return os.Remove(filePath)
 }
 
+// DeleteJournalForDate deletes the journal entry for a specific date
+// Returns the removed path, or an error if no entry exists for that date
+func (j *JournalService) DeleteJournalForDate(date time.Time) (string, error) {
+	journalPath := j.GetJournalPathForDate(date)
+
+	if _, err := os.Stat(journalPath); err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("no journal entry exists for %s", date.Format("2006-01-02"))
+		}
+		return "", fmt.Errorf("failed to check journal: %w", err)
+	}
+
+	if err := j.DeleteJournal(journalPath); err != nil {
+		return "", fmt.Errorf("failed to delete journal: %w", err)
+	}
+
+	return journalPath, nil
+}
+
 // ReadJournal reads the contents of a journal file
 func (j *JournalService) ReadJournal(date time.Time) (string, error) {
 	journalPath := j.GetJournalPathForDate(date)
@@ -116,6 +310,22 @@ func (j *JournalService) ReadJournal(date time.Time) (string, error) {
 		}
 		return "", fmt.Errorf("failed to read journal: %w", err)
 	}
+	if looksBinary(content) {
+		return "", fmt.Errorf("refusing to open %s: %w", filepath.Base(journalPath), ErrBinaryContent)
+	}
+
+	return string(content), nil
+}
+
+// ReadJournalFile reads a journal file by path, refusing files that don't look like text
+func (j *JournalService) ReadJournalFile(filePath string) (string, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", err
+	}
+	if looksBinary(content) {
+		return "", fmt.Errorf("refusing to open %s: %w", filepath.Base(filePath), ErrBinaryContent)
+	}
 
 	return string(content), nil
 }
@@ -140,24 +350,48 @@ type JournalEntry struct {
 	Date     time.Time
 	FilePath string
 	Preview  string
+	Match    Snippet // The first matching line, when found by a search
+}
+
+// SummaryEntry is a saved weekly or monthly summary found by SearchSummaries
+type SummaryEntry struct {
+	Title    string    // e.g. "Week of January 5, 2025" or "January 2025"
+	Date     time.Time // Start of the summarized week or month, zero if the filename isn't recognized
+	FilePath string
+	Preview  string
+	Match    Snippet
+}
+
+// summariesDir is where weekly and monthly summaries are saved
+func (j *JournalService) summariesDir() string {
+	return filepath.Join(j.journalDir, "summaries")
 }
 
-// SearchJournals searches all journal entries by content
-func (j *JournalService) SearchJournals(query string) ([]JournalEntry, error) {
+// SearchJournals searches all journal entries by content, matching the query as
+// opts asks. Entries are searched whole, so opts.FullFile makes no difference.
+// Summaries are searched separately by SearchSummaries.
+func (j *JournalService) SearchJournals(query string, opts SearchOptions) ([]JournalEntry, error) {
 	var results []JournalEntry
 
 	if query == "" {
 		return results, nil
 	}
 
-	query = strings.ToLower(query)
+	match, err := NewSearchMatcher(query, opts)
+	if err != nil {
+		return nil, err
+	}
 
 	// Walk through all journal files
-	err := filepath.Walk(j.journalDir, func(path string, info os.FileInfo, err error) error {
+	err = filepath.Walk(j.journalDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return nil // Skip files we can't access
 		}
 
+		if info.IsDir() && path == j.summariesDir() {
+			return filepath.SkipDir
+		}
+
 		// Only process .md files
 		if info.IsDir() || filepath.Ext(path) != ".md" {
 			return nil
@@ -171,30 +405,23 @@ func (j *JournalService) SearchJournals(query string) ([]JournalEntry, error) {
 
 		contentStr := string(content)
 
-		// Check if content contains query
-		if strings.Contains(strings.ToLower(contentStr), query) {
-			// Try to parse date from filename (format: YYYY-MM-DD.md)
-			filename := filepath.Base(path)
-			dateStr := strings.TrimSuffix(filename, ".md")
-			date, err := time.Parse("2006-01-02", dateStr)
+		// Check if content matches query
+		if match(contentStr) {
+			// Try to parse date from filename (configured layout, YYYY-MM-DD.md by default)
+			date, err := j.ParseJournalFilename(filepath.Base(path))
 			if err != nil {
 				// If we can't parse the date, skip this entry
 				return nil
 			}
 
-			// Create preview (first 100 chars or first line)
-			preview := contentStr
-			if len(preview) > 100 {
-				preview = preview[:100] + "..."
-			}
-			if idx := strings.Index(preview, "\n"); idx > 0 && idx < 100 {
-				preview = preview[:idx] + "..."
-			}
+			// Preview the first line containing the query
+			snippet, _ := FindMatchSnippet(contentStr, query, opts, SnippetWidth)
 
 			results = append(results, JournalEntry{
 				Date:     date,
 				FilePath: path,
-				Preview:  preview,
+				Preview:  snippet.Text,
+				Match:    snippet,
 			})
 		}
 
@@ -208,17 +435,89 @@ func (j *JournalService) SearchJournals(query string) ([]JournalEntry, error) {
 	return results, nil
 }
 
-// GetWeekBoundaries returns the start (Sunday) and end (Saturday) dates for a given date's week
-func (j *JournalService) GetWeekBoundaries(date time.Time) (start time.Time, end time.Time) {
-	// Find the Sunday of this week
-	start = date
-	for start.Weekday() != time.Sunday {
-		start = start.AddDate(0, 0, -1)
+// SearchSummaries searches the saved weekly and monthly summaries by content,
+// matching the query as opts asks
+func (j *JournalService) SearchSummaries(query string, opts SearchOptions) ([]SummaryEntry, error) {
+	var results []SummaryEntry
+
+	if query == "" {
+		return results, nil
+	}
+
+	match, err := NewSearchMatcher(query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	err = filepath.Walk(j.summariesDir(), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil // Skip files we can't access, including a missing summaries directory
+		}
+
+		if info.IsDir() || filepath.Ext(path) != ".md" {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil // Skip files we can't read
+		}
+
+		contentStr := string(content)
+		if !match(contentStr) {
+			return nil
+		}
+
+		// Keep summaries with unrecognized names, titled by their filename
+		title, date := summaryTitle(filepath.Base(path))
+		snippet, _ := FindMatchSnippet(contentStr, query, opts, SnippetWidth)
+
+		results = append(results, SummaryEntry{
+			Title:    title,
+			Date:     date,
+			FilePath: path,
+			Preview:  snippet.Text,
+			Match:    snippet,
+		})
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("error searching summaries: %w", err)
+	}
+
+	return results, nil
+}
+
+// summaryTitle names a summary file (week-2006-01-02.md or month-2006-01.md) and
+// returns the start of the period it covers. Other names are returned without .md.
+func summaryTitle(filename string) (string, time.Time) {
+	name := strings.TrimSuffix(filename, ".md")
+
+	if dateStr, ok := strings.CutPrefix(name, "week-"); ok {
+		if date, err := time.Parse("2006-01-02", dateStr); err == nil {
+			return "Week of " + date.Format("January 2, 2006"), date
+		}
 	}
+	if dateStr, ok := strings.CutPrefix(name, "month-"); ok {
+		if date, err := time.Parse("2006-01", dateStr); err == nil {
+			return date.Format("January 2006"), date
+		}
+	}
+
+	return name, time.Time{}
+}
+
+// GetWeekBoundaries returns the first and last days of a given date's week
+// (Sunday to Saturday, or Monday to Sunday if weeks start on Monday)
+func (j *JournalService) GetWeekBoundaries(date time.Time) (start time.Time, end time.Time) {
+	// Find the first day of this week
+	start = j.startOfWeek(date)
 	// Set to midnight
 	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
 
-	// End is 6 days after start (Saturday)
+	// End is 6 days after start
 	end = start.AddDate(0, 0, 6)
 	end = time.Date(end.Year(), end.Month(), end.Day(), 23, 59, 59, 0, end.Location())
 
@@ -256,12 +555,114 @@ func (j *JournalService) ExtractTasksSection(content string) string {
 	return strings.Join(taskLines, "\n")
 }
 
+// AddTasks appends items as bullets to the ## Tasks section of the journal entry for
+// date, creating the entry first if needed. Returns the entry's path.
+func (j *JournalService) AddTasks(date time.Time, items []string) (string, error) {
+	journalPath, _, err := j.CreateOrOpenJournal(date)
+	if err != nil {
+		return "", err
+	}
+
+	content, err := j.ReadJournalFile(journalPath)
+	if err != nil {
+		return "", err
+	}
+
+	ending := DetectLineEnding(content)
+	updated := AddToTasksSection(NormalizeLineEndings(content), items)
+	if err := os.WriteFile(journalPath, []byte(RestoreLineEndings(updated, ending)), 0644); err != nil {
+		return "", fmt.Errorf("failed to write journal: %w", err)
+	}
+
+	return journalPath, nil
+}
+
+// AddToTasksSection returns journal content with items added as "- item" bullets after
+// the last line of its ## Tasks section, before the next ## heading. Items already
+// written as list items are kept as they are, blank items are dropped, and an empty
+// "- " placeholder ending the section is replaced. A journal without a ## Tasks
+// section gets one at the end. Everything else is left untouched.
+func AddToTasksSection(content string, items []string) string {
+	var bullets []string
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if !taskBulletRegex.MatchString(item) {
+			item = "- " + item
+		}
+		bullets = append(bullets, item)
+	}
+	if len(bullets) == 0 {
+		return content
+	}
+
+	lines := strings.Split(content, "\n")
+
+	heading := -1
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "## Tasks") {
+			heading = i
+			break
+		}
+	}
+	if heading < 0 {
+		content = strings.TrimRight(content, "\n")
+		if content != "" {
+			content += "\n\n"
+		}
+		return content + "## Tasks\n\n" + strings.Join(bullets, "\n") + "\n"
+	}
+
+	// The section ends at the next ## heading; add after its last non-blank line
+	end := len(lines)
+	for i := heading + 1; i < len(lines); i++ {
+		if strings.HasPrefix(strings.TrimSpace(lines[i]), "##") {
+			end = i
+			break
+		}
+	}
+	last := heading
+	for i := end - 1; i > heading; i-- {
+		if strings.TrimSpace(lines[i]) != "" {
+			last = i
+			break
+		}
+	}
+
+	insert := bullets
+	insertAt := last + 1
+	if last == heading {
+		insert = append([]string{""}, bullets...)
+	} else if strings.TrimSpace(lines[last]) == "-" {
+		insertAt = last
+		lines = append(lines[:last], lines[last+1:]...)
+		end--
+	}
+	if insertAt == end && end < len(lines) {
+		// Keep a blank line before the next heading
+		insert = append(insert, "")
+	}
+
+	updated := make([]string, 0, len(lines)+len(insert))
+	updated = append(updated, lines[:insertAt]...)
+	updated = append(updated, insert...)
+	updated = append(updated, lines[insertAt:]...)
+	result := strings.Join(updated, "\n")
+	if !strings.HasSuffix(result, "\n") {
+		result += "\n"
+	}
+	return result
+}
+
+// taskBulletRegex matches text that is already a markdown list item
+var taskBulletRegex = regexp.MustCompile(`^[-*+]\s`)
+
 // GenerateWeeklySummary generates a weekly summary by combining all journal entries for a week
 func (j *JournalService) GenerateWeeklySummary(weekStart time.Time) (string, error) {
-	// Ensure weekStart is actually a Sunday
-	for weekStart.Weekday() != time.Sunday {
-		weekStart = weekStart.AddDate(0, 0, -1)
-	}
+	// Ensure weekStart is actually the first day of the week
+	weekStart = j.startOfWeek(weekStart)
 
 	weekEnd := weekStart.AddDate(0, 0, 6)
 
@@ -350,13 +751,11 @@ func (j *JournalService) GenerateWeeklySummary(weekStart time.Time) (string, err
 
 // GetWeeklySummaryPath returns the path for a weekly summary file
 func (j *JournalService) GetWeeklySummaryPath(weekStart time.Time) string {
-	// Ensure weekStart is a Sunday
-	for weekStart.Weekday() != time.Sunday {
-		weekStart = weekStart.AddDate(0, 0, -1)
-	}
+	// Ensure weekStart is the first day of the week
+	weekStart = j.startOfWeek(weekStart)
 
 	year := weekStart.Format("2006")
-	// Use the Sunday date as the filename
+	// Use the week's first date as the filename
 	filename := fmt.Sprintf("week-%s.md", weekStart.Format("2006-01-02"))
 
 	return filepath.Join(j.journalDir, "summaries", year, filename)
@@ -380,6 +779,120 @@ func (j *JournalService) SaveWeeklySummary(weekStart time.Time, summary string)
 	return nil
 }
 
+// GenerateMonthlySummary generates a summary of every journal entry in month's
+// year/month directory: a combined task list plus a per-week breakdown
+func (j *JournalService) GenerateMonthlySummary(month time.Time) (string, error) {
+	monthStart := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
+	monthDir := filepath.Join(j.journalDir, monthStart.Format("2006"), monthStart.Format("01"))
+
+	type dayTasks struct {
+		day   time.Time
+		tasks string
+	}
+	var dailyTasks []dayTasks
+
+	err := filepath.Walk(monthDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil // Skip files we can't access (or a month with no journals yet)
+		}
+		if info.IsDir() || filepath.Ext(path) != ".md" {
+			return nil
+		}
+
+		day, err := j.ParseJournalFilename(filepath.Base(path))
+		if err != nil {
+			return nil // Not a dated journal entry
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil // Skip days we can't read
+		}
+
+		if tasks := strings.TrimSpace(j.ExtractTasksSection(string(content))); tasks != "" {
+			dailyTasks = append(dailyTasks, dayTasks{day: day, tasks: tasks})
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("error reading journals for %s: %w", monthStart.Format("January 2006"), err)
+	}
+
+	sort.Slice(dailyTasks, func(a, b int) bool {
+		return dailyTasks[a].day.Before(dailyTasks[b].day)
+	})
+
+	// Build the summary header
+	summary := fmt.Sprintf("# Monthly Summary: %s\n\n", monthStart.Format("January 2006"))
+
+	// Collect individual task lines for the combined list
+	var allTasks []string
+	for _, dt := range dailyTasks {
+		for _, line := range strings.Split(dt.tasks, "\n") {
+			trimmed := strings.TrimSpace(line)
+			if trimmed != "" && (strings.HasPrefix(trimmed, "-") || strings.HasPrefix(trimmed, "*")) {
+				allTasks = append(allTasks, line)
+			}
+		}
+	}
+
+	// Generate combined task list
+	if len(allTasks) > 0 {
+		summary += "## All Tasks (Combined)\n\n"
+		for _, task := range allTasks {
+			summary += task + "\n"
+		}
+		summary += "\n"
+	}
+
+	// Generate per-week breakdown (weeks numbered like the journal's WeekN directories)
+	if len(dailyTasks) > 0 {
+		summary += "## Weekly Breakdown\n\n"
+		currentWeek := 0
+		for _, dt := range dailyTasks {
+			if week := getWeekOfMonth(dt.day, j.weekStart); week != currentWeek {
+				currentWeek = week
+				summary += fmt.Sprintf("### Week %d\n\n", week)
+			}
+			summary += fmt.Sprintf("#### %s\n\n", dt.day.Format("Monday, January 2"))
+			summary += dt.tasks + "\n\n"
+		}
+	}
+
+	if len(allTasks) == 0 {
+		summary += "*No tasks recorded this month.*\n"
+	}
+
+	// Save the summary to disk
+	if err := j.SaveMonthlySummary(monthStart, summary); err != nil {
+		// Log error but don't fail - we can still return the summary
+		fmt.Fprintf(os.Stderr, "Warning: failed to save monthly summary: %v\n", err)
+	}
+
+	return summary, nil
+}
+
+// GetMonthlySummaryPath returns the path for a monthly summary file
+func (j *JournalService) GetMonthlySummaryPath(month time.Time) string {
+	filename := fmt.Sprintf("month-%s.md", month.Format("2006-01"))
+	return filepath.Join(j.journalDir, "summaries", month.Format("2006"), filename)
+}
+
+// SaveMonthlySummary saves a monthly summary to disk
+func (j *JournalService) SaveMonthlySummary(month time.Time, summary string) error {
+	summaryPath := j.GetMonthlySummaryPath(month)
+
+	if err := os.MkdirAll(filepath.Dir(summaryPath), 0755); err != nil {
+		return fmt.Errorf("failed to create summary directory: %w", err)
+	}
+
+	if err := os.WriteFile(summaryPath, []byte(summary), 0644); err != nil {
+		return fmt.Errorf("failed to write summary file: %w", err)
+	}
+
+	return nil
+}
+
 // ListWeeklySummaries returns a list of all saved weekly summaries
 func (j *JournalService) ListWeeklySummaries() ([]WeeklySummaryInfo, error) {
 	var summaries []WeeklySummaryInfo
@@ -432,13 +945,9 @@ func (j *JournalService) ListWeeklySummaries() ([]WeeklySummaryInfo, error) {
 	}
 
 	// Sort summaries by week start date (newest first)
-	for i := 0; i < len(summaries)-1; i++ {
-		for j := i + 1; j < len(summaries); j++ {
-			if summaries[i].WeekStart.Before(summaries[j].WeekStart) {
-				summaries[i], summaries[j] = summaries[j], summaries[i]
-			}
-		}
-	}
+	sort.Slice(summaries, func(a, b int) bool {
+		return summaries[a].WeekStart.After(summaries[b].WeekStart)
+	})
 
 	return summaries, nil
 }
@@ -467,10 +976,8 @@ func (j *JournalService) ReadWeeklySummary(weekStart time.Time) (string, error)
 
 // HasJournalEntriesForWeek checks if there are any journal entries for the given week
 func (j *JournalService) HasJournalEntriesForWeek(weekStart time.Time) bool {
-	// Ensure weekStart is actually a Sunday
-	for weekStart.Weekday() != time.Sunday {
-		weekStart = weekStart.AddDate(0, 0, -1)
-	}
+	// Ensure weekStart is actually the first day of the week
+	weekStart = j.startOfWeek(weekStart)
 
 	weekEnd := weekStart.AddDate(0, 0, 6)
 
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// JournalStats summarizes how regularly the journal is kept
+type JournalStats struct {
+	CurrentStreak int // Consecutive days with an entry, ending today or yesterday
+	LongestStreak int // Most consecutive days with an entry ever
+	TotalEntries  int // Days with an entry
+}
+
+// GetStats counts the journal's entries and its streaks of consecutive days. An empty
+// or missing journal directory gives zeroes.
+func (j *JournalService) GetStats() (JournalStats, error) {
+	return j.statsAsOf(time.Now())
+}
+
+// statsAsOf computes GetStats as if today were the given day
+func (j *JournalService) statsAsOf(today time.Time) (JournalStats, error) {
+	var stats JournalStats
+
+	days := make(map[string]bool)
+	err := j.walkEntries(func(path string, date time.Time) {
+		days[date.Format("2006-01-02")] = true
+	})
+	if err != nil {
+		return stats, fmt.Errorf("error reading journal: %w", err)
+	}
+	if len(days) == 0 {
+		return stats, nil
+	}
+	stats.TotalEntries = len(days)
+
+	// Longest run of consecutive days
+	sorted := make([]string, 0, len(days))
+	for day := range days {
+		sorted = append(sorted, day)
+	}
+	sort.Strings(sorted)
+	run := 0
+	var prev time.Time
+	for _, day := range sorted {
+		date, _ := time.Parse("2006-01-02", day)
+		if run > 0 && prev.AddDate(0, 0, 1).Equal(date) {
+			run++
+		} else {
+			run = 1
+		}
+		stats.LongestStreak = max(stats.LongestStreak, run)
+		prev = date
+	}
+
+	// The current streak is still alive if the last entry was today or yesterday
+	day := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	if !days[day.Format("2006-01-02")] {
+		day = day.AddDate(0, 0, -1)
+	}
+	for days[day.Format("2006-01-02")] {
+		stats.CurrentStreak++
+		day = day.AddDate(0, 0, -1)
+	}
+
+	return stats, nil
+}
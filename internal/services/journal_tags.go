@@ -0,0 +1,78 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// walkEntries calls fn with the path and date of every journal entry: the files
+// named with the configured layout, outside the saved summaries
+func (j *JournalService) walkEntries(fn func(path string, date time.Time)) error {
+	return filepath.Walk(j.journalDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil // Skip files we can't access
+		}
+		if info.IsDir() {
+			if path == j.summariesDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		date, err := j.ParseJournalFilename(info.Name())
+		if err != nil {
+			return nil
+		}
+		fn(path, date)
+		return nil
+	})
+}
+
+// GetAllTags returns all unique tags used in journal entries, sorted. Tags are
+// written like in notes: in a "tags:" frontmatter line, or as #tag in the text.
+func (j *JournalService) GetAllTags() ([]string, error) {
+	tags := make(map[string]bool)
+	err := j.walkEntries(func(path string, date time.Time) {
+		entryTags, _ := extractTags(path)
+		for _, tag := range entryTags {
+			tags[tag] = true
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reading journal tags: %w", err)
+	}
+
+	result := make([]string, 0, len(tags))
+	for tag := range tags {
+		result = append(result, tag)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// FilterJournalsByTag returns the journal entries tagged with tag, newest first
+func (j *JournalService) FilterJournalsByTag(tag string) ([]JournalEntry, error) {
+	tag = normalizeTag(tag)
+
+	var results []JournalEntry
+	err := j.walkEntries(func(path string, date time.Time) {
+		entryTags, _ := extractTags(path)
+		for _, t := range entryTags {
+			if t == tag {
+				results = append(results, JournalEntry{Date: date, FilePath: path})
+				return
+			}
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error filtering journals: %w", err)
+	}
+
+	sort.Slice(results, func(a, b int) bool {
+		return results[a].Date.After(results[b].Date)
+	})
+	return results, nil
+}
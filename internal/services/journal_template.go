@@ -0,0 +1,63 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultTemplate returns the template matching the built-in layout of new entries,
+// for scaffolding a template file to edit
+func (j *JournalService) DefaultTemplate() string {
+	template := "# Journal Entry - {{weekday}}, {{longdate}}\n\n"
+	if j.dailyLinks {
+		template += "{{links}}\n\n"
+	}
+	return template + "## Tasks\n\n- \n"
+}
+
+// RenderJournalTemplate fills in a journal template's placeholders for date:
+// {{date}} (2006-01-02), {{weekday}} (Monday), {{longdate}} (January 2, 2006)
+// and {{links}} (links to the previous and next day's entries)
+func (j *JournalService) RenderJournalTemplate(template string, date time.Time) string {
+	replacer := strings.NewReplacer(
+		"{{date}}", date.Format("2006-01-02"),
+		"{{weekday}}", date.Format("Monday"),
+		"{{longdate}}", date.Format("January 2, 2006"),
+		"{{links}}", j.DailyLinks(date),
+	)
+	return replacer.Replace(template)
+}
+
+// newEntryContent returns the content a new journal entry for date starts with,
+// from the template file if there is one
+func (j *JournalService) newEntryContent(date time.Time) (string, error) {
+	template := j.DefaultTemplate()
+	if j.templatePath != "" {
+		data, err := os.ReadFile(j.templatePath)
+		switch {
+		case err == nil:
+			template = string(data)
+		case !os.IsNotExist(err):
+			return "", fmt.Errorf("failed to read journal template: %w", err)
+		}
+	}
+	return j.RenderJournalTemplate(template, date), nil
+}
+
+// WriteDefaultTemplate scaffolds the default journal template at path for the user
+// to edit. An existing template is never overwritten.
+func (j *JournalService) WriteDefaultTemplate(path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("journal template already exists: %s", path)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create template directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(j.DefaultTemplate()), 0644); err != nil {
+		return fmt.Errorf("failed to write journal template: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,516 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDeleteJournalForDate(t *testing.T) {
+	journalDir := t.TempDir()
+	j := NewJournalService(journalDir, time.Sunday)
+	date := time.Date(2025, time.January, 15, 0, 0, 0, 0, time.Local)
+
+	path, _, err := j.CreateOrOpenJournal(date)
+	if err != nil {
+		t.Fatalf("CreateOrOpenJournal returned error: %v", err)
+	}
+
+	removed, err := j.DeleteJournalForDate(date)
+	if err != nil {
+		t.Fatalf("DeleteJournalForDate returned error: %v", err)
+	}
+	if removed != path {
+		t.Errorf("DeleteJournalForDate removed %q, want %q", removed, path)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("journal file still exists after delete")
+	}
+}
+
+func TestDeleteJournalForDateMissingEntry(t *testing.T) {
+	j := NewJournalService(t.TempDir(), time.Sunday)
+	date := time.Date(2025, time.January, 16, 0, 0, 0, 0, time.Local)
+
+	if _, err := j.DeleteJournalForDate(date); err == nil {
+		t.Error("DeleteJournalForDate should return an error when no entry exists")
+	}
+}
+
+func TestJournalFilenameFormatRoundTrip(t *testing.T) {
+	if err := CheckJournalFilenameFormat("2006.01.02"); err != nil {
+		t.Fatalf("CheckJournalFilenameFormat rejected a valid layout: %v", err)
+	}
+
+	journalDir := t.TempDir()
+	j := NewJournalServiceWithOptions(journalDir, JournalOptions{FilenameFormat: "2006.01.02"})
+	date := time.Date(2025, time.March, 7, 0, 0, 0, 0, time.UTC)
+
+	path, _, err := j.CreateOrOpenJournal(date)
+	if err != nil {
+		t.Fatalf("CreateOrOpenJournal returned error: %v", err)
+	}
+	if got := filepath.Base(path); got != "2025.03.07.md" {
+		t.Errorf("journal filename = %q, want %q", got, "2025.03.07.md")
+	}
+
+	parsed, err := j.ParseJournalFilename(filepath.Base(path))
+	if err != nil {
+		t.Fatalf("ParseJournalFilename returned error: %v", err)
+	}
+	if !parsed.Equal(date) {
+		t.Errorf("ParseJournalFilename = %v, want %v", parsed, date)
+	}
+
+	// Search reads dates back with the same layout
+	results, err := j.SearchJournals("journal entry", SearchOptions{})
+	if err != nil {
+		t.Fatalf("SearchJournals returned error: %v", err)
+	}
+	if len(results) != 1 || !results[0].Date.Equal(date) {
+		t.Errorf("SearchJournals = %+v, want one entry dated %v", results, date)
+	}
+}
+
+func TestSearchJournalsWithOptions(t *testing.T) {
+	j := NewJournalService(t.TempDir(), time.Sunday)
+	date := time.Date(2025, time.March, 7, 0, 0, 0, 0, time.UTC)
+	if err := j.WriteJournal(date, "# Friday\n\nDeployed OPS-1234 to staging\n"); err != nil {
+		t.Fatal(err)
+	}
+	if err := j.SaveWeeklySummary(date, "# Week\n\n- Deployed OPS-1234\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		query string
+		opts  SearchOptions
+		want  int
+	}{
+		{"ops-1234", SearchOptions{}, 1},
+		{"ops-1234", SearchOptions{CaseSensitive: true}, 0},
+		{`OPS-\d{4}`, SearchOptions{Regex: true, CaseSensitive: true}, 1},
+		{`ops-\d{5}`, SearchOptions{Regex: true}, 0},
+	}
+	for _, tt := range tests {
+		journals, err := j.SearchJournals(tt.query, tt.opts)
+		if err != nil {
+			t.Fatalf("SearchJournals(%q) returned error: %v", tt.query, err)
+		}
+		summaries, err := j.SearchSummaries(tt.query, tt.opts)
+		if err != nil {
+			t.Fatalf("SearchSummaries(%q) returned error: %v", tt.query, err)
+		}
+		if len(journals) != tt.want || len(summaries) != tt.want {
+			t.Errorf("%q %+v: %d journals and %d summaries, want %d of each", tt.query, tt.opts, len(journals), len(summaries), tt.want)
+		}
+	}
+
+	if _, err := j.SearchJournals("deploy(", SearchOptions{Regex: true}); err == nil {
+		t.Error("expected an invalid pattern to be rejected")
+	}
+}
+
+func TestCheckJournalFilenameFormatRejectsMissingDay(t *testing.T) {
+	if err := CheckJournalFilenameFormat("2006-01"); err == nil {
+		t.Error("CheckJournalFilenameFormat should reject a layout without the day")
+	}
+}
+
+func TestGenerateMonthlySummary(t *testing.T) {
+	journalDir := t.TempDir()
+	j := NewJournalService(journalDir, time.Sunday)
+
+	// March 2025 starts on a Saturday: the 1st is in Week 1, the 10th in Week 2
+	entries := map[time.Time]string{
+		time.Date(2025, time.March, 1, 0, 0, 0, 0, time.Local):  "# Journal\n\n## Tasks\n\n- [x] plan sprint\n\n## Notes\n\nignored\n",
+		time.Date(2025, time.March, 10, 0, 0, 0, 0, time.Local): "# Journal\n\n## Tasks\n\n- [ ] ship release\n",
+		time.Date(2025, time.April, 1, 0, 0, 0, 0, time.Local):  "# Journal\n\n## Tasks\n\n- [ ] next month\n",
+	}
+	for date, content := range entries {
+		if err := j.WriteJournal(date, content); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	month := time.Date(2025, time.March, 20, 0, 0, 0, 0, time.Local)
+	summary, err := j.GenerateMonthlySummary(month)
+	if err != nil {
+		t.Fatalf("GenerateMonthlySummary returned error: %v", err)
+	}
+
+	for _, want := range []string{
+		"# Monthly Summary: March 2025",
+		"## All Tasks (Combined)\n\n- [x] plan sprint\n- [ ] ship release\n",
+		"### Week 1\n\n#### Saturday, March 1",
+		"### Week 2\n\n#### Monday, March 10",
+	} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("summary missing %q:\n%s", want, summary)
+		}
+	}
+	if strings.Contains(summary, "next month") || strings.Contains(summary, "ignored") {
+		t.Errorf("summary should only include March's Tasks sections:\n%s", summary)
+	}
+
+	wantPath := filepath.Join(journalDir, "summaries", "2025", "month-2025-03.md")
+	if got := j.GetMonthlySummaryPath(month); got != wantPath {
+		t.Errorf("GetMonthlySummaryPath = %q, want %q", got, wantPath)
+	}
+	saved, err := os.ReadFile(wantPath)
+	if err != nil || string(saved) != summary {
+		t.Errorf("summary was not saved to %s: %v", wantPath, err)
+	}
+}
+
+func TestWeekStartMidWeekMonth(t *testing.T) {
+	// May 1, 2024 is a Wednesday
+	day := func(d int) time.Time { return time.Date(2024, time.May, d, 9, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		weekStart  time.Weekday
+		date       time.Time
+		week       int
+		start, end time.Time
+	}{
+		{time.Sunday, day(4), 1, time.Date(2024, time.April, 28, 0, 0, 0, 0, time.UTC), day(4)},
+		{time.Sunday, day(12), 2, day(12), day(18)},
+		{time.Monday, day(5), 1, time.Date(2024, time.April, 29, 0, 0, 0, 0, time.UTC), day(5)},
+		{time.Monday, day(12), 1, day(6), day(12)},
+		{time.Monday, day(13), 2, day(13), day(19)},
+	}
+
+	for _, tt := range tests {
+		j := NewJournalService(t.TempDir(), tt.weekStart)
+
+		if got := getWeekOfMonth(tt.date, tt.weekStart); got != tt.week {
+			t.Errorf("%v weeks: week of %s = %d, want %d", tt.weekStart, tt.date.Format("Jan 2"), got, tt.week)
+		}
+
+		start, end := j.GetWeekBoundaries(tt.date)
+		if start.Format("2006-01-02") != tt.start.Format("2006-01-02") || end.Format("2006-01-02") != tt.end.Format("2006-01-02") {
+			t.Errorf("%v weeks: boundaries of %s = %s..%s, want %s..%s", tt.weekStart, tt.date.Format("Jan 2"),
+				start.Format("Jan 2"), end.Format("Jan 2"), tt.start.Format("Jan 2"), tt.end.Format("Jan 2"))
+		}
+
+		wantDir := filepath.Join("2024", "05", fmt.Sprintf("Week%d", tt.week))
+		if path := j.GetJournalPathForDate(tt.date); !strings.Contains(path, wantDir) {
+			t.Errorf("%v weeks: path for %s = %s, want it under %s", tt.weekStart, tt.date.Format("Jan 2"), path, wantDir)
+		}
+	}
+}
+
+func TestWeekStartChangeKeepsExistingEntry(t *testing.T) {
+	dir := t.TempDir()
+	// May 12, 2024 is a Sunday: Week2 when weeks start on Sunday, Week1 when they start on Monday
+	date := time.Date(2024, time.May, 12, 9, 0, 0, 0, time.UTC)
+
+	sunday := NewJournalService(dir, time.Sunday)
+	original, created, err := sunday.CreateOrOpenJournal(date)
+	if err != nil || !created {
+		t.Fatalf("CreateOrOpenJournal() = %q, %v, %v", original, created, err)
+	}
+	if err := os.WriteFile(original, []byte("# Notes from Sunday\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	monday := NewJournalService(dir, time.Monday)
+	path, created, err := monday.CreateOrOpenJournal(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created || path != original {
+		t.Errorf("after changing the week start, CreateOrOpenJournal() = %q, created %v; want the existing %q", path, created, original)
+	}
+	duplicate := filepath.Join(dir, "2024", "05", "Week1", filepath.Base(original))
+	if _, err := os.Stat(duplicate); !os.IsNotExist(err) {
+		t.Errorf("a duplicate entry was created at %s", duplicate)
+	}
+
+	// Dates without an entry still go in the week for the new start day
+	newPath := monday.GetJournalPathForDate(date.AddDate(0, 0, 1))
+	if want := filepath.Join("2024", "05", "Week2"); !strings.Contains(newPath, want) {
+		t.Errorf("path for a new entry = %s, want it under %s", newPath, want)
+	}
+}
+
+func TestWeeklySummaryRespectsWeekStart(t *testing.T) {
+	journalDir := t.TempDir()
+	wednesday := time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC)
+
+	for weekStart, want := range map[time.Weekday]string{
+		time.Sunday: "April 28, 2024 - May 4, 2024",
+		time.Monday: "April 29, 2024 - May 5, 2024",
+	} {
+		j := NewJournalService(journalDir, weekStart)
+
+		summary, err := j.GenerateWeeklySummary(wednesday)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(summary, want) {
+			t.Errorf("%v weeks: summary header should cover %s, got:\n%s", weekStart, want, summary)
+		}
+	}
+}
+
+func TestParseWeekStart(t *testing.T) {
+	if day, err := ParseWeekStart("Monday"); err != nil || day != time.Monday {
+		t.Errorf("ParseWeekStart(Monday) = %v, %v", day, err)
+	}
+	if day, err := ParseWeekStart(""); err != nil || day != time.Sunday {
+		t.Errorf("ParseWeekStart(\"\") = %v, %v", day, err)
+	}
+	if _, err := ParseWeekStart("friday"); err == nil {
+		t.Error("expected an error for an unsupported week start")
+	}
+}
+
+func TestAddToTasksSection(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		items   []string
+		want    string
+	}{
+		{
+			name:    "replaces empty placeholder",
+			content: "# Journal Entry - Monday\n\n## Tasks\n\n- \n",
+			items:   []string{"call bob"},
+			want:    "# Journal Entry - Monday\n\n## Tasks\n\n- call bob\n",
+		},
+		{
+			name:    "before next heading",
+			content: "# Day\n\n## Tasks\n\n- [ ] one\n\n## Notes\n\ntext\n",
+			items:   []string{"two", "- [ ] three", "  "},
+			want:    "# Day\n\n## Tasks\n\n- [ ] one\n- two\n- [ ] three\n\n## Notes\n\ntext\n",
+		},
+		{
+			name:    "heading right after tasks",
+			content: "## Tasks\n- one\n## Notes\n",
+			items:   []string{"two"},
+			want:    "## Tasks\n- one\n- two\n\n## Notes\n",
+		},
+		{
+			name:    "empty section",
+			content: "## Tasks\n\n## Notes\n",
+			items:   []string{"one"},
+			want:    "## Tasks\n\n- one\n\n## Notes\n",
+		},
+		{
+			name:    "no tasks section",
+			content: "# Day\n\nSome text",
+			items:   []string{"one"},
+			want:    "# Day\n\nSome text\n\n## Tasks\n\n- one\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AddToTasksSection(tt.content, tt.items); got != tt.want {
+				t.Errorf("AddToTasksSection() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddTasksCreatesEntry(t *testing.T) {
+	j := NewJournalService(t.TempDir(), time.Sunday)
+	date := time.Date(2025, time.March, 3, 0, 0, 0, 0, time.Local)
+
+	path, err := j.AddTasks(date, []string{"first"})
+	if err != nil {
+		t.Fatalf("AddTasks returned error: %v", err)
+	}
+	if _, err := j.AddTasks(date, []string{"second"}); err != nil {
+		t.Fatalf("AddTasks returned error: %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "# Journal Entry - Monday, March 3, 2025\n\n## Tasks\n\n- first\n- second\n"; string(content) != want {
+		t.Errorf("journal = %q, want %q", content, want)
+	}
+}
+
+func TestDailyLinksPointToNeighborEntries(t *testing.T) {
+	journalDir := t.TempDir()
+	j := NewJournalService(journalDir, time.Sunday)
+	j.dailyLinks = true
+
+	// Saturday May 31 2025: the day before is in the same week, the day after starts a new month
+	date := time.Date(2025, time.May, 31, 0, 0, 0, 0, time.Local)
+	want := "[← Friday, May 30](2025-05-30.md) | [Sunday, June 1 →](../../06/Week1/2025-06-01.md)"
+	if got := j.DailyLinks(date); got != want {
+		t.Errorf("DailyLinks = %q, want %q", got, want)
+	}
+
+	// Each link resolves to the neighbor's real path
+	entryDir := filepath.Dir(j.GetJournalPathForDate(date))
+	for _, day := range []time.Time{date.AddDate(0, 0, -1), date.AddDate(0, 0, 1)} {
+		found := false
+		for _, match := range dailyLinkTargets(j.DailyLinks(date)) {
+			if filepath.Join(entryDir, filepath.FromSlash(match)) == j.GetJournalPathForDate(day) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("no link resolves to %s", j.GetJournalPathForDate(day))
+		}
+	}
+
+	path, created, err := j.CreateOrOpenJournal(date)
+	if err != nil || !created {
+		t.Fatalf("CreateOrOpenJournal = %v, %v", created, err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wantContent := "# Journal Entry - Saturday, May 31, 2025\n\n" + want + "\n\n## Tasks\n\n- \n"; string(content) != wantContent {
+		t.Errorf("new entry = %q, want %q", content, wantContent)
+	}
+
+	// An untouched entry with links still counts as empty
+	if !NewCleanupService(t.TempDir(), journalDir).isDefaultJournalTemplate(string(content)) {
+		t.Error("an unedited entry with daily links should match the default template")
+	}
+}
+
+// dailyLinkTargets returns the link targets in a DailyLinks line
+func dailyLinkTargets(line string) []string {
+	var targets []string
+	for _, part := range strings.Split(line, "](")[1:] {
+		targets = append(targets, strings.Trim(part[:strings.Index(part, ")")], "<>"))
+	}
+	return targets
+}
+
+func TestJournalStats(t *testing.T) {
+	j := NewJournalService(filepath.Join(t.TempDir(), "journal"), time.Sunday)
+
+	// A missing journal directory gives zeroes
+	stats, err := j.GetStats()
+	if err != nil {
+		t.Fatalf("GetStats returned error: %v", err)
+	}
+	if stats != (JournalStats{}) {
+		t.Errorf("empty journal stats = %+v, want zeroes", stats)
+	}
+
+	day := func(d int) time.Time { return time.Date(2025, time.March, d, 0, 0, 0, 0, time.Local) }
+	// A 4-day run, a gap, then 2 days ending yesterday
+	for _, d := range []int{1, 2, 3, 4, 8, 9} {
+		if _, _, err := j.CreateOrOpenJournal(day(d)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Saved summaries aren't entries
+	if err := j.SaveWeeklySummary(day(2), "# Summary\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err = j.statsAsOf(day(10))
+	if err != nil {
+		t.Fatalf("statsAsOf returned error: %v", err)
+	}
+	if want := (JournalStats{CurrentStreak: 2, LongestStreak: 4, TotalEntries: 6}); stats != want {
+		t.Errorf("stats = %+v, want %+v", stats, want)
+	}
+
+	// Writing today extends the streak; missing yesterday too breaks it
+	if stats, _ := j.statsAsOf(day(9)); stats.CurrentStreak != 2 {
+		t.Errorf("streak ending today = %d, want 2", stats.CurrentStreak)
+	}
+	if stats, _ := j.statsAsOf(day(11)); stats.CurrentStreak != 0 {
+		t.Errorf("streak after a missed day = %d, want 0", stats.CurrentStreak)
+	}
+}
+
+func TestJournalTags(t *testing.T) {
+	j := NewJournalService(t.TempDir(), time.Sunday)
+	write := func(d int, content string) string {
+		t.Helper()
+		date := time.Date(2025, time.June, d, 0, 0, 0, 0, time.Local)
+		if err := j.WriteJournal(date, content); err != nil {
+			t.Fatal(err)
+		}
+		return j.GetJournalPathForDate(date)
+	}
+	lisbon := write(2, "---\ntags: travel, Work\n---\n\n## 09:00\nFlight to Lisbon\n")
+	porto := write(5, "## 10:00\nTrain to Porto #travel\n")
+	write(6, "## 14:30\nBack at the desk #work\n")
+	// Summaries aren't entries, even when tagged
+	if err := j.SaveWeeklySummary(time.Date(2025, time.June, 1, 0, 0, 0, 0, time.Local), "#summary\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	tags, err := j.GetAllTags()
+	if err != nil {
+		t.Fatalf("GetAllTags returned error: %v", err)
+	}
+	if want := []string{"travel", "work"}; strings.Join(tags, ",") != strings.Join(want, ",") {
+		t.Errorf("GetAllTags = %v, want %v", tags, want)
+	}
+
+	entries, err := j.FilterJournalsByTag("#Travel")
+	if err != nil {
+		t.Fatalf("FilterJournalsByTag returned error: %v", err)
+	}
+	var paths []string
+	for _, entry := range entries {
+		paths = append(paths, entry.FilePath)
+	}
+	if want := []string{porto, lisbon}; strings.Join(paths, ",") != strings.Join(want, ",") {
+		t.Errorf("travel entries = %v, want newest first %v", paths, want)
+	}
+}
+
+func TestCreateOrOpenJournalUsesTemplate(t *testing.T) {
+	journalDir := t.TempDir()
+	templatePath := filepath.Join(t.TempDir(), "journal_template.md")
+	j := NewJournalServiceWithOptions(journalDir, JournalOptions{Template: templatePath})
+	date := time.Date(2025, time.March, 3, 0, 0, 0, 0, time.Local)
+
+	// Without a template file, entries get the built-in layout
+	path, _, err := j.CreateOrOpenJournal(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := os.ReadFile(path)
+	if want := "# Journal Entry - Monday, March 3, 2025\n\n## Tasks\n\n- \n"; string(got) != want {
+		t.Errorf("entry without template = %q, want %q", got, want)
+	}
+
+	// The scaffolded template makes the same entries
+	if err := j.WriteDefaultTemplate(templatePath); err != nil {
+		t.Fatal(err)
+	}
+	if rendered := j.RenderJournalTemplate(j.DefaultTemplate(), date); rendered != string(got) {
+		t.Errorf("default template renders %q, want %q", rendered, got)
+	}
+	if err := j.WriteDefaultTemplate(templatePath); err == nil {
+		t.Error("WriteDefaultTemplate should not overwrite an existing template")
+	}
+
+	// Once edited, the template's placeholders are filled in
+	template := "# {{weekday}} {{date}} ({{longdate}})\n\n{{links}}\n"
+	if err := os.WriteFile(templatePath, []byte(template), 0644); err != nil {
+		t.Fatal(err)
+	}
+	next := date.AddDate(0, 0, 1)
+	path, created, err := j.CreateOrOpenJournal(next)
+	if err != nil || !created {
+		t.Fatalf("CreateOrOpenJournal = %v, %v", created, err)
+	}
+	got, _ = os.ReadFile(path)
+	want := "# Tuesday 2025-03-04 (March 4, 2025)\n\n" + j.DailyLinks(next) + "\n"
+	if string(got) != want {
+		t.Errorf("entry from template = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// markdownLinkRegex matches markdown links and images, [text](target) or ![alt](target),
+// with the same pattern as imageRefRegex
+var markdownLinkRegex = regexp.MustCompile(`!?` + markdownLinkPattern)
+
+// bareURLRegex matches URLs written directly in the text or as <autolinks>
+var bareURLRegex = regexp.MustCompile(`https?://[^\s<>()\[\]"'` + "`" + `]+`)
+
+// ExternalLink is an http(s) link found in a note
+type ExternalLink struct {
+	FilePath string
+	Line     int // 1-based
+	URL      string
+	Problem  string // Why the URL is malformed, "" if it looks valid
+}
+
+// ExtractLinks returns the http(s) links in markdown content: link and image targets
+// plus bare URLs, in order. Links inside fenced code blocks and inline code are
+// ignored, and each link's Problem is set if it is malformed.
+func ExtractLinks(content string) []ExternalLink {
+	var links []ExternalLink
+	inFence := false
+
+	for i, line := range strings.Split(NormalizeLineEndings(content), "\n") {
+		if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		line = blankCodeSpans(line)
+
+		add := func(target string) {
+			links = append(links, ExternalLink{Line: i + 1, URL: target, Problem: LinkProblem(target)})
+		}
+
+		// Markdown targets first; anything starting with "http" counts so typos like http:/ are reported
+		var spans [][]int
+		for _, match := range markdownLinkRegex.FindAllStringSubmatchIndex(line, -1) {
+			spans = append(spans, match[:2])
+			target := strings.TrimSpace(line[match[4]:match[5]])
+			// Drop an optional link title: [text](url "title")
+			if fields := strings.Fields(target); len(fields) > 1 && strings.HasPrefix(fields[1], `"`) {
+				target = fields[0]
+			}
+			if strings.HasPrefix(strings.ToLower(target), "http") {
+				add(target)
+			}
+		}
+
+		for _, match := range bareURLRegex.FindAllStringIndex(line, -1) {
+			if withinSpans(match[0], spans) {
+				continue
+			}
+			// Sentence punctuation after a URL isn't part of it
+			add(strings.TrimRight(line[match[0]:match[1]], ".,;:!?"))
+		}
+	}
+
+	return links
+}
+
+// withinSpans reports whether pos falls inside any of the [start, end) spans
+func withinSpans(pos int, spans [][]int) bool {
+	for _, span := range spans {
+		if pos >= span[0] && pos < span[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// LinkProblem describes what's wrong with an http(s) URL, or returns "" if it looks valid
+func LinkProblem(link string) string {
+	if strings.ContainsAny(link, " \t") {
+		return "contains spaces"
+	}
+
+	u, err := url.Parse(link)
+	if err != nil {
+		return "can't be parsed"
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "scheme should be http:// or https://"
+	}
+	if u.Host == "" || u.Hostname() == "" {
+		return "missing host"
+	}
+
+	host := u.Hostname()
+	if strings.HasPrefix(host, ".") || strings.Contains(host, "..") {
+		return "invalid host"
+	}
+	if !strings.Contains(host, ".") && host != "localhost" && net.ParseIP(host) == nil {
+		return "host has no domain"
+	}
+
+	return ""
+}
+
+// FindExternalLinks returns the http(s) links in every note, skipping templates and
+// archived notes
+func (s *NotesService) FindExternalLinks() ([]ExternalLink, error) {
+	var links []ExternalLink
+
+	err := filepath.Walk(s.notesDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil // Skip files we can't access
+		}
+		if info.IsDir() && (path == s.templatesDir || path == s.GetArchiveDir()) {
+			return filepath.SkipDir
+		}
+		if info.IsDir() || !strings.HasSuffix(info.Name(), ".md") {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil // Skip files we can't read
+		}
+
+		for _, link := range ExtractLinks(string(content)) {
+			link.FilePath = path
+			links = append(links, link)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan notes for links: %w", err)
+	}
+
+	return links, nil
+}
+
+// CheckURL requests link and returns the response status code. It sends a HEAD
+// request, falling back to GET for servers that don't allow HEAD.
+func CheckURL(client *http.Client, link string) (int, error) {
+	status, err := requestStatus(client, http.MethodHead, link)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented) {
+		status, err = requestStatus(client, http.MethodGet, link)
+	}
+	return status, err
+}
+
+func requestStatus(client *http.Client, method, link string) (int, error) {
+	req, err := http.NewRequest(method, link, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", "notetkr-link-check")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
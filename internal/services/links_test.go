@@ -0,0 +1,52 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractLinks(t *testing.T) {
+	content := "# Links\r\n" +
+		"See [docs](https://example.com/docs \"Docs\") and ![logo](<https://example.com/logo.png>).\r\n" +
+		"Bare https://go.dev/doc, and <https://pkg.go.dev>.\r\n" +
+		"[local](notes/other.md) ![img](.attachments/a.png) `[code](https://code.example.com)`\r\n" +
+		"```sh\r\n" +
+		"curl https://ignored.example.com\r\n" +
+		"```\r\n" +
+		"[typo](http:/example.com) [spaced](https://exa mple.com) http://intranet\r\n"
+
+	var got []ExternalLink
+	for _, link := range ExtractLinks(content) {
+		got = append(got, ExternalLink{Line: link.Line, URL: link.URL, Problem: link.Problem})
+	}
+
+	want := []ExternalLink{
+		{Line: 2, URL: "https://example.com/docs"},
+		{Line: 2, URL: "https://example.com/logo.png"},
+		{Line: 3, URL: "https://go.dev/doc"},
+		{Line: 3, URL: "https://pkg.go.dev"},
+		{Line: 8, URL: "http:/example.com", Problem: "missing host"},
+		{Line: 8, URL: "https://exa mple.com", Problem: "contains spaces"},
+		{Line: 8, URL: "http://intranet", Problem: "host has no domain"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExtractLinks =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestLinkProblem(t *testing.T) {
+	tests := map[string]string{
+		"https://example.com/a?b=c#d": "",
+		"http://localhost:8080/x":     "",
+		"http://127.0.0.1/":           "",
+		"https://":                    "missing host",
+		"https://example..com":        "invalid host",
+		"httpx://example.com":         "scheme should be http:// or https://",
+		"https://example.com/%zz":     "can't be parsed",
+	}
+	for link, want := range tests {
+		if got := LinkProblem(link); got != want {
+			t.Errorf("LinkProblem(%q) = %q, want %q", link, got, want)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	gohtml "html"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// mathJaxScript loads MathJax from its CDN to typeset the \( \) and \[ \] math in a preview
+const mathJaxScript = `<script id="MathJax-script" async src="https://cdn.jsdelivr.net/npm/mathjax@3/es5/tex-chtml.js"></script>`
+
+var (
+	kindMathInline = ast.NewNodeKind("MathInline")
+	kindMathBlock  = ast.NewNodeKind("MathBlock")
+)
+
+// mathInline is $...$ (or $$...$$ within a line) math
+type mathInline struct {
+	ast.BaseInline
+	Segment text.Segment
+	Display bool
+}
+
+func (n *mathInline) Kind() ast.NodeKind { return kindMathInline }
+
+func (n *mathInline) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, nil, nil)
+}
+
+// mathBlock is display math between "$$" lines
+type mathBlock struct {
+	ast.BaseBlock
+}
+
+func (n *mathBlock) Kind() ast.NodeKind { return kindMathBlock }
+
+func (n *mathBlock) IsRaw() bool { return true }
+
+func (n *mathBlock) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, nil, nil)
+}
+
+// mathExtension adds $...$ inline and $$...$$ display math to goldmark. Code spans
+// and code blocks are parsed separately, so dollar signs in code are left alone.
+type mathExtension struct{}
+
+func (mathExtension) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(
+		parser.WithInlineParsers(util.Prioritized(&mathInlineParser{}, 150)),
+		parser.WithBlockParsers(util.Prioritized(&mathBlockParser{}, 750)),
+	)
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(util.Prioritized(&mathRenderer{}, 500)))
+}
+
+type mathInlineParser struct{}
+
+func (p *mathInlineParser) Trigger() []byte {
+	return []byte{'$'}
+}
+
+func (p *mathInlineParser) Parse(parent ast.Node, block text.Reader, pc parser.Context) ast.Node {
+	line, segment := block.PeekLine()
+
+	delim := 1
+	if len(line) > 1 && line[1] == '$' {
+		delim = 2
+	}
+	rest := line[delim:]
+	// Like pandoc, inline math can't start with a space, so "$5 and $10" stays text
+	if len(rest) == 0 || (delim == 1 && util.IsSpace(rest[0])) {
+		return nil
+	}
+
+	for i := 1; i < len(rest); i++ {
+		switch {
+		case rest[i] == '\\':
+			i++ // Skip escaped characters such as \$
+		case rest[i] != '$':
+		case delim == 2:
+			if i+1 < len(rest) && rest[i+1] == '$' {
+				return p.node(block, segment, delim, i, true)
+			}
+		case !util.IsSpace(rest[i-1]) && (i+1 >= len(rest) || !util.IsNumeric(rest[i+1])):
+			return p.node(block, segment, delim, i, false)
+		}
+	}
+
+	return nil
+}
+
+// node consumes the math and its delimiters and returns it as an inline node
+func (p *mathInlineParser) node(block text.Reader, segment text.Segment, delim, length int, display bool) ast.Node {
+	start := segment.Start + delim
+	block.Advance(delim + length + delim)
+	return &mathInline{Segment: text.NewSegment(start, start+length), Display: display}
+}
+
+type mathBlockParser struct{}
+
+func (b *mathBlockParser) Trigger() []byte {
+	return []byte{'$'}
+}
+
+func (b *mathBlockParser) Open(parent ast.Node, reader text.Reader, pc parser.Context) (ast.Node, parser.State) {
+	line, _ := reader.PeekLine()
+	if string(util.TrimRightSpace(util.TrimLeftSpace(line))) != "$$" {
+		return nil, parser.NoChildren
+	}
+	return &mathBlock{}, parser.NoChildren
+}
+
+func (b *mathBlockParser) Continue(node ast.Node, reader text.Reader, pc parser.Context) parser.State {
+	line, segment := reader.PeekLine()
+	if string(util.TrimRightSpace(util.TrimLeftSpace(line))) == "$$" {
+		reader.Advance(segment.Len())
+		return parser.Close
+	}
+
+	node.Lines().Append(segment)
+	reader.Advance(segment.Len() - 1)
+	return parser.Continue | parser.NoChildren
+}
+
+func (b *mathBlockParser) Close(node ast.Node, reader text.Reader, pc parser.Context) {}
+
+func (b *mathBlockParser) CanInterruptParagraph() bool {
+	return true
+}
+
+func (b *mathBlockParser) CanAcceptIndentedLine() bool {
+	return false
+}
+
+// mathRenderer writes math in MathJax's \( \) and \[ \] delimiters
+type mathRenderer struct{}
+
+func (r *mathRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(kindMathInline, r.renderInline)
+	reg.Register(kindMathBlock, r.renderBlock)
+}
+
+func (r *mathRenderer) renderInline(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	node := n.(*mathInline)
+	value := gohtml.EscapeString(string(node.Segment.Value(source)))
+	if node.Display {
+		_, _ = w.WriteString(`<span class="math display">\[` + value + `\]</span>`)
+	} else {
+		_, _ = w.WriteString(`<span class="math inline">\(` + value + `\)</span>`)
+	}
+	return ast.WalkSkipChildren, nil
+}
+
+func (r *mathRenderer) renderBlock(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	_, _ = w.WriteString(`<div class="math display">\[`)
+	lines := n.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		segment := lines.At(i)
+		_, _ = w.WriteString(gohtml.EscapeString(string(segment.Value(source))))
+	}
+	_, _ = w.WriteString("\\]</div>\n")
+	return ast.WalkSkipChildren, nil
+}
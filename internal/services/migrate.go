@@ -0,0 +1,168 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+)
+
+// MigrationSummary describes the files moved by MigrateDataDir
+type MigrationSummary struct {
+	Files int
+	Bytes int64
+}
+
+// renameFile is os.Rename, swappable so tests can simulate a move across devices
+var renameFile = os.Rename
+
+// MigrateDataDir moves every file under from (notes, journals and their .attachments)
+// to the same relative path under to, then removes the emptied directories. Paths in
+// skip (e.g. the active config file) are left where they are. progress, if set, is
+// called with each file's relative path before it is moved. Nothing is moved if any
+// destination file already exists or any source file or directory can't be read.
+func MigrateDataDir(from, to string, skip []string, progress func(relPath string)) (MigrationSummary, error) {
+	var summary MigrationSummary
+
+	from, err := filepath.Abs(from)
+	if err != nil {
+		return summary, fmt.Errorf("failed to resolve %s: %w", from, err)
+	}
+	to, err = filepath.Abs(to)
+	if err != nil {
+		return summary, fmt.Errorf("failed to resolve %s: %w", to, err)
+	}
+
+	info, err := os.Stat(from)
+	if err != nil {
+		return summary, fmt.Errorf("failed to access %s: %w", from, err)
+	}
+	if !info.IsDir() {
+		return summary, fmt.Errorf("%s is not a directory", from)
+	}
+	if from == to {
+		return summary, fmt.Errorf("source and destination are the same directory")
+	}
+	if isWithin(to, from) {
+		return summary, fmt.Errorf("destination %s is inside the source directory", to)
+	}
+
+	skipped := make(map[string]bool)
+	for _, path := range skip {
+		if abs, err := filepath.Abs(path); err == nil {
+			skipped[abs] = true
+		}
+	}
+
+	// Collect the files first so a conflict, or a file that can't be read (and would
+	// be left behind), is found before anything moves
+	var files []string
+	err = filepath.Walk(from, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if info.IsDir() || skipped[path] {
+			return nil
+		}
+		relPath, err := filepath.Rel(from, path)
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(filepath.Join(to, relPath)); err == nil {
+			return fmt.Errorf("%s already exists in %s", relPath, to)
+		}
+		files = append(files, relPath)
+		summary.Bytes += info.Size()
+		return nil
+	})
+	if err != nil {
+		return MigrationSummary{}, err
+	}
+
+	for _, relPath := range files {
+		if progress != nil {
+			progress(relPath)
+		}
+		if err := moveFile(filepath.Join(from, relPath), filepath.Join(to, relPath)); err != nil {
+			return summary, fmt.Errorf("failed to move %s: %w", relPath, err)
+		}
+		summary.Files++
+	}
+
+	removeEmptyDirs(from)
+
+	return summary, nil
+}
+
+// isWithin reports whether path is dir or somewhere below it
+func isWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// moveFile renames src to dst, falling back to copy and remove when they are on different devices
+func moveFile(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+
+	err := renameFile(src, dst)
+	if err == nil || !errors.Is(err, syscall.EXDEV) {
+		return err
+	}
+
+	if err := copyFile(src, dst); err != nil {
+		os.Remove(dst)
+		return err
+	}
+	return os.Remove(src)
+}
+
+// copyFile copies src to dst, keeping its permissions and modification time
+func copyFile(src, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+
+	return os.Chtimes(dst, info.ModTime(), info.ModTime())
+}
+
+// removeEmptyDirs deletes every empty directory under root, deepest first, leaving root itself
+func removeEmptyDirs(root string) {
+	var dirs []string
+	filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err == nil && info.IsDir() && path != root {
+			dirs = append(dirs, path)
+		}
+		return nil
+	})
+
+	sort.Sort(sort.Reverse(sort.StringSlice(dirs)))
+	for _, dir := range dirs {
+		// Fails harmlessly for directories that still hold skipped files
+		os.Remove(dir)
+	}
+}
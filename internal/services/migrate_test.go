@@ -0,0 +1,127 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+func TestMigrateDataDirMovesFiles(t *testing.T) {
+	for _, crossDevice := range []bool{false, true} {
+		root := t.TempDir()
+		from := filepath.Join(root, "old")
+		to := filepath.Join(root, "new")
+
+		files := map[string]string{
+			"notes/work/plan.md":               "# Plan\n",
+			"notes/.attachments/imgs/shot.png": "png",
+			"journal/2024/01/2024-01-02.md":    "# Journal\n",
+			"notetkr.yml":                      "notes:\n  dir: x\n",
+		}
+		for rel, content := range files {
+			path := filepath.Join(from, rel)
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if crossDevice {
+			renameFile = func(string, string) error {
+				return &os.LinkError{Op: "rename", Err: syscall.EXDEV}
+			}
+		}
+
+		var moved []string
+		summary, err := MigrateDataDir(from, to, []string{filepath.Join(from, "notetkr.yml")}, func(rel string) {
+			moved = append(moved, rel)
+		})
+		renameFile = os.Rename
+		if err != nil {
+			t.Fatalf("crossDevice=%v: MigrateDataDir returned error: %v", crossDevice, err)
+		}
+
+		if summary.Files != 3 || len(moved) != 3 {
+			t.Errorf("crossDevice=%v: moved %d file(s) (%v), want 3", crossDevice, summary.Files, moved)
+		}
+		for rel, content := range files {
+			if rel == "notetkr.yml" {
+				continue
+			}
+			data, err := os.ReadFile(filepath.Join(to, rel))
+			if err != nil || string(data) != content {
+				t.Errorf("crossDevice=%v: %s at new path = %q, %v", crossDevice, rel, data, err)
+			}
+			if _, err := os.Stat(filepath.Join(from, rel)); !os.IsNotExist(err) {
+				t.Errorf("crossDevice=%v: %s still exists at the old path", crossDevice, rel)
+			}
+		}
+
+		if _, err := os.Stat(filepath.Join(from, "notetkr.yml")); err != nil {
+			t.Errorf("crossDevice=%v: skipped config file was moved: %v", crossDevice, err)
+		}
+		if _, err := os.Stat(filepath.Join(from, "notes")); !os.IsNotExist(err) {
+			t.Errorf("crossDevice=%v: emptied notes directory was not removed", crossDevice)
+		}
+	}
+}
+
+func TestMigrateDataDirRefusesConflicts(t *testing.T) {
+	root := t.TempDir()
+	from := filepath.Join(root, "old")
+	to := filepath.Join(root, "new")
+
+	for _, dir := range []string{from, to} {
+		if err := os.MkdirAll(filepath.Join(dir, "notes"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "notes", "a.md"), []byte(dir), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(from, "notes", "b.md"), []byte("b"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := MigrateDataDir(from, to, nil, nil); err == nil {
+		t.Fatal("expected an error when a destination file exists")
+	}
+	if _, err := os.Stat(filepath.Join(from, "notes", "b.md")); err != nil {
+		t.Error("no files should move when there is a conflict")
+	}
+
+	if _, err := MigrateDataDir(from, filepath.Join(from, "nested"), nil, nil); err == nil {
+		t.Error("expected an error when the destination is inside the source")
+	}
+}
+
+func TestMigrateDataDirRefusesUnreadableFiles(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("permissions don't stop root from reading files")
+	}
+	from := t.TempDir()
+	to := filepath.Join(t.TempDir(), "new")
+
+	locked := filepath.Join(from, "notes", "locked")
+	if err := os.MkdirAll(locked, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(from, "notes", "a.md"), []byte("a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(locked, 0); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chmod(locked, 0755)
+
+	if _, err := MigrateDataDir(from, to, nil, nil); err == nil || !strings.Contains(err.Error(), "locked") {
+		t.Fatalf("MigrateDataDir error = %v, want one naming the unreadable directory", err)
+	}
+	if _, err := os.Stat(filepath.Join(from, "notes", "a.md")); err != nil {
+		t.Error("no files should move when one can't be read")
+	}
+}
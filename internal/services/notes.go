@@ -1,42 +1,98 @@
 package services
 
 import (
+	"embed"
+	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/redjax/notetkr/internal/config"
 )
 
+// defaultTemplatesFS holds the built-in note templates seeded into new vaults
+//
+//go:embed templates/*.md
+var defaultTemplatesFS embed.FS
+
 // Note represents a note with metadata
 type Note struct {
-	Name       string
-	FilePath   string
-	Tags       []string
-	Keywords   []string
-	Attendees  []Attendee
-	ModTime    time.Time
-	IsTemplate bool
+	Name       string     `json:"name"`
+	FilePath   string     `json:"file_path"`
+	Tags       []string   `json:"tags"`
+	Keywords   []string   `json:"keywords"`
+	Attendees  []Attendee `json:"attendees"`
+	Title      string     `json:"title"`      // Display name: frontmatter title, first H1, or the filename
+	Weight     int        `json:"weight"`     // Frontmatter weight/priority; lower sorts first
+	HasWeight  bool       `json:"has_weight"` // Whether the note sets a weight
+	Pinned     bool       `json:"pinned"`     // Frontmatter pinned: true; listed before other notes
+	ModTime    time.Time  `json:"mod_time"`
+	IsTemplate bool       `json:"is_template"`
+}
+
+// DisplayName returns the note's title, or its filename when it has none
+func (n Note) DisplayName() string {
+	if n.Title != "" {
+		return n.Title
+	}
+	return n.Name
 }
 
 // Attendee represents a meeting attendee with optional metadata
 type Attendee struct {
-	Name    string
-	Company string
-	Email   string
+	Name    string `json:"name"`
+	Company string `json:"company,omitempty"`
+	Email   string `json:"email,omitempty"`
+}
+
+// NotesOptions controls how a NotesService creates and moves notes
+type NotesOptions struct {
+	// NewNoteHeading starts new blank notes with an empty "# " title heading
+	NewNoteHeading bool
+
+	// SuffixOnClash saves moves and renames onto an existing note's name as
+	// "name-2.md", "name-3.md", ... instead of failing
+	SuffixOnClash bool
+}
+
+// DefaultNotesOptions returns the options NewNotesService uses
+func DefaultNotesOptions() NotesOptions {
+	return NotesOptions{
+		NewNoteHeading: true,
+		SuffixOnClash:  true,
+	}
+}
+
+// NotesOptionsFromConfig returns the notes options cfg sets
+func NotesOptionsFromConfig(cfg *config.Config) NotesOptions {
+	return NotesOptions{
+		NewNoteHeading: cfg.NewNoteHeading,
+		SuffixOnClash:  cfg.NotesSuffixOnClash,
+	}
 }
 
 type NotesService struct {
 	notesDir     string
 	templatesDir string
+	options      NotesOptions
 }
 
 func NewNotesService(notesDir string) *NotesService {
+	return NewNotesServiceWithOptions(notesDir, DefaultNotesOptions())
+}
+
+// NewNotesServiceWithOptions creates a notes service set up with opts
+func NewNotesServiceWithOptions(notesDir string, opts NotesOptions) *NotesService {
 	templatesDir := filepath.Join(notesDir, ".templates")
 	return &NotesService{
 		notesDir:     notesDir,
 		templatesDir: templatesDir,
+		options:      opts,
 	}
 }
 
@@ -45,7 +101,12 @@ func (s *NotesService) GetNotesDir() string {
 	return s.notesDir
 }
 
-// ListNotes returns all notes in the notes directory (excluding templates)
+// GetTemplatesDir returns the templates directory path
+func (s *NotesService) GetTemplatesDir() string {
+	return s.templatesDir
+}
+
+// ListNotes returns all notes in the notes directory (excluding templates and archived notes)
 func (s *NotesService) ListNotes() ([]Note, error) {
 	var notes []Note
 
@@ -54,29 +115,15 @@ func (s *NotesService) ListNotes() ([]Note, error) {
 			return err
 		}
 
-		// Skip template directory
-		if info.IsDir() && path == s.templatesDir {
+		// Skip template and archive directories
+		if info.IsDir() && (path == s.templatesDir || path == s.GetArchiveDir()) {
 			return filepath.SkipDir
 		}
 
 		// Only include .md files
 		if !info.IsDir() && strings.HasSuffix(info.Name(), ".md") {
 			relPath, _ := filepath.Rel(s.notesDir, path)
-
-			// Extract metadata from file
-			tags, _ := s.extractTags(path)
-			keywords, _ := s.extractKeywords(path)
-			attendees, _ := s.extractAttendees(path)
-
-			notes = append(notes, Note{
-				Name:       relPath,
-				FilePath:   path,
-				Tags:       tags,
-				Keywords:   keywords,
-				Attendees:  attendees,
-				ModTime:    info.ModTime(),
-				IsTemplate: false,
-			})
+			notes = append(notes, noteFromFile(path, relPath, info.ModTime()))
 		}
 		return nil
 	})
@@ -84,6 +131,26 @@ func (s *NotesService) ListNotes() ([]Note, error) {
 	return notes, err
 }
 
+// ListRecentNotes returns up to limit notes from the whole notes directory, most
+// recently modified first. A missing notes directory has no notes.
+func (s *NotesService) ListRecentNotes(limit int) ([]Note, error) {
+	notes, err := s.ListNotes()
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(notes, func(i, j int) bool {
+		return notes[i].ModTime.After(notes[j].ModTime)
+	})
+	if limit >= 0 && len(notes) > limit {
+		notes = notes[:limit]
+	}
+	return notes, nil
+}
+
 // ListTemplates returns all template notes
 func (s *NotesService) ListTemplates() ([]Note, error) {
 	var templates []Note
@@ -120,26 +187,78 @@ func (s *NotesService) ListTemplates() ([]Note, error) {
 	return templates, err
 }
 
-// extractTags reads a note file and extracts tags from the content
-// Tags are in the format: #tag or tags: tag1, tag2
-func (s *NotesService) extractTags(filePath string) ([]string, error) {
+// noteFromFile reads the note at path once and fills in its metadata. A note that
+// can't be read is listed with only its name.
+func noteFromFile(path, name string, modTime time.Time) Note {
+	note := Note{
+		Name:     name,
+		FilePath: path,
+		Title:    name,
+		ModTime:  modTime,
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return note
+	}
+
+	text := string(content)
+	note.Tags = parseTags(text)
+	note.Keywords = parseKeywords(text)
+	note.Attendees = parseAttendees(text)
+	note.Weight, note.HasWeight = parseWeight(text)
+	note.Pinned = parsePinned(text)
+	if title := parseTitle(text); title != "" {
+		note.Title = title
+	}
+	return note
+}
+
+var (
+	// frontMatterRegex splits content into a --- delimited frontmatter block and the body after it
+	frontMatterRegex = regexp.MustCompile(`(?s)^---\s*\n(.*?)\n---\s*\n?(.*)`)
+
+	// Frontmatter fields. The list fields only match with a value on the same line
+	// ([ \t] rather than \s, which would run on to the next line).
+	tagsFieldRegex      = regexp.MustCompile(`(?m)^tags:[ \t]+(\S[^\n]*)$`)
+	keywordsFieldRegex  = regexp.MustCompile(`(?m)^keywords:[ \t]+(\S[^\n]*)$`)
+	titleFieldRegex     = regexp.MustCompile(`(?m)^title:[ \t]+(\S[^\n]*)$`)
+	weightFieldRegex    = regexp.MustCompile(`(?m)^(?:weight|priority):[ \t]*(-?\d+)[ \t]*$`)
+	attendeesFieldRegex = regexp.MustCompile(`(?m)^attendees:\s*$`)
+
+	// h1Regex matches a level-one heading with text
+	h1Regex = regexp.MustCompile(`(?m)^#[ \t]+(\S[^\n]*)$`)
+)
+
+// extractTags reads a note or journal entry and extracts tags from the content
+// Tags are in the format: #tag, tags: tag1, tag2, or a frontmatter YAML list:
+//
+//	tags:
+//	  - tag1
+//	  - tag2
+func extractTags(filePath string) ([]string, error) {
 	content, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, err
 	}
 
+	return parseTags(string(content)), nil
+}
+
+// parseTags extracts the tags from note or journal entry content, lowercased
+func parseTags(text string) []string {
 	tags := make(map[string]bool)
-	text := string(content)
 
 	// Extract frontmatter and body separately
 	var frontmatterText string
 	var bodyText string
 
 	// Check for frontmatter with --- delimiters
-	fmBlockRe := regexp.MustCompile(`(?s)^---\s*\n(.*?)\n---\s*\n?(.*)`)
-	if fmBlock := fmBlockRe.FindStringSubmatch(text); len(fmBlock) > 2 {
+	hasFrontMatter := false
+	if fmBlock := frontMatterRegex.FindStringSubmatch(text); len(fmBlock) > 2 {
 		frontmatterText = fmBlock[1]
 		bodyText = fmBlock[2]
+		hasFrontMatter = true
 	} else {
 		// No frontmatter delimiters - treat everything as potential frontmatter for backwards compatibility
 		frontmatterText = text
@@ -147,30 +266,22 @@ func (s *NotesService) extractTags(filePath string) ([]string, error) {
 	}
 
 	// Extract hashtag-style tags (#tag) from body content only (not frontmatter)
-	hashtagRe := regexp.MustCompile(`#([a-zA-Z0-9_-]+)`)
-	matches := hashtagRe.FindAllStringSubmatch(bodyText, -1)
-	for _, match := range matches {
-		if len(match) > 1 {
-			tags[strings.ToLower(match[1])] = true
-		}
+	for _, tag := range bodyHashtags(bodyText) {
+		tags[normalizeTag(tag)] = true
 	}
 
 	// Extract tags from frontmatter
-	// Only match if there's actual content on the same line (use [ \t] for space/tab only, not \s which includes newline)
-	tagsRe := regexp.MustCompile(`(?m)^tags:[ \t]+(\S[^\n]*)$`)
-	if tagMatches := tagsRe.FindStringSubmatch(frontmatterText); len(tagMatches) > 1 {
-		tagContent := strings.TrimSpace(tagMatches[1])
-		if tagContent != "" {
-			tagList := strings.Split(tagContent, ",")
-			for _, tag := range tagList {
-				tag = strings.TrimSpace(tag)
-				tag = strings.ToLower(tag)
-				if tag != "" {
-					tags[tag] = true
-				}
-			}
+	if tagMatches := tagsFieldRegex.FindStringSubmatch(frontmatterText); len(tagMatches) > 1 {
+		for _, tag := range inlineTagItems(tagMatches[1]) {
+			tags[normalizeTag(tag)] = true
+		}
+	} else if hasFrontMatter {
+		// Or as a YAML list under a bare tags: key
+		for _, tag := range frontMatterTagList(frontmatterText) {
+			tags[normalizeTag(tag)] = true
 		}
 	}
+	delete(tags, "")
 
 	// Convert map to slice
 	result := make([]string, 0, len(tags))
@@ -178,10 +289,99 @@ func (s *NotesService) extractTags(filePath string) ([]string, error) {
 		result = append(result, tag)
 	}
 
-	return result, nil
+	return result
 }
 
-// extractKeywords reads a note file and extracts keywords from frontmatter
+// normalizeTag returns a tag the way parseTags reports it: trimmed, unquoted,
+// lowercase and without a leading #, so "#Work", "work" and "WORK" are one tag
+func normalizeTag(tag string) string {
+	tag = strings.Trim(strings.TrimSpace(tag), `"'`)
+	return strings.ToLower(strings.TrimSpace(strings.TrimPrefix(tag, "#")))
+}
+
+// inlineTagItems splits an inline tags: value, "a, b" or a YAML flow list "[a, b]",
+// into its items, trimmed but otherwise as written
+func inlineTagItems(value string) []string {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]") {
+		value = value[1 : len(value)-1]
+	}
+
+	items := strings.Split(value, ",")
+	for i, item := range items {
+		items[i] = strings.TrimSpace(item)
+	}
+	return items
+}
+
+// hashtagRegex matches a #tag at the start of a line or after whitespace, so URL
+// fragments like page#section aren't read as tags
+var hashtagRegex = regexp.MustCompile(`(?:^|\s)#([a-zA-Z0-9_-]+)`)
+
+// bodyHashtags returns the #tags in markdown text, skipping fenced code blocks and
+// inline code, where # is usually a color like #333 or a comment
+func bodyHashtags(body string) []string {
+	var tags []string
+	inFence := false
+
+	for _, line := range strings.Split(body, "\n") {
+		if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		for _, match := range hashtagRegex.FindAllStringSubmatch(blankCodeSpans(line), -1) {
+			tags = append(tags, match[1])
+		}
+	}
+
+	return tags
+}
+
+// blankCodeSpans returns a line of markdown with its inline code spans blanked out.
+// Odd-numbered pieces between backticks are inline code; their text becomes spaces,
+// keeping the backticks (so a # right after a code span isn't at a word start) and
+// every other byte where it was, so match positions in the result hold in line.
+func blankCodeSpans(line string) string {
+	pieces := strings.Split(line, "`")
+	for j := 1; j < len(pieces); j += 2 {
+		pieces[j] = strings.Repeat(" ", len(pieces[j]))
+	}
+	return strings.Join(pieces, "`")
+}
+
+// frontMatterTagList returns the items of a tags: key written as a YAML list, i.e.
+// a bare "tags:" line followed by "- item" lines, up to the next key
+func frontMatterTagList(frontMatter string) []string {
+	lines := strings.Split(frontMatter, "\n")
+	for i, line := range lines {
+		if strings.TrimRight(line, " \t\r") != "tags:" {
+			continue
+		}
+
+		var tags []string
+		for _, item := range lines[i+1:] {
+			trimmed := strings.TrimSpace(item)
+			if trimmed == "" {
+				continue
+			}
+			value, ok := strings.CutPrefix(trimmed, "-")
+			if !ok {
+				break // The next key
+			}
+			if value = strings.Trim(strings.TrimSpace(value), `"'`); value != "" {
+				tags = append(tags, value)
+			}
+		}
+		return tags
+	}
+	return nil
+}
+
+// parseKeywords extracts keywords from note content's frontmatter
 // Supports both YAML frontmatter with --- delimiters and inline format:
 //
 //	---
@@ -191,21 +391,14 @@ func (s *NotesService) extractTags(filePath string) ([]string, error) {
 // Or:
 //
 //	keywords: keyword1, keyword2, keyword3
-func (s *NotesService) extractKeywords(filePath string) ([]string, error) {
-	content, err := os.ReadFile(filePath)
-	if err != nil {
-		return nil, err
-	}
-
-	text := string(content)
+func parseKeywords(text string) []string {
 	keywords := make([]string, 0)
 
 	// Extract frontmatter block if it exists
 	var frontmatterText string
 
 	// Check for frontmatter with --- delimiters
-	fmBlockRe := regexp.MustCompile(`(?s)^---\s*\n(.*?)\n---`)
-	if fmBlock := fmBlockRe.FindStringSubmatch(text); len(fmBlock) > 1 {
+	if fmBlock := frontMatterRegex.FindStringSubmatch(text); len(fmBlock) > 1 {
 		frontmatterText = fmBlock[1]
 	} else {
 		// Fallback to inline frontmatter format
@@ -213,9 +406,7 @@ func (s *NotesService) extractKeywords(filePath string) ([]string, error) {
 	}
 
 	// Extract keywords from frontmatter
-	// Only match if there's actual content on the same line (use [ \t] for space/tab only, not \s which includes newline)
-	keywordsRe := regexp.MustCompile(`(?m)^keywords:[ \t]+(\S[^\n]*)$`)
-	if matches := keywordsRe.FindStringSubmatch(frontmatterText); len(matches) > 1 {
+	if matches := keywordsFieldRegex.FindStringSubmatch(frontmatterText); len(matches) > 1 {
 		keywordContent := strings.TrimSpace(matches[1])
 		if keywordContent != "" {
 			keywordList := strings.Split(keywordContent, ",")
@@ -228,32 +419,112 @@ func (s *NotesService) extractKeywords(filePath string) ([]string, error) {
 		}
 	}
 
-	return keywords, nil
+	return keywords
 }
 
-// extractAttendees reads a note file and extracts attendees from YAML frontmatter
-// Supports nested YAML structure:
-// attendees:
-//
-//	david correll:
-//	jack kenyon:
-//	  company: embrace pet insurance
-//	  email: jkenyon@example.com
-func (s *NotesService) extractAttendees(filePath string) ([]Attendee, error) {
-	content, err := os.ReadFile(filePath)
+// parseTitle extracts the title from note content, or "" if it has none
+func parseTitle(text string) string {
+	if fmBlock := frontMatterRegex.FindStringSubmatch(text); len(fmBlock) >= 2 {
+		if matches := titleFieldRegex.FindStringSubmatch(fmBlock[1]); len(matches) >= 2 {
+			title := strings.TrimSpace(matches[1])
+			title = strings.Trim(title, `"'`)
+			if title != "" {
+				return title
+			}
+		}
+	}
+
+	// Fall back to the first non-empty H1 in the body
+	if matches := h1Regex.FindStringSubmatch(StripFrontMatter(text)); len(matches) >= 2 {
+		return strings.TrimSpace(matches[1])
+	}
+	return ""
+}
+
+// parseWeight extracts an integer "weight:" or "priority:" from note content's
+// --- delimited frontmatter. Returns false if the note doesn't set one.
+func parseWeight(text string) (int, bool) {
+	fmBlock := frontMatterRegex.FindStringSubmatch(text)
+	if len(fmBlock) < 2 {
+		return 0, false
+	}
+
+	matches := weightFieldRegex.FindStringSubmatch(fmBlock[1])
+	if len(matches) < 2 {
+		return 0, false
+	}
+
+	weight, err := strconv.Atoi(matches[1])
 	if err != nil {
-		return nil, err
+		return 0, false
 	}
+	return weight, true
+}
 
-	text := string(content)
+// pinnedRegex matches a frontmatter line pinning a note
+var pinnedRegex = regexp.MustCompile(`(?mi)^pinned:[ \t]*(?:true|yes)[ \t]*$`)
+
+// parsePinned reports whether note content's frontmatter sets pinned: true
+func parsePinned(text string) bool {
+	frontMatter, _, ok, err := splitFrontMatter(NormalizeLineEndings(text))
+	return ok && err == nil && pinnedRegex.MatchString(frontMatter)
+}
+
+// NoteSortMode controls how notes are ordered in the browser
+type NoteSortMode int
+
+const (
+	NoteSortModified NoteSortMode = iota // Newest first
+	NoteSortWeight                       // Lowest weight first, then newest
+)
+
+// String returns a short label for the sort mode
+func (n NoteSortMode) String() string {
+	if n == NoteSortWeight {
+		return "weight"
+	}
+	return "recent"
+}
+
+// SortNotes orders notes using the given mode, pinned notes first. In weight mode,
+// notes with a weight come before notes without one, lower weights first; ties fall
+// back to modtime.
+func SortNotes(notes []Note, mode NoteSortMode) {
+	sort.SliceStable(notes, func(i, j int) bool {
+		a, b := notes[i], notes[j]
+		if a.Pinned != b.Pinned {
+			return a.Pinned
+		}
+		if mode == NoteSortWeight {
+			if a.HasWeight != b.HasWeight {
+				return a.HasWeight
+			}
+			if a.HasWeight && a.Weight != b.Weight {
+				return a.Weight < b.Weight
+			}
+		}
+		return a.ModTime.After(b.ModTime)
+	})
+}
+
+// parseAttendees extracts attendees from a note's frontmatter text. It reads the
+// nested YAML form:
+//
+//	attendees:
+//	  david correll:
+//	  jack kenyon:
+//	    company: embrace pet insurance
+//	    email: jkenyon@example.com
+//
+// Besides the nested "name:" form, bare names and "- name" list items are read as attendees.
+func parseAttendees(text string) []Attendee {
 	attendees := make([]Attendee, 0)
 
 	// Extract frontmatter block if it exists
 	var frontmatterText string
 
 	// Check for frontmatter with --- delimiters
-	fmBlockRe := regexp.MustCompile(`(?s)^---\s*\n(.*?)\n---`)
-	if fmBlock := fmBlockRe.FindStringSubmatch(text); len(fmBlock) > 1 {
+	if fmBlock := frontMatterRegex.FindStringSubmatch(text); len(fmBlock) > 1 {
 		frontmatterText = fmBlock[1]
 	} else {
 		// Fallback to inline frontmatter format
@@ -261,14 +532,14 @@ func (s *NotesService) extractAttendees(filePath string) ([]Attendee, error) {
 	}
 
 	// Find the attendees section
-	attendeesRe := regexp.MustCompile(`(?m)^attendees:\s*$`)
-	if !attendeesRe.MatchString(frontmatterText) {
-		return attendees, nil
+	if !attendeesFieldRegex.MatchString(frontmatterText) {
+		return attendees
 	}
 
 	// Split content into lines
 	lines := strings.Split(frontmatterText, "\n")
 	inAttendees := false
+	attendeeIndent := -1 // Indent of the attendee names, set by the first one
 	var currentAttendee *Attendee
 
 	for _, line := range lines {
@@ -288,7 +559,8 @@ func (s *NotesService) extractAttendees(filePath string) ([]Attendee, error) {
 			continue
 		}
 
-		// Parse attendee lines
+		// Skip blank and whitespace-only lines, like the placeholder line the default
+		// meeting template leaves under attendees:
 		trimmed := strings.TrimSpace(line)
 		if trimmed == "" {
 			continue
@@ -296,18 +568,22 @@ func (s *NotesService) extractAttendees(filePath string) ([]Attendee, error) {
 
 		// Check indentation level
 		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		if attendeeIndent < 0 {
+			attendeeIndent = indent
+		}
 
-		// Main attendee (2 spaces indent)
-		if indent <= 2 && strings.HasSuffix(trimmed, ":") {
+		if indent <= attendeeIndent {
 			// Save previous attendee if exists
 			if currentAttendee != nil {
 				attendees = append(attendees, *currentAttendee)
+				currentAttendee = nil
+			}
+			// Start new attendee, unless the line names nobody (e.g. a bare ":")
+			if name := attendeeName(trimmed); name != "" {
+				currentAttendee = &Attendee{Name: name}
 			}
-			// Start new attendee
-			name := strings.TrimSuffix(trimmed, ":")
-			currentAttendee = &Attendee{Name: name}
-		} else if indent > 2 && currentAttendee != nil {
-			// Nested properties (4+ spaces indent)
+		} else if currentAttendee != nil {
+			// Nested properties, indented under the attendee's name
 			if strings.Contains(trimmed, ":") {
 				parts := strings.SplitN(trimmed, ":", 2)
 				if len(parts) == 2 {
@@ -330,11 +606,56 @@ func (s *NotesService) extractAttendees(filePath string) ([]Attendee, error) {
 		attendees = append(attendees, *currentAttendee)
 	}
 
-	return attendees, nil
+	return attendees
+}
+
+// attendeeName returns the name an attendees: entry gives, like "alice:", a bare
+// "alice" or "- alice", or a list item's "- name: alice". It's "" for entries that
+// name nobody, like a bare ":" or an empty "name:".
+func attendeeName(entry string) string {
+	entry = strings.TrimSpace(strings.TrimPrefix(entry, "-"))
+	key, value, hasColon := strings.Cut(entry, ":")
+	key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+	switch {
+	case !hasColon:
+		return entry
+	case strings.EqualFold(key, "name"):
+		return value
+	case value == "":
+		return key
+	default:
+		// Some other key, not a name
+		return ""
+	}
 }
 
 // SearchNotes searches notes by name, tags, or content
 func (s *NotesService) SearchNotes(query string) ([]Note, error) {
+	return s.SearchNotesWithOpts(query, SearchNotesOpts{})
+}
+
+// SearchOptions controls how a search query matches notes, journal entries and summaries
+type SearchOptions struct {
+	// FullFile matches content against the whole file, including frontmatter.
+	// By default only the body is searched so frontmatter keys don't match every note.
+	FullFile bool
+	// CaseSensitive matches the query's case exactly instead of ignoring it
+	CaseSensitive bool
+	// Regex treats the query as a regular expression (see regexp/syntax)
+	Regex bool
+}
+
+// SearchNotesOpts are the options SearchNotesWithOpts matches notes with
+type SearchNotesOpts = SearchOptions
+
+// SearchNotesWithOpts searches notes by name, title, tags, keywords, attendees, or content.
+// Returns an error if opts.Regex is set and the query isn't a valid pattern.
+func (s *NotesService) SearchNotesWithOpts(query string, opts SearchNotesOpts) ([]Note, error) {
+	match, err := NewSearchMatcher(query, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	allNotes, err := s.ListNotes()
 	if err != nil {
 		return nil, err
@@ -344,12 +665,11 @@ func (s *NotesService) SearchNotes(query string) ([]Note, error) {
 		return allNotes, nil
 	}
 
-	query = strings.ToLower(query)
 	var results []Note
 
 	for _, note := range allNotes {
-		// Search in filename
-		if strings.Contains(strings.ToLower(note.Name), query) {
+		// Search in filename and title
+		if match(note.Name) || match(note.Title) {
 			results = append(results, note)
 			continue
 		}
@@ -357,7 +677,7 @@ func (s *NotesService) SearchNotes(query string) ([]Note, error) {
 		// Search in tags
 		foundInTags := false
 		for _, tag := range note.Tags {
-			if strings.Contains(tag, query) {
+			if match(tag) {
 				results = append(results, note)
 				foundInTags = true
 				break
@@ -367,9 +687,22 @@ func (s *NotesService) SearchNotes(query string) ([]Note, error) {
 			continue
 		}
 
+		// Search in keywords and attendees (these live in frontmatter)
+		if matchesMetadata(note, match) {
+			results = append(results, note)
+			continue
+		}
+
 		// Search in content
 		content, err := os.ReadFile(note.FilePath)
-		if err == nil && strings.Contains(strings.ToLower(string(content)), query) {
+		if err != nil {
+			continue
+		}
+		text := string(content)
+		if !opts.FullFile {
+			text = StripFrontMatter(text)
+		}
+		if match(text) {
 			results = append(results, note)
 		}
 	}
@@ -377,6 +710,57 @@ func (s *NotesService) SearchNotes(query string) ([]Note, error) {
 	return results, nil
 }
 
+// ResolveNotePath finds a note by its name relative to the notes directory,
+// adding the .md extension if it's missing
+func (s *NotesService) ResolveNotePath(name string) (string, error) {
+	if !strings.HasSuffix(name, ".md") {
+		name += ".md"
+	}
+
+	path := filepath.Join(s.notesDir, filepath.Clean(name))
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("note %s not found in %s", name, s.notesDir)
+		}
+		return "", fmt.Errorf("failed to access note %s: %w", name, err)
+	}
+	if info.IsDir() {
+		return "", fmt.Errorf("%s is a directory, not a note", name)
+	}
+	return path, nil
+}
+
+// SearchInNote returns the lines of a single note that match query
+func (s *NotesService) SearchInNote(name, query string, opts SearchOptions) ([]LineMatch, error) {
+	path, err := s.ResolveNotePath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := s.ReadNote(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return SearchLines(content, query, opts)
+}
+
+// matchesMetadata reports whether a note's keywords or attendees match
+func matchesMetadata(note Note, match func(string) bool) bool {
+	for _, keyword := range note.Keywords {
+		if match(keyword) {
+			return true
+		}
+	}
+	for _, attendee := range note.Attendees {
+		if match(attendee.Name) {
+			return true
+		}
+	}
+	return false
+}
+
 // FilterByTag returns notes that have the specified tag
 func (s *NotesService) FilterByTag(tag string) ([]Note, error) {
 	allNotes, err := s.ListNotes()
@@ -384,7 +768,7 @@ func (s *NotesService) FilterByTag(tag string) ([]Note, error) {
 		return nil, err
 	}
 
-	tag = strings.ToLower(tag)
+	tag = normalizeTag(tag)
 	var results []Note
 
 	for _, note := range allNotes {
@@ -421,6 +805,87 @@ func (s *NotesService) GetAllTags() ([]string, error) {
 	return result, nil
 }
 
+// TagCount is a tag and how many notes use it
+type TagCount struct {
+	Tag   string
+	Count int
+}
+
+// GetTagCounts returns every tag with the number of notes using it,
+// most used first (ties sorted by name)
+func (s *NotesService) GetTagCounts() ([]TagCount, error) {
+	allNotes, err := s.ListNotes()
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	for _, note := range allNotes {
+		for _, tag := range note.Tags {
+			counts[tag]++
+		}
+	}
+
+	result := make([]TagCount, 0, len(counts))
+	for tag, count := range counts {
+		result = append(result, TagCount{Tag: tag, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Tag < result[j].Tag
+	})
+
+	return result, nil
+}
+
+// defaultNoteFrontMatter is the empty frontmatter block new notes start with
+const defaultNoteFrontMatter = "---\ntags:\nkeywords:\n---\n\n"
+
+// uniqueDestPath returns the path for a note named name in dir, adding -2, -3, ... before
+// the extension until it doesn't clash with an existing file
+func uniqueDestPath(dir, name string) string {
+	path := filepath.Join(dir, name)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return path
+	}
+
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	for i := 2; ; i++ {
+		path = filepath.Join(dir, fmt.Sprintf("%s-%d%s", base, i, ext))
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return path
+		}
+	}
+}
+
+// destPath returns where a note named name goes in dir: the name itself, or a suffixed
+// one if it's taken and the service suffixes on clashes. Otherwise a clash is an error.
+func (s *NotesService) destPath(dir, name string) (string, error) {
+	path := filepath.Join(dir, name)
+	if _, err := os.Stat(path); err != nil {
+		return path, nil
+	}
+	if !s.options.SuffixOnClash {
+		rel, _ := filepath.Rel(s.notesDir, dir)
+		return "", fmt.Errorf("a note named '%s' already exists in '%s'", name, rel)
+	}
+	return uniqueDestPath(dir, name), nil
+}
+
+// moveNoteFile renames a note and its saved undo history, if it has one
+func moveNoteFile(oldPath, newPath string) error {
+	if err := os.Rename(oldPath, newPath); err != nil {
+		return err
+	}
+	if err := os.Rename(UndoHistoryPath(oldPath), UndoHistoryPath(newPath)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
 // CreateNote creates a new note file
 func (s *NotesService) CreateNote(name string) (string, error) {
 	return s.CreateNoteInPath(name, "")
@@ -456,7 +921,10 @@ func (s *NotesService) CreateNoteInPath(name string, relPath string) (string, er
 
 		// Write initial template with proper YAML frontmatter
 		// Don't auto-fill the title - let user add it if they want
-		template := "---\ntags:\nkeywords:\n---\n\n# \n\n"
+		template := defaultNoteFrontMatter
+		if s.options.NewNoteHeading {
+			template += "# \n\n"
+		}
 		_, err = file.WriteString(template)
 		if err != nil {
 			return "", err
@@ -466,12 +934,44 @@ func (s *NotesService) CreateNoteInPath(name string, relPath string) (string, er
 	return filePath, nil
 }
 
+// CreateNoteWithBody creates a new note in a subdirectory whose body is the given text,
+// wrapped in the default frontmatter. Existing notes are never overwritten.
+func (s *NotesService) CreateNoteWithBody(name, relPath, body string) (string, error) {
+	if !strings.HasSuffix(name, ".md") {
+		name = name + ".md"
+	}
+
+	targetDir := filepath.Join(s.notesDir, relPath)
+	filePath := filepath.Join(targetDir, name)
+
+	if _, err := os.Stat(filePath); err == nil {
+		return "", fmt.Errorf("a note named '%s' already exists", name)
+	}
+
+	if err := os.MkdirAll(targetDir, 0755); err != nil {
+		return "", err
+	}
+
+	if !strings.HasSuffix(body, "\n") {
+		body += "\n"
+	}
+
+	if err := os.WriteFile(filePath, []byte(defaultNoteFrontMatter+body), 0644); err != nil {
+		return "", fmt.Errorf("failed to write note: %w", err)
+	}
+
+	return filePath, nil
+}
+
 // ReadNote reads a note's content
 func (s *NotesService) ReadNote(filePath string) (string, error) {
 	content, err := os.ReadFile(filePath)
 	if err != nil {
 		return "", err
 	}
+	if looksBinary(content) {
+		return "", fmt.Errorf("refusing to open %s: %w", filepath.Base(filePath), ErrBinaryContent)
+	}
 	return string(content), nil
 }
 
@@ -486,9 +986,15 @@ func (s *NotesService) WriteNote(filePath, content string) error {
 	return os.WriteFile(filePath, []byte(content), 0644)
 }
 
-// DeleteNote deletes a note file
+// DeleteNote deletes a note file, along with its saved undo history
 func (s *NotesService) DeleteNote(filePath string) error {
-	return os.Remove(filePath)
+	if err := os.Remove(filePath); err != nil {
+		return err
+	}
+	if err := os.Remove(UndoHistoryPath(filePath)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
 }
 
 // CreateNoteFromTemplate creates a new note using a template
@@ -530,20 +1036,47 @@ func (s *NotesService) CreateNoteFromTemplateInPath(name, templatePath, relPath
 	return filePath, nil
 }
 
-// CreateTemplate creates a new template file
-func (s *NotesService) CreateTemplate(name, content string) (string, error) {
-	// Ensure templates directory exists
-	if err := os.MkdirAll(s.templatesDir, 0755); err != nil {
-		return "", err
+// templateExtRegex matches a file extension at the end of a template name
+var templateExtRegex = regexp.MustCompile(`\.[A-Za-z0-9]+$`)
+
+// TemplateFileName validates a template name and returns its file name relative
+// to the templates directory, adding the .md extension if it's missing. Names may
+// use subdirectories of the templates directory but not leave it.
+func TemplateFileName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("template name is empty")
 	}
 
-	// Ensure .md extension
-	if !strings.HasSuffix(name, ".md") {
-		name = name + ".md"
+	ext := templateExtRegex.FindString(name)
+	if ext != "" && !strings.EqualFold(ext, ".md") {
+		return "", fmt.Errorf("templates are markdown files, so the name can't end in %s: %s", ext, name)
+	}
+	if ext == "" {
+		name += ".md"
+	}
+
+	clean := filepath.Clean(name)
+	if filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) || strings.HasPrefix(filepath.Base(clean), ".") {
+		return "", fmt.Errorf("template name must be a file inside the templates directory: %s", name)
+	}
+	return clean, nil
+}
+
+// CreateTemplate creates a new template file, replacing any template with the same name
+func (s *NotesService) CreateTemplate(name, content string) (string, error) {
+	name, err := TemplateFileName(name)
+	if err != nil {
+		return "", err
 	}
 
 	filePath := filepath.Join(s.templatesDir, name)
 
+	// Ensure the template's directory exists
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return "", err
+	}
+
 	// Write template
 	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
 		return "", err
@@ -570,61 +1103,39 @@ func (s *NotesService) DeleteTemplate(templatePath string) error {
 	return os.Remove(templatePath)
 }
 
-// InitializeDefaultTemplates creates default templates if they don't exist
+// InitializeDefaultTemplates writes the embedded default templates into the
+// user's templates directory. Templates that already exist on disk are left
+// untouched, so users can override a default by editing it or supplement the
+// set by adding their own files alongside them.
 func (s *NotesService) InitializeDefaultTemplates() error {
 	// Ensure templates directory exists
 	if err := os.MkdirAll(s.templatesDir, 0755); err != nil {
 		return err
 	}
 
-	// Define default templates with proper YAML frontmatter
-	templates := map[string]string{
-		"meeting-notes.md": `---
-tags: meeting
-keywords:
-attendees:
-  
----
-
-# Meeting Notes
-
-## Notes
-
-
-
-## Takeaways
-
-`,
-		"todo-list.md": `---
-tags: todo
-keywords:
----
-
-# TODO List
-
-## Tasks
+	entries, err := fs.ReadDir(defaultTemplatesFS, "templates")
+	if err != nil {
+		return err
+	}
 
-- [ ] 
-- [ ] 
-- [ ] 
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+			continue
+		}
 
-## Completed
+		filePath := filepath.Join(s.templatesDir, entry.Name())
 
-- [x] 
-`,
-		"blank.md": `---
-tags:
-keywords:
----
+		// Keep the user's copy if one already exists
+		if _, err := os.Stat(filePath); err == nil {
+			continue
+		}
 
-# 
-`,
-	}
+		content, err := defaultTemplatesFS.ReadFile("templates/" + entry.Name())
+		if err != nil {
+			return err
+		}
 
-	// Create or update templates
-	for name, content := range templates {
-		filePath := filepath.Join(s.templatesDir, name)
-		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		if err := os.WriteFile(filePath, content, 0644); err != nil {
 			return err
 		}
 	}
@@ -632,7 +1143,9 @@ keywords:
 	return nil
 }
 
-// CreateCategory creates a new directory/category for notes
+// CreateCategory creates a new directory/category for notes.
+// Returns an error if the path already exists and is not an empty directory,
+// so callers don't silently merge into an existing category.
 func (s *NotesService) CreateCategory(categoryPath string) error {
 	// Clean the path and build absolute path
 	cleanPath := filepath.Clean(categoryPath)
@@ -644,12 +1157,68 @@ func (s *NotesService) CreateCategory(categoryPath string) error {
 		return filepath.ErrBadPattern
 	}
 
+	// Guard against colliding with an existing file or populated directory
+	if info, err := os.Stat(fullPath); err == nil {
+		if !info.IsDir() {
+			return fmt.Errorf("cannot create category '%s': a file with that name already exists", relPath)
+		}
+		entries, err := os.ReadDir(fullPath)
+		if err != nil {
+			return fmt.Errorf("cannot read existing category '%s': %w", relPath, err)
+		}
+		if len(entries) > 0 {
+			return fmt.Errorf("category '%s' already exists and contains %d item(s)", relPath, len(entries))
+		}
+	}
+
 	// Create the directory and any necessary parent directories
 	return os.MkdirAll(fullPath, 0755)
 }
 
-// MoveNote moves a note to a new location
-func (s *NotesService) MoveNote(oldPath, newCategoryPath string) error {
+// CountCategoryItems returns the number of entries directly inside a category
+func (s *NotesService) CountCategoryItems(categoryPath string) (int, error) {
+	entries, err := os.ReadDir(filepath.Join(s.notesDir, filepath.Clean(categoryPath)))
+	if err != nil {
+		return 0, err
+	}
+	return len(entries), nil
+}
+
+// DeleteCategory removes a category directory. Non-empty categories are only
+// removed when force is true; otherwise a descriptive error is returned.
+func (s *NotesService) DeleteCategory(categoryPath string, force bool) error {
+	cleanPath := filepath.Clean(categoryPath)
+	fullPath := filepath.Join(s.notesDir, cleanPath)
+
+	// Prevent deleting the notes root or anything outside it
+	relPath, err := filepath.Rel(s.notesDir, fullPath)
+	if err != nil || relPath == "." || strings.HasPrefix(relPath, "..") {
+		return filepath.ErrBadPattern
+	}
+
+	info, err := os.Stat(fullPath)
+	if err != nil {
+		return fmt.Errorf("cannot delete category '%s': %w", relPath, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("cannot delete '%s': not a category", relPath)
+	}
+
+	count, err := s.CountCategoryItems(cleanPath)
+	if err != nil {
+		return fmt.Errorf("cannot read category '%s': %w", relPath, err)
+	}
+	if count > 0 && !force {
+		return fmt.Errorf("category '%s' is not empty (%d item(s)); confirm to delete it and its contents", relPath, count)
+	}
+
+	return os.RemoveAll(fullPath)
+}
+
+// MoveNote moves a note to a new category and returns its new path. A note with the
+// same name there is never overwritten: the moved note gets a numeric suffix, or with
+// SuffixOnClash turned off, the move fails.
+func (s *NotesService) MoveNote(oldPath, newCategoryPath string) (string, error) {
 	// Clean the new category path
 	cleanPath := filepath.Clean(newCategoryPath)
 	targetDir := filepath.Join(s.notesDir, cleanPath)
@@ -657,20 +1226,119 @@ func (s *NotesService) MoveNote(oldPath, newCategoryPath string) error {
 	// Prevent moving outside notes directory
 	relPath, err := filepath.Rel(s.notesDir, targetDir)
 	if err != nil || strings.HasPrefix(relPath, "..") {
-		return filepath.ErrBadPattern
+		return "", filepath.ErrBadPattern
+	}
+
+	// Moving a note onto itself is a no-op
+	filename := filepath.Base(oldPath)
+	if filepath.Clean(oldPath) == filepath.Join(targetDir, filename) {
+		return oldPath, nil
+	}
+
+	// Don't clobber an existing note
+	newPath, err := s.destPath(targetDir, filename)
+	if err != nil {
+		return "", err
 	}
 
 	// Create target directory if it doesn't exist
 	if err := os.MkdirAll(targetDir, 0755); err != nil {
+		return "", err
+	}
+
+	if err := moveNoteFile(oldPath, newPath); err != nil {
+		return "", err
+	}
+	return newPath, nil
+}
+
+// RenameNote gives a note a new name in the same category, returning its new path.
+// Like MoveNote it won't overwrite another note.
+func (s *NotesService) RenameNote(oldPath, newName string) (string, error) {
+	newName = strings.TrimSpace(newName)
+	if newName == "" || strings.ContainsAny(newName, `/\`) || strings.HasPrefix(newName, ".") {
+		return "", fmt.Errorf("invalid note name %q", newName)
+	}
+	if !strings.HasSuffix(newName, ".md") {
+		newName += ".md"
+	}
+
+	dir := filepath.Dir(oldPath)
+	if filepath.Base(oldPath) == newName {
+		return oldPath, nil
+	}
+
+	newPath, err := s.destPath(dir, newName)
+	if err != nil {
+		return "", err
+	}
+	if err := moveNoteFile(oldPath, newPath); err != nil {
+		return "", err
+	}
+	return newPath, nil
+}
+
+// DuplicateNote copies a note next to itself, named with the next free numeric suffix
+// (e.g. "standup-2.md"), and returns the copy's path
+func (s *NotesService) DuplicateNote(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read note: %w", err)
+	}
+
+	newPath := uniqueDestPath(filepath.Dir(path), filepath.Base(path))
+	if err := os.WriteFile(newPath, content, 0644); err != nil {
+		return "", fmt.Errorf("failed to write note: %w", err)
+	}
+	return newPath, nil
+}
+
+// SetPinned pins or unpins a note by rewriting the pinned: key of its frontmatter.
+// The note keeps its modification time, so pinning doesn't reorder it as an edit would.
+func (s *NotesService) SetPinned(path string, pinned bool) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to read note: %w", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read note: %w", err)
+	}
+
+	content := string(data)
+	ending := DetectLineEnding(content)
+	updated, err := SetFrontMatterPinned(NormalizeLineEndings(content), pinned)
+	if err != nil {
 		return err
 	}
+	if err := os.WriteFile(path, []byte(RestoreLineEndings(updated, ending)), info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write note: %w", err)
+	}
+	_ = os.Chtimes(path, info.ModTime(), info.ModTime())
+	return nil
+}
 
-	// Build new file path with same filename
-	filename := filepath.Base(oldPath)
-	newPath := filepath.Join(targetDir, filename)
+// NeighborNote returns the note step places after path (negative steps go back) in
+// its directory, listed as the browser lists it with mode. Stepping past either end
+// wraps around, so a directory with one note is its own neighbor.
+func (s *NotesService) NeighborNote(path string, mode NoteSortMode, step int) (string, error) {
+	relDir, err := filepath.Rel(s.notesDir, filepath.Dir(path))
+	if err != nil {
+		return "", fmt.Errorf("note is outside the notes directory: %w", err)
+	}
+	notes, _, err := s.ListNotesInPath(relDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to list notes: %w", err)
+	}
+	SortNotes(notes, mode)
 
-	// Move the file
-	return os.Rename(oldPath, newPath)
+	for i, note := range notes {
+		if note.FilePath == filepath.Clean(path) {
+			n := len(notes)
+			return notes[((i+step)%n+n)%n].FilePath, nil
+		}
+	}
+	return "", fmt.Errorf("note not found in its directory: %s", filepath.Base(path))
 }
 
 // ListNotesInPath returns notes and directories in a specific path
@@ -703,20 +1371,7 @@ func (s *NotesService) ListNotesInPath(relPath string) ([]Note, []string, error)
 				continue
 			}
 
-			// Extract metadata from file
-			tags, _ := s.extractTags(fullPath)
-			keywords, _ := s.extractKeywords(fullPath)
-			attendees, _ := s.extractAttendees(fullPath)
-
-			notes = append(notes, Note{
-				Name:       entry.Name(),
-				FilePath:   fullPath,
-				Tags:       tags,
-				Keywords:   keywords,
-				Attendees:  attendees,
-				ModTime:    info.ModTime(),
-				IsTemplate: false,
-			})
+			notes = append(notes, noteFromFile(fullPath, entry.Name(), info.ModTime()))
 		}
 	}
 
@@ -731,6 +1386,80 @@ func (s *NotesService) ListNotesInPath(relPath string) ([]Note, []string, error)
 	return notes, directories, nil
 }
 
+// DirSortMode controls how directories are ordered in the browser
+type DirSortMode int
+
+const (
+	DirSortName     DirSortMode = iota // Alphabetical (A-Z)
+	DirSortModified                    // Newest contained file first
+)
+
+// String returns a short label for the sort mode
+func (d DirSortMode) String() string {
+	if d == DirSortModified {
+		return "recent"
+	}
+	return "name"
+}
+
+// LatestModTime returns the newest modification time of any file under dirPath,
+// skipping hidden directories. An empty directory reports its own modification time.
+func LatestModTime(dirPath string) (time.Time, error) {
+	root, err := os.Stat(dirPath)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var latest time.Time
+	err = filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil // Skip unreadable entries
+		}
+
+		if info.IsDir() {
+			if path != dirPath && strings.HasPrefix(info.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+		return nil
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if latest.IsZero() {
+		latest = root.ModTime()
+	}
+	return latest, nil
+}
+
+// SortDirectories orders directory names within relPath using the given mode
+func (s *NotesService) SortDirectories(relPath string, directories []string, mode DirSortMode) {
+	if mode != DirSortModified {
+		sort.Strings(directories)
+		return
+	}
+
+	modTimes := make(map[string]time.Time, len(directories))
+	for _, dir := range directories {
+		modTime, _ := LatestModTime(filepath.Join(s.notesDir, relPath, dir))
+		modTimes[dir] = modTime
+	}
+
+	sort.SliceStable(directories, func(i, j int) bool {
+		ti, tj := modTimes[directories[i]], modTimes[directories[j]]
+		if ti.Equal(tj) {
+			return directories[i] < directories[j]
+		}
+		return ti.After(tj)
+	})
+}
+
 // GetAllDirectories recursively gets all directories under the notes directory
 func (s *NotesService) GetAllDirectories() ([]string, error) {
 	var directories []string
@@ -0,0 +1,747 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInitializeDefaultTemplatesWritesEmbeddedDefaults(t *testing.T) {
+	notesDir := t.TempDir()
+	s := NewNotesService(notesDir)
+
+	if err := s.InitializeDefaultTemplates(); err != nil {
+		t.Fatalf("InitializeDefaultTemplates returned error: %v", err)
+	}
+
+	for _, name := range []string{"blank.md", "meeting-notes.md", "todo-list.md"} {
+		want, err := defaultTemplatesFS.ReadFile("templates/" + name)
+		if err != nil {
+			t.Fatalf("embedded template %s missing: %v", name, err)
+		}
+
+		got, err := os.ReadFile(filepath.Join(notesDir, ".templates", name))
+		if err != nil {
+			t.Fatalf("template %s was not written: %v", name, err)
+		}
+
+		if string(got) != string(want) {
+			t.Errorf("template %s content mismatch:\ngot:  %q\nwant: %q", name, got, want)
+		}
+	}
+}
+
+func TestInitializeDefaultTemplatesKeepsUserOverrides(t *testing.T) {
+	notesDir := t.TempDir()
+	s := NewNotesService(notesDir)
+
+	templatesDir := filepath.Join(notesDir, ".templates")
+	if err := os.MkdirAll(templatesDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	custom := "# My blank template\n"
+	if err := os.WriteFile(filepath.Join(templatesDir, "blank.md"), []byte(custom), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.InitializeDefaultTemplates(); err != nil {
+		t.Fatalf("InitializeDefaultTemplates returned error: %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(templatesDir, "blank.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != custom {
+		t.Errorf("user template was overwritten: got %q", got)
+	}
+}
+
+func TestCreateCategoryExistingDirectory(t *testing.T) {
+	notesDir := t.TempDir()
+	s := NewNotesService(notesDir)
+
+	// An existing empty directory is fine
+	if err := os.MkdirAll(filepath.Join(notesDir, "work"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.CreateCategory("work"); err != nil {
+		t.Errorf("CreateCategory on empty dir returned error: %v", err)
+	}
+
+	// An existing directory with content should be reported
+	if err := os.WriteFile(filepath.Join(notesDir, "work", "note.md"), []byte("# Note\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.CreateCategory("work"); err == nil {
+		t.Error("CreateCategory on non-empty dir should return an error")
+	}
+}
+
+func TestDeleteCategoryNonEmptyRequiresForce(t *testing.T) {
+	notesDir := t.TempDir()
+	s := NewNotesService(notesDir)
+
+	categoryDir := filepath.Join(notesDir, "work", "projects")
+	if err := os.MkdirAll(categoryDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(categoryDir, "note.md"), []byte("# Note\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.DeleteCategory("work", false); err == nil {
+		t.Fatal("DeleteCategory without force should refuse a non-empty dir")
+	}
+	if _, err := os.Stat(categoryDir); err != nil {
+		t.Fatalf("category was removed without confirmation: %v", err)
+	}
+
+	if err := s.DeleteCategory("work", true); err != nil {
+		t.Fatalf("DeleteCategory with force returned error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(notesDir, "work")); !os.IsNotExist(err) {
+		t.Errorf("category still exists after forced delete")
+	}
+
+	if err := s.DeleteCategory("", true); err == nil {
+		t.Error("DeleteCategory should refuse to delete the notes root")
+	}
+}
+
+func TestMoveNoteRefusesToOverwrite(t *testing.T) {
+	notesDir := t.TempDir()
+	s := NewNotesServiceWithOptions(notesDir, NotesOptions{NewNoteHeading: true})
+
+	src := filepath.Join(notesDir, "note.md")
+	if err := os.WriteFile(src, []byte("source"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(notesDir, "work"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	dest := filepath.Join(notesDir, "work", "note.md")
+	if err := os.WriteFile(dest, []byte("existing"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.MoveNote(src, "work"); err == nil {
+		t.Fatal("MoveNote should refuse to overwrite an existing note")
+	}
+
+	got, _ := os.ReadFile(dest)
+	if string(got) != "existing" {
+		t.Errorf("destination note was overwritten: %q", got)
+	}
+}
+
+func TestNameClashesGetNumericSuffixes(t *testing.T) {
+	notesDir := t.TempDir()
+	s := NewNotesService(notesDir)
+	write := func(rel, content string) string {
+		t.Helper()
+		path := filepath.Join(notesDir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	write("work/note.md", "existing")
+	write("work/note-2.md", "existing too")
+
+	// Moving onto a taken name picks the next free suffix
+	moved, err := s.MoveNote(write("note.md", "moved"), "work")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(notesDir, "work", "note-3.md"); moved != want {
+		t.Errorf("MoveNote = %s, want %s", moved, want)
+	}
+
+	// Renaming onto a taken name does too
+	renamed, err := s.RenameNote(write("work/draft.md", "draft"), "note")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(notesDir, "work", "note-4.md"); renamed != want {
+		t.Errorf("RenameNote = %s, want %s", renamed, want)
+	}
+	if _, err := s.RenameNote(renamed, "../escape"); err == nil {
+		t.Error("RenameNote should refuse names with a path")
+	}
+
+	// Duplicates always get a suffix
+	first, err := s.DuplicateNote(filepath.Join(notesDir, "work", "note.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := s.DuplicateNote(filepath.Join(notesDir, "work", "note.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(first) != "note-5.md" || filepath.Base(second) != "note-6.md" {
+		t.Errorf("duplicates = %s, %s; want note-5.md, note-6.md", filepath.Base(first), filepath.Base(second))
+	}
+
+	// Nothing was overwritten
+	for name, want := range map[string]string{"note.md": "existing", "note-2.md": "existing too", "note-3.md": "moved", "note-4.md": "draft", "note-6.md": "existing"} {
+		if got, _ := os.ReadFile(filepath.Join(notesDir, "work", name)); string(got) != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestLatestModTimeAndDirectorySort(t *testing.T) {
+	notesDir := t.TempDir()
+	s := NewNotesService(notesDir)
+
+	now := time.Now()
+	files := map[string]time.Time{
+		filepath.Join("archive", "old.md"):           now.Add(-72 * time.Hour),
+		filepath.Join("active", "deep", "recent.md"): now.Add(-1 * time.Hour),
+		filepath.Join("active", "older.md"):          now.Add(-48 * time.Hour),
+		filepath.Join("misc", "note.md"):             now.Add(-24 * time.Hour),
+	}
+	for rel, modTime := range files {
+		path := filepath.Join(notesDir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("# Note\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	latest, err := LatestModTime(filepath.Join(notesDir, "active"))
+	if err != nil {
+		t.Fatalf("LatestModTime returned error: %v", err)
+	}
+	if !latest.Equal(files[filepath.Join("active", "deep", "recent.md")]) {
+		t.Errorf("LatestModTime = %v, want the nested file's modtime", latest)
+	}
+
+	dirs := []string{"archive", "misc", "active"}
+	s.SortDirectories("", dirs, DirSortModified)
+	if want := []string{"active", "misc", "archive"}; !reflect.DeepEqual(dirs, want) {
+		t.Errorf("SortDirectories(modified) = %v, want %v", dirs, want)
+	}
+
+	s.SortDirectories("", dirs, DirSortName)
+	if want := []string{"active", "archive", "misc"}; !reflect.DeepEqual(dirs, want) {
+		t.Errorf("SortDirectories(name) = %v, want %v", dirs, want)
+	}
+}
+
+func TestSearchNotesBodyOnlyIgnoresFrontMatter(t *testing.T) {
+	notesDir := t.TempDir()
+	s := NewNotesService(notesDir)
+
+	note := "---\ntags: [work]\nkeywords:\n---\n\n# Weekly sync\n\nDiscussed the roadmap.\n"
+	if err := os.WriteFile(filepath.Join(notesDir, "sync.md"), []byte(note), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := s.SearchNotesWithOpts("keywords", SearchOptions{})
+	if err != nil {
+		t.Fatalf("SearchNotesWithOpts returned error: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("body-only search matched a frontmatter key: %d result(s)", len(results))
+	}
+
+	results, err = s.SearchNotesWithOpts("roadmap", SearchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 {
+		t.Errorf("body-only search should match body text, got %d result(s)", len(results))
+	}
+
+	results, err = s.SearchNotesWithOpts("keywords", SearchOptions{FullFile: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 {
+		t.Errorf("full-file search should match frontmatter, got %d result(s)", len(results))
+	}
+}
+
+func TestSortNotesByWeight(t *testing.T) {
+	notesDir := t.TempDir()
+	s := NewNotesService(notesDir)
+
+	now := time.Now()
+	files := []struct {
+		name    string
+		content string
+		modTime time.Time
+	}{
+		{"unweighted.md", "# Newest\n", now},
+		{"heavy.md", "---\nweight: 10\n---\n# Heavy\n", now.Add(-1 * time.Hour)},
+		{"pinned.md", "---\npriority: -1\n---\n# Pinned\n", now.Add(-72 * time.Hour)},
+		{"light-old.md", "---\nweight: 1\n---\n# Light old\n", now.Add(-48 * time.Hour)},
+		{"light-new.md", "---\nweight: 1\n---\n# Light new\n", now.Add(-24 * time.Hour)},
+	}
+	for _, f := range files {
+		path := filepath.Join(notesDir, f.name)
+		if err := os.WriteFile(path, []byte(f.content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, f.modTime, f.modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	notes, _, err := s.ListNotesInPath("")
+	if err != nil {
+		t.Fatalf("ListNotesInPath returned error: %v", err)
+	}
+
+	SortNotes(notes, NoteSortWeight)
+	var got []string
+	for _, note := range notes {
+		got = append(got, note.Name)
+	}
+	want := []string{"pinned.md", "light-new.md", "light-old.md", "heavy.md", "unweighted.md"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SortNotes(weight) = %v, want %v", got, want)
+	}
+
+	SortNotes(notes, NoteSortModified)
+	if notes[0].Name != "unweighted.md" {
+		t.Errorf("SortNotes(modified) put %s first, want unweighted.md", notes[0].Name)
+	}
+}
+
+func TestNoteTitleFallbacks(t *testing.T) {
+	notesDir := t.TempDir()
+	s := NewNotesService(notesDir)
+
+	files := map[string]string{
+		"frontmatter.md": "---\ntitle: \"Quarterly Planning\"\ntags: work\n---\n# Ignored Heading\n",
+		"heading.md":     "---\ntags:\n---\n\n# Team Retro\n\nbody\n",
+		"plain.md":       "---\ntags:\nkeywords:\n---\n\n# \n\njust text\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(notesDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	notes, _, err := s.ListNotesInPath("")
+	if err != nil {
+		t.Fatalf("ListNotesInPath returned error: %v", err)
+	}
+	got := make(map[string]string)
+	for _, note := range notes {
+		got[note.Name] = note.DisplayName()
+	}
+	want := map[string]string{
+		"frontmatter.md": "Quarterly Planning",
+		"heading.md":     "Team Retro",
+		"plain.md":       "plain.md",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("display names = %v, want %v", got, want)
+	}
+
+	// Search matches the title as well as the filename
+	for _, query := range []string{"quarterly", "frontmatter"} {
+		results, err := s.SearchNotes(query)
+		if err != nil {
+			t.Fatalf("SearchNotes(%q) returned error: %v", query, err)
+		}
+		if len(results) != 1 || results[0].Name != "frontmatter.md" {
+			t.Errorf("SearchNotes(%q) = %v, want frontmatter.md", query, results)
+		}
+	}
+}
+
+func TestSearchNotesCaseSensitiveAndRegex(t *testing.T) {
+	notesDir := t.TempDir()
+	s := NewNotesService(notesDir)
+
+	files := map[string]string{
+		"api.md":     "Deployed the API gateway\n",
+		"lower.md":   "the api client is flaky\n",
+		"tickets.md": "Fixed OPS-1234 and OPS-99\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(notesDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	names := func(notes []Note) []string {
+		var result []string
+		for _, note := range notes {
+			result = append(result, note.Name)
+		}
+		sort.Strings(result)
+		return result
+	}
+
+	results, err := s.SearchNotesWithOpts("API", SearchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := names(results), []string{"api.md", "lower.md"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("case-insensitive search = %v, want %v", got, want)
+	}
+
+	results, err = s.SearchNotesWithOpts("API", SearchOptions{CaseSensitive: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := names(results), []string{"api.md"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("case-sensitive search = %v, want %v", got, want)
+	}
+
+	results, err = s.SearchNotesWithOpts(`ops-\d{4}\b`, SearchOptions{Regex: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := names(results), []string{"tickets.md"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("regex search = %v, want %v", got, want)
+	}
+
+	results, err = s.SearchNotesWithOpts(`ops-\d{4}`, SearchOptions{Regex: true, CaseSensitive: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 0 {
+		t.Errorf("case-sensitive regex search = %v, want no results", names(results))
+	}
+
+	_, err = s.SearchNotesWithOpts("gateway(", SearchOptions{Regex: true})
+	if err == nil || !strings.Contains(err.Error(), "invalid search pattern") {
+		t.Errorf("invalid pattern error = %v, want an invalid search pattern error", err)
+	}
+}
+
+func TestSearchInNoteLineNumbers(t *testing.T) {
+	notesDir := t.TempDir()
+	s := NewNotesService(notesDir)
+
+	if err := os.MkdirAll(filepath.Join(notesDir, "work"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := "# Runbook\r\n\r\nRestart the gateway\r\nCheck logs\r\n\r\nGateway timeout: 30s\r\n"
+	if err := os.WriteFile(filepath.Join(notesDir, "work", "runbook.md"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	matches, err := s.SearchInNote("work/runbook", "gateway", SearchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []LineMatch{
+		{Line: 3, Text: "Restart the gateway"},
+		{Line: 6, Text: "Gateway timeout: 30s"},
+	}
+	if !reflect.DeepEqual(matches, want) {
+		t.Errorf("SearchInNote = %v, want %v", matches, want)
+	}
+
+	matches, err = s.SearchInNote("work/runbook.md", `^\w+ logs$`, SearchOptions{Regex: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []LineMatch{{Line: 4, Text: "Check logs"}}; !reflect.DeepEqual(matches, want) {
+		t.Errorf("regex SearchInNote = %v, want %v", matches, want)
+	}
+
+	if _, err := s.SearchInNote("missing", "gateway", SearchOptions{}); err == nil {
+		t.Error("expected an error for a missing note")
+	}
+}
+
+func TestCreateTemplateValidatesName(t *testing.T) {
+	s := NewNotesService(t.TempDir())
+
+	for _, name := range []string{"", "  ", "standup.txt", "../outside", "/abs/path", ".hidden"} {
+		if _, err := s.CreateTemplate(name, "content"); err == nil {
+			t.Errorf("CreateTemplate(%q) should fail", name)
+		}
+	}
+
+	path, err := s.CreateTemplate("meetings/standup", "# Standup\n")
+	if err != nil {
+		t.Fatalf("CreateTemplate returned error: %v", err)
+	}
+	if want := filepath.Join(s.GetTemplatesDir(), "meetings", "standup.md"); path != want {
+		t.Errorf("path = %q, want %q", path, want)
+	}
+	if got, _ := os.ReadFile(path); string(got) != "# Standup\n" {
+		t.Errorf("content = %q", got)
+	}
+
+	if _, err := s.CreateTemplate("empty.md", ""); err != nil {
+		t.Errorf("CreateTemplate with .md and no content returned error: %v", err)
+	}
+}
+
+func TestListTemplates(t *testing.T) {
+	s := NewNotesService(t.TempDir())
+
+	for _, name := range []string{"weekly", "meetings/standup", "blank.md"} {
+		if _, err := s.CreateTemplate(name, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Notes outside the templates directory aren't templates
+	if _, err := s.CreateNote("not-a-template"); err != nil {
+		t.Fatal(err)
+	}
+
+	templates, err := s.ListTemplates()
+	if err != nil {
+		t.Fatalf("ListTemplates returned error: %v", err)
+	}
+	var names []string
+	for _, template := range templates {
+		if !template.IsTemplate {
+			t.Errorf("%s is not marked as a template", template.Name)
+		}
+		names = append(names, template.Name)
+	}
+	want := []string{"blank.md", filepath.Join("meetings", "standup.md"), "weekly.md"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("templates = %q, want %q", names, want)
+	}
+}
+
+func TestNeighborNoteFollowsDirectoryOrder(t *testing.T) {
+	notesDir := t.TempDir()
+	s := NewNotesService(notesDir)
+	now := time.Now()
+	write := func(rel, content string, age time.Duration) string {
+		t.Helper()
+		path := filepath.Join(notesDir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, now.Add(-age), now.Add(-age)); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	newest := write("work/newest.md", "# Newest", time.Minute)
+	middle := write("work/middle.md", "---\nweight: 1\n---\n# Middle", time.Hour)
+	oldest := write("work/oldest.md", "---\nweight: 2\n---\n# Oldest", 2*time.Hour)
+	write("elsewhere.md", "# Not in work", 0)
+	write("work/sub/nested.md", "# Not in work either", 0)
+
+	tests := []struct {
+		mode NoteSortMode
+		from string
+		step int
+		want string
+	}{
+		{NoteSortModified, newest, 1, middle},
+		{NoteSortModified, middle, 1, oldest},
+		{NoteSortModified, oldest, 1, newest},  // Wraps past the end
+		{NoteSortModified, newest, -1, oldest}, // And past the start
+		{NoteSortWeight, middle, 1, oldest},
+		{NoteSortWeight, oldest, 1, newest},
+		{NoteSortWeight, middle, -1, newest},
+	}
+	for _, tt := range tests {
+		got, err := s.NeighborNote(tt.from, tt.mode, tt.step)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("NeighborNote(%s, %s, %d) = %s, want %s",
+				filepath.Base(tt.from), tt.mode, tt.step, filepath.Base(got), filepath.Base(tt.want))
+		}
+	}
+
+	// A note alone in its directory is its own neighbor
+	alone := write("solo/alone.md", "# Alone", 0)
+	if got, err := s.NeighborNote(alone, NoteSortModified, 1); err != nil || got != alone {
+		t.Errorf("NeighborNote(alone) = %s, %v; want itself", got, err)
+	}
+}
+
+func TestListRecentNotes(t *testing.T) {
+	notesDir := t.TempDir()
+	s := NewNotesService(notesDir)
+	now := time.Now()
+	for i, rel := range []string{"work/newest.md", "middle.md", "personal/older.md", "oldest.md"} {
+		path := filepath.Join(notesDir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("# "+rel+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		modTime := now.Add(-time.Duration(i) * time.Hour)
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	notes, err := s.ListRecentNotes(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, note := range notes {
+		names = append(names, filepath.ToSlash(note.Name))
+	}
+	if want := []string{"work/newest.md", "middle.md", "personal/older.md"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("ListRecentNotes(3) = %v, want %v", names, want)
+	}
+
+	// No notes directory yet means no recent notes, not an error
+	missing := NewNotesService(filepath.Join(notesDir, "missing"))
+	if notes, err := missing.ListRecentNotes(3); err != nil || len(notes) != 0 {
+		t.Errorf("ListRecentNotes on a missing directory = %v, %v", notes, err)
+	}
+}
+
+func TestParseAttendees(t *testing.T) {
+	meetingTemplate, err := defaultTemplatesFS.ReadFile("templates/meeting-notes.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		want    []Attendee
+	}{
+		{"default meeting template", string(meetingTemplate), []Attendee{}},
+		{"default meeting template with CRLF", strings.ReplaceAll(string(meetingTemplate), "\n", "\r\n"), []Attendee{}},
+		{
+			"bare names and names with company",
+			"---\nattendees:\n  alice:\n  bob:\n    company: Acme\n    email: bob@example.com\n  carol\n  - dave\n---\nBody\n",
+			[]Attendee{{Name: "alice"}, {Name: "bob", Company: "Acme", Email: "bob@example.com"}, {Name: "carol"}, {Name: "dave"}},
+		},
+		{
+			"blank lines and names that aren't",
+			"---\nattendees:\n  \n  alice:\n\t\n  :\n    company: Nobody Inc\n  - name:\n  erin:\n    company: Initech\ntags: meeting\n---\n",
+			[]Attendee{{Name: "alice"}, {Name: "erin", Company: "Initech"}},
+		},
+		{
+			"list of names",
+			"---\nattendees:\n    - name: alice\n      company: Acme\n    - name: bob\n---\n",
+			[]Attendee{{Name: "alice", Company: "Acme"}, {Name: "bob"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseAttendees(tt.content); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseAttendees = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractTagsFrontMatterForms(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{"inline", "---\ntags: Work, meeting\n---\nBody\n", []string{"meeting", "work"}},
+		{"list", "---\ntitle: Standup\ntags:\n  - Work\n  - meeting\n---\nBody\n", []string{"meeting", "work"}},
+		{"unindented list", "---\ntags:\n- work\n-   'q1'\n---\n", []string{"q1", "work"}},
+		{"list stops at the next key", "---\ntags:\n  - work\n\nkeywords:\n  - golang\n---\n", []string{"work"}},
+		{"list with hashtags", "---\ntags:\n  - work\nattendees:\n  alice:\n---\nPlanning for #launch\n", []string{"launch", "work"}},
+		{"inline with hashtags", "---\ntags: work\n---\nSee #retro\n", []string{"retro", "work"}},
+		{"empty tags", "---\ntags:\nkeywords:\n---\n", []string{}},
+		{"list in a note without frontmatter", "tags:\n- groceries\n", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "note.md")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			got, err := extractTags(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("extractTags = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractTagsSkipsCodeAndURLs(t *testing.T) {
+	content := "---\ntags: work\n---\n# Styles\n\n" +
+		"Decided on the palette, see #design for details.\n\n" +
+		"```css\nbody {\n  color: #333;\n  background: #ffffff;\n}\n```\n\n" +
+		"~~~\n#not-a-tag\n~~~\n\n" +
+		"Use `#fff` for borders, per https://example.com/guide#colors and page#2.\n" +
+		"#launch at the start of a line counts, and so does\t#retro after a tab.\n"
+
+	path := filepath.Join(t.TempDir(), "note.md")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := extractTags(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(got)
+	if want := []string{"design", "launch", "retro", "work"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("extractTags = %v, want %v", got, want)
+	}
+}
+
+func TestGetAllTagsNormalizesCase(t *testing.T) {
+	notesDir := t.TempDir()
+	for name, content := range map[string]string{
+		"hashtag.md":   "Planning for #Work\n",
+		"lower.md":     "---\ntags: work\n---\n",
+		"upper.md":     "---\ntags: WORK\n---\n",
+		"padded.md":    "---\ntags:  Work ,\t#work,\n---\n",
+		"flow.md":      "---\ntags: [Work, Home]\n---\n",
+		"quoted.md":    "---\ntags:\n  - \"#Work\"\n  - ' Home '\n---\n",
+		"crlf.md":      "---\r\ntags: WORK\r\n---\r\nAlso #Work\r\n",
+		"no-fm-key.md": "tags: Work\n",
+	} {
+		if err := os.WriteFile(filepath.Join(notesDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := NewNotesService(notesDir)
+	tags, err := s.GetAllTags()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(tags)
+	if want := []string{"home", "work"}; !reflect.DeepEqual(tags, want) {
+		t.Errorf("GetAllTags = %q, want %q", tags, want)
+	}
+
+	tagged, err := s.FilterByTag(" #Work")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tagged) != 8 {
+		t.Errorf("FilterByTag(#Work) = %d notes, want all 8", len(tagged))
+	}
+}
@@ -2,43 +2,104 @@ package services
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	gohtml "html"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/redjax/notetkr/internal/config"
 	"github.com/yuin/goldmark"
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
+	"github.com/yuin/goldmark/ast"
 	"github.com/yuin/goldmark/extension"
 	"github.com/yuin/goldmark/parser"
 	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
 )
 
+// PreviewOptions controls optional preview rendering features
+type PreviewOptions struct {
+	// AttendeeTable renders the attendees frontmatter as a table at the top of the preview
+	AttendeeTable bool
+
+	// Math renders $...$ and $$...$$ as math typeset by MathJax, which is loaded from a CDN
+	Math bool
+
+	// CodeTheme is the chroma style used to highlight fenced code blocks, e.g. "github" or "monokai"
+	CodeTheme string
+
+	// OutputDir is where previews opened in the browser are written, the system temp directory if empty
+	OutputDir string
+
+	// SkipShortTOC leaves out the table of contents for documents with fewer than MinTOCHeadings headings
+	SkipShortTOC bool
+
+	// StripEmptyHeadings leaves out headings with no text, such as the "# " new notes start with
+	StripEmptyHeadings bool
+
+	// SpellCheck underlines words that aren't in the bundled word list or SpellDictionary
+	SpellCheck bool
+
+	// SpellDictionary is a file of extra words for SpellCheck to accept, one per line
+	SpellDictionary string
+}
+
+// PreviewOptionsFromConfig returns the preview options cfg sets
+func PreviewOptionsFromConfig(cfg *config.Config) PreviewOptions {
+	return PreviewOptions{
+		AttendeeTable:      cfg.PreviewAttendees,
+		CodeTheme:          cfg.PreviewCodeTheme,
+		SkipShortTOC:       cfg.PreviewSkipShortTOC,
+		Math:               cfg.PreviewEnableMath,
+		StripEmptyHeadings: cfg.PreviewStripEmptyHeadings,
+		SpellCheck:         cfg.PreviewSpellCheck,
+		SpellDictionary:    cfg.PreviewDictionary,
+		OutputDir:          cfg.PreviewDir,
+	}
+}
+
+// MinTOCHeadings is how many H1-H3 headings a document needs for a table of contents
+// when PreviewOptions.SkipShortTOC is set
+const MinTOCHeadings = 3
+
+// DefaultCodeTheme is the code highlighting style used when none is configured
+const DefaultCodeTheme = "github"
+
 // PreviewService handles markdown preview functionality
 type PreviewService struct {
 	tempDir string
+	options PreviewOptions
 }
 
-// NewPreviewService creates a new preview service
-func NewPreviewService() *PreviewService {
+// previewMaxAge is how long preview files are kept before being cleaned up
+const previewMaxAge = 24 * time.Hour
+
+// NewPreviewService creates a new preview service that renders with opts
+func NewPreviewService(opts PreviewOptions) *PreviewService {
+	tempDir := opts.OutputDir
+	if tempDir == "" {
+		tempDir = os.TempDir()
+	}
+
 	return &PreviewService{
-		tempDir: os.TempDir(),
+		tempDir: tempDir,
+		options: opts,
 	}
 }
 
 // PreviewMarkdown converts markdown to HTML and opens it in the default browser
 func (p *PreviewService) PreviewMarkdown(markdownPath, content string) error {
-	// Convert markdown to HTML
-	htmlContent, err := p.markdownToHTML(content, markdownPath)
+	tempFile, err := p.writePreview(markdownPath, content)
 	if err != nil {
-		return fmt.Errorf("failed to convert markdown: %w", err)
-	}
-
-	// Create temporary HTML file
-	tempFile := filepath.Join(p.tempDir, "notetkr-preview.html")
-	if err := os.WriteFile(tempFile, []byte(htmlContent), 0644); err != nil {
-		return fmt.Errorf("failed to write temp file: %w", err)
+		return err
 	}
 
 	// Open in default browser
@@ -49,19 +110,116 @@ func (p *PreviewService) PreviewMarkdown(markdownPath, content string) error {
 	return nil
 }
 
+// PreviewMarkdownToFile converts markdown to the styled preview HTML and writes it to outputPath
+func (p *PreviewService) PreviewMarkdownToFile(markdownPath, content, outputPath string) error {
+	if sameFile(markdownPath, outputPath) {
+		return fmt.Errorf("the HTML output would overwrite %s", markdownPath)
+	}
+
+	htmlContent, err := p.markdownToHTML(content, markdownPath)
+	if err != nil {
+		return fmt.Errorf("failed to convert markdown: %w", err)
+	}
+
+	if dir := filepath.Dir(outputPath); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(outputPath, []byte(htmlContent), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outputPath, err)
+	}
+
+	return nil
+}
+
+// sameFile reports whether two paths name the same file, directly or through a link
+func sameFile(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	if errA == nil && errB == nil && absA == absB {
+		return true
+	}
+
+	infoA, errA := os.Stat(a)
+	infoB, errB := os.Stat(b)
+	return errA == nil && errB == nil && os.SameFile(infoA, infoB)
+}
+
+// writePreview writes a note's preview to its own file in the preview directory, so
+// previews don't overwrite each other, and removes stale previews of other notes
+func (p *PreviewService) writePreview(markdownPath, content string) (string, error) {
+	previewFile := p.previewFilePath(markdownPath)
+	if err := p.PreviewMarkdownToFile(markdownPath, content, previewFile); err != nil {
+		return "", err
+	}
+
+	p.cleanOldPreviews(previewMaxAge)
+	return previewFile, nil
+}
+
+// cleanOldPreviews removes preview files not written to within maxAge. Failures are
+// ignored since a leftover preview file is harmless.
+func (p *PreviewService) cleanOldPreviews(maxAge time.Duration) {
+	matches, err := filepath.Glob(filepath.Join(p.tempDir, "notetkr-preview*.html"))
+	if err != nil {
+		return
+	}
+
+	cutoff := time.Now().Add(-maxAge)
+	for _, path := range matches {
+		if info, err := os.Stat(path); err == nil && info.ModTime().Before(cutoff) {
+			_ = os.Remove(path)
+		}
+	}
+}
+
+// previewFilePath returns the temp file a note's preview is written to, named from
+// a hash of the note's path so it's stable per note
+func (p *PreviewService) previewFilePath(markdownPath string) string {
+	if abs, err := filepath.Abs(markdownPath); err == nil {
+		markdownPath = abs
+	}
+	sum := sha256.Sum256([]byte(markdownPath))
+	return filepath.Join(p.tempDir, fmt.Sprintf("notetkr-preview-%s.html", hex.EncodeToString(sum[:])[:12]))
+}
+
+// RenderHTML converts a note's markdown to the same styled HTML document used for previews
+func (p *PreviewService) RenderHTML(markdownPath, content string) (string, error) {
+	htmlContent, err := p.markdownToHTML(content, markdownPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert markdown: %w", err)
+	}
+	return htmlContent, nil
+}
+
 // markdownToHTML converts markdown content to styled HTML
 func (p *PreviewService) markdownToHTML(markdown, sourcePath string) (string, error) {
+	// Render attendees from the front matter before it gets stripped
+	var attendeesHTML string
+	if p.options.AttendeeTable {
+		attendeesHTML = renderAttendeesTable(parseAttendees(markdown))
+	}
+
 	// Strip YAML front matter if present
 	stripped := p.stripFrontMatter(markdown)
 
 	// Configure goldmark with extensions
-	md := goldmark.New(
-		goldmark.WithExtensions(
-			extension.GFM,   // GitHub Flavored Markdown
-			extension.Table, // Tables
-			extension.Strikethrough,
-			extension.TaskList, // - [ ] checkboxes
+	extensions := []goldmark.Extender{
+		extension.GFM,   // GitHub Flavored Markdown
+		extension.Table, // Tables
+		extension.Strikethrough,
+		extension.TaskList, // - [ ] checkboxes
+		highlighting.NewHighlighting( // Fenced code block highlighting, styled by codeThemeCSS
+			highlighting.WithFormatOptions(chromahtml.WithClasses(true)),
 		),
+	}
+	if p.options.Math {
+		extensions = append(extensions, mathExtension{})
+	}
+
+	md := goldmark.New(
+		goldmark.WithExtensions(extensions...),
 		goldmark.WithParserOptions(
 			parser.WithAutoHeadingID(), // Auto-generate heading IDs
 		),
@@ -71,33 +229,208 @@ func (p *PreviewService) markdownToHTML(markdown, sourcePath string) (string, er
 		),
 	)
 
+	// Parse first so the table of contents can use the generated heading IDs
+	source := []byte(stripped)
+	doc := md.Parser().Parse(text.NewReader(source))
+	if p.options.StripEmptyHeadings {
+		removeEmptyHeadings(doc, source)
+	}
+	headings := collectTOCHeadings(doc, source)
+
 	// Convert markdown to HTML
 	var buf bytes.Buffer
-	if err := md.Convert([]byte(stripped), &buf); err != nil {
+	buf.WriteString(attendeesHTML)
+	if err := md.Renderer().Render(&buf, source, doc); err != nil {
 		return "", err
 	}
 
+	body := buf.String()
+	if p.options.SpellCheck {
+		// A missing dictionary file was reported at startup, so check with the bundled words
+		checker, _ := NewSpellChecker(p.options.SpellDictionary)
+		body = checker.MarkMisspelled(body)
+	}
+
+	var toc string
+	if !p.options.SkipShortTOC || len(headings) >= MinTOCHeadings {
+		toc = renderTOC(headings)
+	}
+
 	// Get the directory of the source file for relative image paths
 	sourceDir := filepath.Dir(sourcePath)
 
 	// Wrap in full HTML document with styling
-	html := p.wrapHTML(buf.String(), filepath.Base(sourcePath), sourceDir)
+	html := p.wrapHTML(body, toc, filepath.Base(sourcePath), sourceDir)
 	return html, nil
 }
 
-// stripFrontMatter removes YAML front matter from markdown content
+// CheckCodeTheme verifies a code highlighting theme name is a known chroma style
+func CheckCodeTheme(theme string) error {
+	if _, ok := styles.Registry[theme]; !ok {
+		return fmt.Errorf("unknown code theme %q (try github, monokai, dracula or solarized-light)", theme)
+	}
+	return nil
+}
+
+// tocHeading is an H1-H3 heading listed in the table of contents
+type tocHeading struct {
+	Level int
+	ID    string
+	Text  string
+}
+
+// collectTOCHeadings lists the document's H1-H3 headings that have an ID
+func collectTOCHeadings(doc ast.Node, source []byte) []tocHeading {
+	var headings []tocHeading
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		heading, ok := n.(*ast.Heading)
+		if !entering || !ok {
+			return ast.WalkContinue, nil
+		}
+		if heading.Level <= 3 {
+			if id, ok := heading.AttributeString("id"); ok {
+				if idBytes, ok := id.([]byte); ok {
+					headings = append(headings, tocHeading{
+						Level: heading.Level,
+						ID:    string(idBytes),
+						Text:  nodeText(heading, source),
+					})
+				}
+			}
+		}
+		return ast.WalkSkipChildren, nil
+	})
+	return headings
+}
+
+// removeEmptyHeadings deletes headings with no text, e.g. a lone "# ", from doc
+func removeEmptyHeadings(doc ast.Node, source []byte) {
+	var empty []ast.Node
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		heading, ok := n.(*ast.Heading)
+		if !entering || !ok {
+			return ast.WalkContinue, nil
+		}
+		// Check the heading's source rather than its text, so images and math count
+		var raw strings.Builder
+		lines := heading.Lines()
+		for i := 0; i < lines.Len(); i++ {
+			segment := lines.At(i)
+			raw.Write(segment.Value(source))
+		}
+		if strings.TrimSpace(raw.String()) == "" {
+			empty = append(empty, heading)
+		}
+		return ast.WalkSkipChildren, nil
+	})
+
+	for _, heading := range empty {
+		heading.Parent().RemoveChild(heading.Parent(), heading)
+	}
+}
+
+// nodeText returns the plain text inside an inline node, without markup
+func nodeText(n ast.Node, source []byte) string {
+	var b strings.Builder
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		switch t := c.(type) {
+		case *ast.Text:
+			b.Write(t.Segment.Value(source))
+			if t.SoftLineBreak() || t.HardLineBreak() {
+				b.WriteString(" ")
+			}
+		case *ast.String:
+			b.Write(t.Value)
+		default:
+			b.WriteString(nodeText(c, source))
+		}
+	}
+	return b.String()
+}
+
+// renderTOC renders headings as a nav of anchor links. Returns an empty string when there are none.
+func renderTOC(headings []tocHeading) string {
+	if len(headings) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("<nav class=\"toc\">\n<div class=\"toc-title\">Contents</div>\n<ul>\n")
+	for _, h := range headings {
+		fmt.Fprintf(&b, "<li class=\"toc-h%d\"><a href=\"#%s\">%s</a></li>\n",
+			h.Level, gohtml.EscapeString(h.ID), gohtml.EscapeString(h.Text))
+	}
+	b.WriteString("</ul>\n</nav>\n")
+	return b.String()
+}
+
+// codeThemeCSS returns the stylesheet for highlighted code blocks. Themes with a
+// "-dark" counterpart (e.g. github-dark) use it when the browser prefers dark mode.
+func (p *PreviewService) codeThemeCSS() string {
+	theme := p.options.CodeTheme
+	if theme == "" {
+		theme = DefaultCodeTheme
+	}
+
+	formatter := chromahtml.New(chromahtml.WithClasses(true))
+
+	var buf bytes.Buffer
+	if err := formatter.WriteCSS(&buf, styles.Get(theme)); err != nil {
+		return ""
+	}
+
+	if dark, ok := styles.Registry[theme+"-dark"]; ok {
+		buf.WriteString("@media (prefers-color-scheme: dark) {\n")
+		if err := formatter.WriteCSS(&buf, dark); err != nil {
+			return ""
+		}
+		buf.WriteString("}\n")
+	}
+
+	return buf.String()
+}
+
+// renderAttendeesTable renders attendees as an HTML table (name/company/email).
+// Returns an empty string when there are no attendees.
+func renderAttendeesTable(attendees []Attendee) string {
+	if len(attendees) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<table class=\"attendees\">\n<thead>\n<tr><th>Name</th><th>Company</th><th>Email</th></tr>\n</thead>\n<tbody>\n")
+	for _, a := range attendees {
+		email := gohtml.EscapeString(a.Email)
+		if email != "" {
+			email = fmt.Sprintf("<a href=\"mailto:%s\">%s</a>", email, email)
+		}
+		sb.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%s</td><td>%s</td></tr>\n",
+			gohtml.EscapeString(a.Name), gohtml.EscapeString(a.Company), email))
+	}
+	sb.WriteString("</tbody>\n</table>\n")
+
+	return sb.String()
+}
+
+// stripFrontMatter removes front matter before rendering (see StripFrontMatter)
 func (p *PreviewService) stripFrontMatter(content string) string {
+	return StripFrontMatter(content)
+}
+
+// StripFrontMatter removes YAML (---) or TOML (+++) front matter from markdown content.
+// Content that doesn't start with a recognised front matter block is returned intact.
+func StripFrontMatter(content string) string {
 	lines := strings.Split(content, "\n")
 	if len(lines) == 0 {
 		return content
 	}
 
-	// Check if first line is "---" (YAML front matter delimiter)
+	// Check if first line is a front matter fence ("---" for YAML, "+++" for TOML)
 	firstLine := strings.TrimSpace(lines[0])
-	if firstLine == "---" {
-		// Find the closing "---"
+	if firstLine == "---" || firstLine == "+++" {
+		// Find the matching closing fence
 		for i := 1; i < len(lines); i++ {
-			if strings.TrimSpace(lines[i]) == "---" {
+			if strings.TrimSpace(lines[i]) == firstLine {
 				// Found closing delimiter, return everything after it
 				if i+1 < len(lines) {
 					return strings.Join(lines[i+1:], "\n")
@@ -147,11 +480,17 @@ func (p *PreviewService) stripFrontMatter(content string) string {
 	return content
 }
 
-// wrapHTML wraps the markdown HTML in a complete HTML document with styling
-func (p *PreviewService) wrapHTML(content, title, baseDir string) string {
+// wrapHTML wraps the markdown HTML and its table of contents (if any) in a complete HTML document with styling
+func (p *PreviewService) wrapHTML(content, toc, title, baseDir string) string {
 	// Convert baseDir to file:// URL for proper image loading
 	basePath := filepath.ToSlash(baseDir)
 
+	// Only load MathJax when math is enabled, so previews otherwise work offline
+	mathScript := ""
+	if p.options.Math {
+		mathScript = mathJaxScript
+	}
+
 	return fmt.Sprintf(`<!DOCTYPE html>
 <html>
 <head>
@@ -240,6 +579,10 @@ func (p *PreviewService) wrapHTML(content, title, baseDir string) string {
             font-family: ui-monospace, SFMono-Regular, "SF Mono", Menlo, Consolas, monospace;
         }
         
+        .misspelled {
+            text-decoration: underline dotted red;
+        }
+        
         pre {
             padding: 16px;
             overflow: auto;
@@ -331,12 +674,67 @@ func (p *PreviewService) wrapHTML(content, title, baseDir string) string {
         del {
             text-decoration: line-through;
         }
+        
+        /* Table of contents */
+        nav.toc {
+            border: 1px solid var(--border-color);
+            border-radius: 6px;
+            background-color: var(--code-bg);
+            padding: 12px 16px;
+            margin-bottom: 24px;
+            font-size: 14px;
+        }
+        
+        nav.toc .toc-title {
+            font-weight: 600;
+            margin-bottom: 8px;
+        }
+        
+        nav.toc ul {
+            list-style: none;
+            padding-left: 0;
+            margin: 0;
+        }
+        
+        nav.toc li.toc-h2 {
+            padding-left: 1em;
+        }
+        
+        nav.toc li.toc-h3 {
+            padding-left: 2em;
+        }
+        
+        nav.toc a {
+            color: var(--link-color);
+            text-decoration: none;
+        }
+        
+        nav.toc a:hover {
+            text-decoration: underline;
+        }
+        
+        /* Pin the contents beside the page when there's room */
+        @media (min-width: 1500px) {
+            nav.toc {
+                position: fixed;
+                top: 45px;
+                left: 24px;
+                width: 220px;
+                max-height: calc(100vh - 90px);
+                overflow-y: auto;
+                margin-bottom: 0;
+            }
+        }
     </style>
+    <style>
+%s
+    </style>
+%s
 </head>
 <body>
-%s
+%s%s
 </body>
-</html>`, title, basePath, content)
+</html>`, title, basePath, p.codeThemeCSS(), mathScript, toc, content)
 }
 
 // openInBrowser opens the file in the default browser using OS-specific commands
@@ -0,0 +1,279 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStripFrontMatterTOMLFence(t *testing.T) {
+	p := NewPreviewService(PreviewOptions{})
+	content := "+++\ntitle = \"Hugo post\"\ntags = [\"go\"]\n+++\n\n# Heading\n\nBody text\n"
+
+	got := p.stripFrontMatter(content)
+	want := "\n# Heading\n\nBody text\n"
+	if got != want {
+		t.Errorf("stripFrontMatter() = %q, want %q", got, want)
+	}
+}
+
+func TestStripFrontMatterPlainDocument(t *testing.T) {
+	p := NewPreviewService(PreviewOptions{})
+	content := "# Plain note\n\ntags are mentioned here but this is not front matter\n---\n\nMore text\n"
+
+	if got := p.stripFrontMatter(content); got != content {
+		t.Errorf("stripFrontMatter() altered a document without front matter: %q", got)
+	}
+}
+
+func TestMarkdownToHTMLAttendeeTable(t *testing.T) {
+	content := "---\ntags: [meeting]\nattendees:\n  jane doe:\n    company: acme\n    email: jane@example.com\n  john smith:\n---\n\n# Standup\n"
+
+	p := NewPreviewService(PreviewOptions{})
+	p.options.AttendeeTable = true
+
+	got, err := p.markdownToHTML(content, "/notes/standup.md")
+	if err != nil {
+		t.Fatalf("markdownToHTML returned error: %v", err)
+	}
+
+	for _, want := range []string{
+		`<table class="attendees">`,
+		"<td>jane doe</td><td>acme</td>",
+		`<a href="mailto:jane@example.com">jane@example.com</a>`,
+		"<td>john smith</td>",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("preview HTML missing %q", want)
+		}
+	}
+
+	// Disabled by default
+	p.options.AttendeeTable = false
+	got, err = p.markdownToHTML(content, "/notes/standup.md")
+	if err != nil {
+		t.Fatalf("markdownToHTML returned error: %v", err)
+	}
+	if strings.Contains(got, `class="attendees"`) {
+		t.Error("attendee table rendered while the option is disabled")
+	}
+}
+
+func TestPreviewMarkdownToFileAndPerNotePaths(t *testing.T) {
+	dir := t.TempDir()
+	p := NewPreviewService(PreviewOptions{})
+	p.tempDir = dir
+
+	out := filepath.Join(dir, "site", "standup.html")
+	if err := p.PreviewMarkdownToFile("/notes/standup.md", "# Standup\n\nNotes\n", out); err != nil {
+		t.Fatalf("PreviewMarkdownToFile returned error: %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "<h1 id=\"standup\">Standup</h1>") {
+		t.Errorf("output file missing rendered heading:\n%s", data)
+	}
+
+	first := p.previewFilePath("/notes/a.md")
+	if first != p.previewFilePath("/notes/a.md") {
+		t.Error("preview path should be stable for the same note")
+	}
+	if first == p.previewFilePath("/notes/b.md") {
+		t.Error("different notes should get different preview paths")
+	}
+	if filepath.Dir(first) != dir {
+		t.Errorf("preview path %s should be in the temp directory", first)
+	}
+}
+
+func TestPreviewMarkdownToFileKeepsInput(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "page.html")
+	if err := os.WriteFile(input, []byte("<p>original</p>\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	p := NewPreviewService(PreviewOptions{})
+	for _, out := range []string{input, filepath.Join(dir, ".", "page.html")} {
+		if err := p.PreviewMarkdownToFile(input, "# Page\n", out); err == nil {
+			t.Errorf("writing the preview to %s should fail", out)
+		}
+	}
+	if data, _ := os.ReadFile(input); string(data) != "<p>original</p>\n" {
+		t.Errorf("input was overwritten with %q", data)
+	}
+}
+
+func TestMarkdownToHTMLHighlightsCodeBlocks(t *testing.T) {
+	content := "# Code\n\n```go\nfunc main() {}\n```\n\n```\nplain block <tag>\n```\n"
+
+	p := NewPreviewService(PreviewOptions{})
+	p.options.CodeTheme = "github"
+
+	got, err := p.markdownToHTML(content, "/notes/code.md")
+	if err != nil {
+		t.Fatalf("markdownToHTML returned error: %v", err)
+	}
+
+	for _, want := range []string{
+		`<pre class="chroma">`,
+		`<span class="kd">func</span>`, // Go keyword highlighted
+		"plain block &lt;tag&gt;",      // Block without a language still rendered and escaped
+		"@media (prefers-color-scheme: dark)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("preview HTML missing %q", want)
+		}
+	}
+
+	if err := CheckCodeTheme("monokai"); err != nil {
+		t.Errorf("CheckCodeTheme(monokai) = %v", err)
+	}
+	if err := CheckCodeTheme("no-such-theme"); err == nil {
+		t.Error("expected an error for an unknown code theme")
+	}
+}
+
+func TestMarkdownToHTMLTableOfContents(t *testing.T) {
+	content := "# Project *Plan*\n\n## Goals\n\n### Q1 & Q2\n\n#### Too deep\n\nBody\n"
+
+	p := NewPreviewService(PreviewOptions{})
+	got, err := p.markdownToHTML(content, "/notes/plan.md")
+	if err != nil {
+		t.Fatalf("markdownToHTML returned error: %v", err)
+	}
+
+	for _, want := range []string{
+		`<nav class="toc">`,
+		`<li class="toc-h1"><a href="#project-plan">Project Plan</a></li>`,
+		`<li class="toc-h2"><a href="#goals">Goals</a></li>`,
+		`<li class="toc-h3"><a href="#q1--q2">Q1 &amp; Q2</a></li>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("preview HTML missing %q", want)
+		}
+	}
+	if strings.Contains(got, "Too deep</a>") {
+		t.Error("H4 headings should not be listed in the table of contents")
+	}
+
+	// Short documents skip the table of contents when the option is set
+	p.options.SkipShortTOC = true
+	got, err = p.markdownToHTML("# Title\n\n## Only section\n", "/notes/short.md")
+	if err != nil {
+		t.Fatalf("markdownToHTML returned error: %v", err)
+	}
+	if strings.Contains(got, `<nav class="toc">`) {
+		t.Error("table of contents rendered for a document with fewer than 3 headings")
+	}
+}
+
+func TestMarkdownToHTMLMath(t *testing.T) {
+	content := "Energy $E = mc^2$ costs $5 and $10.\n\n$$\n\\sum_{i=1}^n i < n^2\n$$\n\nCode `$HOME` stays.\n\n```sh\necho $PATH$\n```\n"
+
+	p := NewPreviewService(PreviewOptions{})
+	got, err := p.markdownToHTML(content, "/notes/math.md")
+	if err != nil {
+		t.Fatalf("markdownToHTML returned error: %v", err)
+	}
+	if strings.Contains(got, "MathJax") || strings.Contains(got, `class="math`) {
+		t.Error("math rendered with the option off")
+	}
+
+	p.options.Math = true
+	got, err = p.markdownToHTML(content, "/notes/math.md")
+	if err != nil {
+		t.Fatalf("markdownToHTML returned error: %v", err)
+	}
+
+	for _, want := range []string{
+		mathJaxScript,
+		`<span class="math inline">\(E = mc^2\)</span>`,
+		"costs $5 and $10.",
+		`<div class="math display">\[\sum_{i=1}^n i &lt; n^2`,
+		"<code>$HOME</code>",
+		`<span class="nv">$PATH</span>$`, // Fenced code is highlighted, not typeset
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("preview HTML missing %q", want)
+		}
+	}
+	if strings.Count(got, `class="math`) != 2 {
+		t.Errorf("expected exactly 2 math elements, got %d", strings.Count(got, `class="math`))
+	}
+}
+
+func TestPreviewsUseConfiguredDirPerNote(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "previews")
+	p := NewPreviewService(PreviewOptions{OutputDir: dir})
+
+	// A stale preview from an earlier session is cleaned up
+	stale := filepath.Join(dir, "notetkr-preview-stale.html")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(stale, []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * previewMaxAge)
+	if err := os.Chtimes(stale, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := p.writePreview("/notes/first.md", "# First\n")
+	if err != nil {
+		t.Fatalf("writePreview returned error: %v", err)
+	}
+	second, err := p.writePreview("/notes/second.md", "# Second\n")
+	if err != nil {
+		t.Fatalf("writePreview returned error: %v", err)
+	}
+
+	if first == second {
+		t.Fatal("two notes should get distinct preview files")
+	}
+	for path, want := range map[string]string{first: "First</h1>", second: "Second</h1>"} {
+		if filepath.Dir(path) != dir {
+			t.Errorf("preview %s should be in %s", path, dir)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil || !strings.Contains(string(data), want) {
+			t.Errorf("preview %s missing %q: %v", path, want, err)
+		}
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Error("stale preview file should have been removed")
+	}
+}
+
+func TestMarkdownToHTMLStripsEmptyHeadings(t *testing.T) {
+	content := defaultNoteFrontMatter + "# \n\nBody text\n\n##\n\n## Real heading\n\n# ![logo](logo.png)\n"
+
+	p := NewPreviewService(PreviewOptions{})
+	got, err := p.markdownToHTML(content, "/notes/new.md")
+	if err != nil {
+		t.Fatalf("markdownToHTML returned error: %v", err)
+	}
+	if !strings.Contains(got, "<h1 id=\"heading\"></h1>") {
+		t.Error("empty heading removed with the option off")
+	}
+
+	p.options.StripEmptyHeadings = true
+	got, err = p.markdownToHTML(content, "/notes/new.md")
+	if err != nil {
+		t.Fatalf("markdownToHTML returned error: %v", err)
+	}
+	if emptyHeading := regexp.MustCompile(`<h[1-6][^>]*></h[1-6]>`); emptyHeading.MatchString(got) {
+		t.Errorf("empty heading left in the preview: %s", emptyHeading.FindString(got))
+	}
+	for _, want := range []string{"<p>Body text</p>", ">Real heading</h2>", `<img src="logo.png" alt="logo"`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("preview HTML missing %q", want)
+		}
+	}
+}
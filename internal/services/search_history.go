@@ -0,0 +1,83 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultSearchHistorySize is how many past search queries are kept
+const DefaultSearchHistorySize = 50
+
+// SearchHistory is a list of past search queries, oldest first, without duplicates.
+// It's saved to a file with one query per line if it has a path.
+type SearchHistory struct {
+	path    string
+	size    int
+	queries []string
+}
+
+// LoadSearchHistory reads the history file at path, keeping at most size queries.
+// A missing file gives an empty history; an empty path keeps the history in memory only.
+func LoadSearchHistory(path string, size int) (*SearchHistory, error) {
+	h := &SearchHistory{path: path, size: size}
+	if path == "" {
+		return h, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return h, nil
+		}
+		return h, fmt.Errorf("failed to read search history: %w", err)
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		h.add(line)
+	}
+	return h, nil
+}
+
+// Queries returns the saved queries, oldest first
+func (h *SearchHistory) Queries() []string {
+	return h.queries
+}
+
+// Add records a query as the most recent, moving it to the end if it was already
+// in the history, and saves the history file
+func (h *SearchHistory) Add(query string) error {
+	if !h.add(query) || h.path == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(h.path), 0755); err != nil {
+		return fmt.Errorf("failed to create search history directory: %w", err)
+	}
+	if err := os.WriteFile(h.path, []byte(strings.Join(h.queries, "\n")+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to save search history: %w", err)
+	}
+	return nil
+}
+
+// add records a query in memory, reporting whether it was recorded
+func (h *SearchHistory) add(query string) bool {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return false
+	}
+
+	for i, q := range h.queries {
+		if q == query {
+			h.queries = append(h.queries[:i], h.queries[i+1:]...)
+			break
+		}
+	}
+	h.queries = append(h.queries, query)
+
+	if h.size > 0 && len(h.queries) > h.size {
+		h.queries = h.queries[len(h.queries)-h.size:]
+	}
+	return true
+}
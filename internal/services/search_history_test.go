@@ -0,0 +1,35 @@
+package services
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSearchHistoryDeduplicatesAndPersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "search_history")
+
+	h, err := LoadSearchHistory(path, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, q := range []string{"alpha", "beta", "  ", "alpha", "gamma", "delta"} {
+		if err := h.Add(q); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// alpha moved to the end when repeated, blank skipped, oldest dropped past 3
+	want := []string{"alpha", "gamma", "delta"}
+	if got := h.Queries(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Queries() = %v, want %v", got, want)
+	}
+
+	reloaded, err := LoadSearchHistory(path, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := reloaded.Queries(); !reflect.DeepEqual(got, want) {
+		t.Errorf("reloaded Queries() = %v, want %v", got, want)
+	}
+}
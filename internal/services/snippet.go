@@ -0,0 +1,214 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// SnippetWidth is roughly how many characters a search result preview shows
+const SnippetWidth = 80
+
+// Snippet is the line a search matched, trimmed around the match.
+// Start and End are byte offsets of the match within Text.
+type Snippet struct {
+	Text  string
+	Start int
+	End   int
+}
+
+// Before returns the text preceding the match
+func (s Snippet) Before() string { return s.Text[:s.Start] }
+
+// Match returns the matched text
+func (s Snippet) Match() string { return s.Text[s.Start:s.End] }
+
+// After returns the text following the match
+func (s Snippet) After() string { return s.Text[s.End:] }
+
+// FindMatchSnippet returns the first line of content matching query (using the same
+// case and regex rules as note search), trimmed to about width characters around the
+// match. ok is false if no line matches or the pattern is invalid.
+func FindMatchSnippet(content, query string, opts SearchOptions, width int) (Snippet, bool) {
+	if query == "" {
+		return Snippet{}, false
+	}
+
+	re, err := compileSearchPattern(query, opts)
+	if err != nil {
+		return Snippet{}, false
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		loc := re.FindStringIndex(line)
+		// Skip empty matches (e.g. a regex like "x*") that don't point at anything
+		if loc == nil || loc[0] == loc[1] {
+			continue
+		}
+		return trimSnippet(strings.TrimRight(line, "\r"), loc[0], loc[1], width), true
+	}
+
+	return Snippet{}, false
+}
+
+// LineMatch is a line of a document that matched a search
+type LineMatch struct {
+	Line int // 1-based line number
+	Text string
+}
+
+// SearchLines returns every line of content matching query (using the same case and
+// regex rules as note search) with its line number. Returns an error if opts.Regex is
+// set and the query isn't a valid pattern.
+func SearchLines(content, query string, opts SearchOptions) ([]LineMatch, error) {
+	if query == "" {
+		return nil, nil
+	}
+
+	re, err := compileSearchPattern(query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []LineMatch
+	for i, line := range strings.Split(NormalizeLineEndings(content), "\n") {
+		if loc := re.FindStringIndex(line); loc != nil && loc[0] != loc[1] {
+			matches = append(matches, LineMatch{Line: i + 1, Text: line})
+		}
+	}
+	return matches, nil
+}
+
+// ReplaceMatches replaces matches of query in content with replacement, using the same
+// case and regex rules as SearchLines. If line is a zero-based line number only the
+// first match on that line is replaced, and with line < 0 every match is. In regex
+// mode replacement may refer to groups as $1. Returns the new content and how many
+// matches were replaced.
+func ReplaceMatches(content, query, replacement string, opts SearchOptions, line int) (string, int, error) {
+	if query == "" {
+		return content, 0, nil
+	}
+
+	re, err := compileSearchPattern(query, opts)
+	if err != nil {
+		return content, 0, err
+	}
+
+	replace := func(text string, limit int) (string, int) {
+		count := 0
+		var b strings.Builder
+		last := 0
+		for _, loc := range re.FindAllStringSubmatchIndex(text, limit) {
+			if loc[0] == loc[1] {
+				continue // Empty matches would insert the replacement between every character
+			}
+			b.WriteString(text[last:loc[0]])
+			if opts.Regex {
+				b.Write(re.ExpandString(nil, replacement, text, loc))
+			} else {
+				b.WriteString(replacement)
+			}
+			last = loc[1]
+			count++
+		}
+		b.WriteString(text[last:])
+		return b.String(), count
+	}
+
+	lines := strings.Split(NormalizeLineEndings(content), "\n")
+	total := 0
+	for i := range lines {
+		if line >= 0 && i != line {
+			continue
+		}
+		limit := -1
+		if line >= 0 {
+			limit = 1
+		}
+		var count int
+		lines[i], count = replace(lines[i], limit)
+		total += count
+	}
+
+	return strings.Join(lines, "\n"), total, nil
+}
+
+// NewSearchMatcher builds the function used to test each searched field against query.
+// Returns an error if opts.Regex is set and the query isn't a valid pattern.
+func NewSearchMatcher(query string, opts SearchOptions) (func(string) bool, error) {
+	re, err := compileSearchPattern(query, opts)
+	if err != nil {
+		return nil, err
+	}
+	return re.MatchString, nil
+}
+
+// compileSearchPattern turns a search query into a regexp, quoting it unless opts.Regex is set
+func compileSearchPattern(query string, opts SearchOptions) (*regexp.Regexp, error) {
+	pattern := query
+	if !opts.Regex {
+		pattern = regexp.QuoteMeta(query)
+	}
+	if !opts.CaseSensitive {
+		pattern = "(?i)" + pattern
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid search pattern %q: %w", query, err)
+	}
+	return re, nil
+}
+
+// trimSnippet cuts line down to about width runes, keeping the match at [start, end)
+// in view and marking cut ends with "…"
+func trimSnippet(line string, start, end, width int) Snippet {
+	// Drop leading indentation so the match isn't pushed out of view
+	trimmed := strings.TrimLeft(line, " \t")
+	if cut := len(line) - len(trimmed); cut <= start {
+		line, start, end = trimmed, start-cut, end-cut
+	}
+
+	runes := []rune(line)
+	if len(runes) <= width {
+		return Snippet{Text: line, Start: start, End: end}
+	}
+
+	// Work in runes so multi-byte characters aren't split
+	runeStart := len([]rune(line[:start]))
+	runeEnd := runeStart + len([]rune(line[start:end]))
+
+	from := runeStart - width/4
+	if from < 0 {
+		from = 0
+	}
+	to := from + width
+	if to < runeEnd {
+		to = runeEnd
+	}
+	if to > len(runes) {
+		to = len(runes)
+		if from = to - width; from > runeStart {
+			from = runeStart
+		}
+		if from < 0 {
+			from = 0
+		}
+	}
+
+	prefix, suffix := "", ""
+	if from > 0 {
+		prefix = "…"
+	}
+	if to < len(runes) {
+		suffix = "…"
+	}
+
+	before := prefix + string(runes[from:runeStart])
+	match := string(runes[runeStart:runeEnd])
+	return Snippet{
+		Text:  before + match + string(runes[runeEnd:to]) + suffix,
+		Start: len(before),
+		End:   len(before) + len(match),
+	}
+}
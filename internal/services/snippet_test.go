@@ -0,0 +1,82 @@
+package services
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFindMatchSnippet(t *testing.T) {
+	content := "# Standup\n\n  - Discussed the Billing migration\n- billing again\n"
+
+	snippet, ok := FindMatchSnippet(content, "billing", SearchOptions{}, SnippetWidth)
+	if !ok {
+		t.Fatal("expected a match")
+	}
+	if snippet.Text != "- Discussed the Billing migration" {
+		t.Errorf("Text = %q, want the first matching line without indentation", snippet.Text)
+	}
+	if snippet.Match() != "Billing" {
+		t.Errorf("Match() = %q, want %q", snippet.Match(), "Billing")
+	}
+
+	snippet, ok = FindMatchSnippet(content, "billing", SearchOptions{CaseSensitive: true}, SnippetWidth)
+	if !ok || snippet.Text != "- billing again" {
+		t.Errorf("case-sensitive snippet = %q, want the lowercase line", snippet.Text)
+	}
+
+	if _, ok := FindMatchSnippet(content, "payroll", SearchOptions{}, SnippetWidth); ok {
+		t.Error("a query that isn't in the content should not match")
+	}
+}
+
+func TestFindMatchSnippetTrimsLongLines(t *testing.T) {
+	line := strings.Repeat("lorem ipsum ", 20) + "NEEDLE" + strings.Repeat(" dolor sit", 20)
+
+	snippet, ok := FindMatchSnippet(line, "needle", SearchOptions{}, 40)
+	if !ok {
+		t.Fatal("expected a match")
+	}
+	if n := len([]rune(snippet.Text)); n > 42 {
+		t.Errorf("snippet is %d runes, want about 40", n)
+	}
+	if !strings.HasPrefix(snippet.Text, "…") || !strings.HasSuffix(snippet.Text, "…") {
+		t.Errorf("trimmed snippet should be marked at both ends, got %q", snippet.Text)
+	}
+	if snippet.Match() != "NEEDLE" {
+		t.Errorf("Match() = %q after trimming, want NEEDLE", snippet.Match())
+	}
+}
+
+func TestReplaceMatches(t *testing.T) {
+	content := "Foo and foo\nno match\nfoo again"
+
+	tests := []struct {
+		name        string
+		query       string
+		replacement string
+		opts        SearchOptions
+		line        int
+		want        string
+		count       int
+	}{
+		{"all", "foo", "bar", SearchOptions{}, -1, "bar and bar\nno match\nbar again", 3},
+		{"first on line", "foo", "bar", SearchOptions{}, 0, "bar and foo\nno match\nfoo again", 1},
+		{"line without a match", "foo", "bar", SearchOptions{}, 1, content, 0},
+		{"case sensitive", "foo", "bar", SearchOptions{CaseSensitive: true}, -1, "Foo and bar\nno match\nbar again", 2},
+		{"literal dollar", "again", "$1", SearchOptions{}, -1, "Foo and foo\nno match\nfoo $1", 1},
+		{"regex groups", `(\w+) again`, "again $1", SearchOptions{Regex: true}, -1, "Foo and foo\nno match\nagain foo", 1},
+	}
+	for _, tt := range tests {
+		got, count, err := ReplaceMatches(content, tt.query, tt.replacement, tt.opts, tt.line)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got != tt.want || count != tt.count {
+			t.Errorf("%s: got %q (%d), want %q (%d)", tt.name, got, count, tt.want, tt.count)
+		}
+	}
+
+	if _, _, err := ReplaceMatches(content, "(", "x", SearchOptions{Regex: true}, -1); err == nil {
+		t.Error("expected an error for an invalid pattern")
+	}
+}
@@ -0,0 +1,239 @@
+package services
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// bundledWords is the built-in English word list, one lowercase word per line
+//
+//go:embed dictionary/words.txt
+var bundledWords string
+
+var (
+	bundledDictionary     map[string]bool
+	bundledDictionaryOnce sync.Once
+)
+
+// SpellChecker finds words missing from the bundled word list and a user's own words
+type SpellChecker struct {
+	custom map[string]bool
+}
+
+// NewSpellChecker creates a spell checker that also accepts the words in the
+// dictionary file at customPath (one per line, # for comments), if it's set. If
+// the file can't be read the checker still works, with only the bundled words.
+func NewSpellChecker(customPath string) (*SpellChecker, error) {
+	bundledDictionaryOnce.Do(func() {
+		bundledDictionary = make(map[string]bool)
+		for _, word := range strings.Fields(bundledWords) {
+			bundledDictionary[word] = true
+		}
+	})
+
+	c := &SpellChecker{custom: make(map[string]bool)}
+	if customPath == "" {
+		return c, nil
+	}
+
+	data, err := os.ReadFile(customPath)
+	if err != nil {
+		return c, fmt.Errorf("failed to read spell check dictionary: %w", err)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		word := strings.TrimSpace(line)
+		if word != "" && !strings.HasPrefix(word, "#") {
+			c.custom[strings.ToLower(word)] = true
+		}
+	}
+	return c, nil
+}
+
+// contractionSuffixes are endings like "n't" in "don't", removed before a word is looked up
+var contractionSuffixes = []string{"n't", "'s", "'re", "'ve", "'ll", "'d", "'m"}
+
+// Known reports whether word is spelled correctly. Besides exact matches it accepts
+// common inflections of known words, like plurals and -ed, -ing and -ly endings.
+func (c *SpellChecker) Known(word string) bool {
+	word = strings.ToLower(strings.ReplaceAll(word, "’", "'"))
+	for _, suffix := range contractionSuffixes {
+		if base, ok := strings.CutSuffix(word, suffix); ok && base != "" {
+			// "can't", "won't" and "shan't" drop more than the "n't"
+			if suffix == "n't" && (base == "ca" || base == "wo" || base == "sha") {
+				return true
+			}
+			word = base
+			break
+		}
+	}
+
+	if c.known(word) {
+		return true
+	}
+	for _, base := range inflectionBases(word) {
+		if c.known(base) {
+			return true
+		}
+	}
+	return false
+}
+
+// known looks a word up without trying inflections
+func (c *SpellChecker) known(word string) bool {
+	return bundledDictionary[word] || c.custom[word]
+}
+
+// inflectionSuffixes maps word endings to the endings their base words might have,
+// e.g. "tries" to "try" and "baked" to "bake"
+var inflectionSuffixes = []struct {
+	suffix string
+	bases  []string
+}{
+	{"ies", []string{"y"}},
+	{"ied", []string{"y"}},
+	{"ier", []string{"y"}},
+	{"iest", []string{"y"}},
+	{"ily", []string{"y"}},
+	{"es", []string{"", "e"}},
+	{"s", []string{""}},
+	{"ed", []string{"", "e"}},
+	{"ing", []string{"", "e"}},
+	{"er", []string{"", "e"}},
+	{"est", []string{"", "e"}},
+	{"ly", []string{"", "le"}},
+	{"ness", []string{""}},
+	{"ment", []string{""}},
+	{"ful", []string{""}},
+	{"less", []string{""}},
+}
+
+// inflectionBases returns the words word might be an inflection of
+func inflectionBases(word string) []string {
+	var bases []string
+	for _, inflection := range inflectionSuffixes {
+		stem, ok := strings.CutSuffix(word, inflection.suffix)
+		if !ok || len(stem) < 2 {
+			continue
+		}
+		for _, ending := range inflection.bases {
+			bases = append(bases, stem+ending)
+		}
+		// "stopped" and "running" double the base word's last consonant
+		if n := len(stem); n >= 3 && stem[n-1] == stem[n-2] {
+			bases = append(bases, stem[:n-1])
+		}
+	}
+	return bases
+}
+
+// spellCheckWordRegex matches words in text, including contractions like "don't"
+var spellCheckWordRegex = regexp.MustCompile(`[A-Za-z]+(?:['’][A-Za-z]+)*`)
+
+// spellCheckSkipTags are elements whose text isn't prose, so it isn't checked
+var spellCheckSkipTags = map[string]bool{
+	"code": true, "pre": true, "kbd": true, "samp": true, "script": true, "style": true, "a": true,
+}
+
+// MarkMisspelled wraps the unknown words in rendered HTML in
+// <span class="misspelled">. Code, links and math are left alone, as are words
+// that look like names or acronyms (capitalized), and words that are part of a
+// URL, a path, or contain digits.
+func (c *SpellChecker) MarkMisspelled(html string) string {
+	var b strings.Builder
+	skipDepth := 0
+	for len(html) > 0 {
+		if html[0] == '<' {
+			end := strings.IndexByte(html, '>')
+			if end < 0 {
+				b.WriteString(html)
+				break
+			}
+			tag := html[:end+1]
+			name, closing := htmlTagName(tag)
+			switch {
+			case spellCheckSkipTags[name] || (name == "span" || name == "div") && strings.Contains(tag, `class="math`):
+				if closing {
+					skipDepth = max(skipDepth-1, 0)
+				} else if !strings.HasSuffix(tag, "/>") {
+					skipDepth++
+				}
+			case skipDepth > 0 && (name == "span" || name == "div"):
+				// Keep count of spans nested in math so its closing tag is found
+				if closing {
+					skipDepth--
+				} else {
+					skipDepth++
+				}
+			}
+			b.WriteString(tag)
+			html = html[end+1:]
+			continue
+		}
+
+		end := strings.IndexByte(html, '<')
+		if end < 0 {
+			end = len(html)
+		}
+		if skipDepth > 0 {
+			b.WriteString(html[:end])
+		} else {
+			b.WriteString(c.markText(html[:end]))
+		}
+		html = html[end:]
+	}
+	return b.String()
+}
+
+// markText wraps the unknown words in a run of text between tags
+func (c *SpellChecker) markText(text string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range spellCheckWordRegex.FindAllStringIndex(text, -1) {
+		word := text[loc[0]:loc[1]]
+		if !c.shouldCheck(text, loc[0], loc[1]) || c.Known(word) {
+			continue
+		}
+		b.WriteString(text[last:loc[0]])
+		b.WriteString(`<span class="misspelled">` + word + `</span>`)
+		last = loc[1]
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// shouldCheck reports whether the word at text[start:end] is prose worth checking
+func (c *SpellChecker) shouldCheck(text string, start, end int) bool {
+	word := text[start:end]
+	if len(word) < 2 || word[0] < 'a' || word[0] > 'z' {
+		return false
+	}
+
+	// Skip words glued to digits, paths, URLs, e-mail addresses and HTML entities
+	// like &amp; by looking at the whole whitespace-separated token
+	tokenStart := strings.LastIndexAny(text[:start], " \t\n") + 1
+	tokenEnd := end + strings.IndexAny(text[end:]+" ", " \t\n")
+	token := text[tokenStart:tokenEnd]
+	if strings.ContainsAny(token, "0123456789/\\@_&=") || strings.Contains(token, "://") {
+		return false
+	}
+	return true
+}
+
+// htmlTagName returns the lowercase name of an HTML tag like "<a href=...>" or
+// "</code>", and whether it's a closing tag
+func htmlTagName(tag string) (name string, closing bool) {
+	tag = strings.TrimPrefix(tag, "<")
+	if strings.HasPrefix(tag, "/") {
+		closing = true
+		tag = tag[1:]
+	}
+	end := strings.IndexAny(tag, " \t\n/>")
+	if end < 0 {
+		end = len(tag)
+	}
+	return strings.ToLower(tag[:end]), closing
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMarkdownToHTMLSpellCheck(t *testing.T) {
+	content := "# Meeting notes\n\nWe discussed the teh budget and didn't recieve the reports. " +
+		"Alice stopped running tests quickly.\n\n" +
+		"See https://example.com/wrnog and [the docz](notes/mispeled.md), or `fmt.Prinln`.\n\n" +
+		"```go\nvar speling = 1\n```\n\nOur frobnicator works.\n"
+
+	p := NewPreviewService(PreviewOptions{})
+	got, err := p.markdownToHTML(content, "/notes/spelling.md")
+	if err != nil {
+		t.Fatalf("markdownToHTML returned error: %v", err)
+	}
+	if strings.Contains(got, `<span class="misspelled">`) {
+		t.Error("words marked with spell checking off")
+	}
+
+	p.options.SpellCheck = true
+	got, err = p.markdownToHTML(content, "/notes/spelling.md")
+	if err != nil {
+		t.Fatalf("markdownToHTML returned error: %v", err)
+	}
+	for _, word := range []string{"teh", "recieve", "frobnicator"} {
+		if !strings.Contains(got, `<span class="misspelled">`+word+`</span>`) {
+			t.Errorf("%q not marked as misspelled", word)
+		}
+	}
+	for _, word := range []string{"discussed", "didn't", "stopped", "running", "quickly", "wrnog", "docz", "mispeled", "Prinln", "speling", "Alice"} {
+		if strings.Contains(got, `<span class="misspelled">`+word+`</span>`) {
+			t.Errorf("%q marked as misspelled", word)
+		}
+	}
+
+	// Words from the custom dictionary are accepted
+	dict := filepath.Join(t.TempDir(), "words.txt")
+	if err := os.WriteFile(dict, []byte("# Project words\nFrobnicator\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	p.options.SpellDictionary = dict
+	got, err = p.markdownToHTML(content, "/notes/spelling.md")
+	if err != nil {
+		t.Fatalf("markdownToHTML returned error: %v", err)
+	}
+	if strings.Contains(got, `<span class="misspelled">frobnicator</span>`) {
+		t.Error("word from the custom dictionary marked as misspelled")
+	}
+	if !strings.Contains(got, `<span class="misspelled">teh</span>`) {
+		t.Error("custom dictionary replaced the bundled checks")
+	}
+
+	if _, err := NewSpellChecker(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("expected an error for a missing dictionary file")
+	}
+}
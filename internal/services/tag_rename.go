@@ -0,0 +1,241 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// TagChange is a note whose tags a rename changed
+type TagChange struct {
+	RelPath string
+	Before  []string // The note's tags before the rename, sorted
+	After   []string // And after it
+}
+
+// TagRenameResult describes the notes checked by RenameTagWithReport
+type TagRenameResult struct {
+	Checked int
+	Changed []TagChange      // Notes that were (or, in a dry run, would be) rewritten, by relative path
+	Failed  map[string]error // Relative paths that couldn't be read or written, left untouched
+}
+
+// tagNameRegex matches the tag names a rename can write, which also work as #tags
+var tagNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// RenameTag renames oldTag to newTag in every note, in frontmatter tags and #tags in
+// the text, and returns the number of notes changed. Renaming onto a tag a note
+// already has merges the two.
+func (s *NotesService) RenameTag(oldTag, newTag string) (int, error) {
+	result, err := s.RenameTagWithReport(oldTag, newTag, false)
+	if err != nil {
+		return 0, err
+	}
+	if len(result.Failed) > 0 {
+		return len(result.Changed), fmt.Errorf("failed to update %d note(s)", len(result.Failed))
+	}
+	return len(result.Changed), nil
+}
+
+// RenameTagWithReport renames a tag like RenameTag, reporting each changed note's tags
+// before and after. Templates and archived notes are skipped. With dryRun nothing is
+// written, but the report lists the same notes.
+func (s *NotesService) RenameTagWithReport(oldTag, newTag string, dryRun bool) (TagRenameResult, error) {
+	result := TagRenameResult{Failed: make(map[string]error)}
+
+	oldTag, newTag = normalizeTag(oldTag), normalizeTag(newTag)
+	switch {
+	case oldTag == "" || newTag == "":
+		return result, fmt.Errorf("tag names can't be empty")
+	case !tagNameRegex.MatchString(newTag):
+		return result, fmt.Errorf("tag '%s' can only contain letters, digits, - and _", newTag)
+	case oldTag == newTag:
+		return result, fmt.Errorf("the tag is already named '%s'", newTag)
+	}
+
+	err := filepath.Walk(s.notesDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() && (path == s.templatesDir || path == s.GetArchiveDir()) {
+			return filepath.SkipDir
+		}
+		if info.IsDir() || !strings.HasSuffix(info.Name(), ".md") {
+			return nil
+		}
+
+		relPath, _ := filepath.Rel(s.notesDir, path)
+		result.Checked++
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			result.Failed[relPath] = err
+			return nil
+		}
+		content := string(data)
+		ending := DetectLineEnding(content)
+		content = NormalizeLineEndings(content)
+
+		updated := replaceTag(content, oldTag, newTag)
+		if updated == content {
+			return nil
+		}
+
+		if !dryRun {
+			if err := os.WriteFile(path, []byte(RestoreLineEndings(updated, ending)), info.Mode().Perm()); err != nil {
+				result.Failed[relPath] = fmt.Errorf("failed to write note: %w", err)
+				return nil
+			}
+		}
+
+		change := TagChange{RelPath: relPath, Before: parseTags(content), After: parseTags(updated)}
+		sort.Strings(change.Before)
+		sort.Strings(change.After)
+		result.Changed = append(result.Changed, change)
+		return nil
+	})
+	if err != nil {
+		return result, fmt.Errorf("failed to scan notes: %w", err)
+	}
+
+	sort.Slice(result.Changed, func(i, j int) bool {
+		return result.Changed[i].RelPath < result.Changed[j].RelPath
+	})
+	return result, nil
+}
+
+// replaceTag returns content with oldTag renamed to newTag wherever parseTags would
+// find it. Everything else is left as written.
+func replaceTag(content, oldTag, newTag string) string {
+	frontMatter, body, ok, err := splitFrontMatter(content)
+	if err != nil || !ok {
+		// Like parseTags, read a tags: line anywhere in notes without frontmatter
+		return replaceHashtags(replaceFrontMatterTags(content, oldTag, newTag, false), oldTag, newTag)
+	}
+
+	head := content[:strings.IndexByte(content, '\n')+1]
+	closing := content[len(head)+len(frontMatter) : len(content)-len(body)]
+	return head + replaceFrontMatterTags(frontMatter, oldTag, newTag, true) + closing + replaceHashtags(body, oldTag, newTag)
+}
+
+// replaceFrontMatterTags renames a tag in the first tags: key of frontmatter text,
+// written inline or, with lists set, as a YAML list. A tag renamed onto one that's
+// already listed is dropped instead of repeated.
+func replaceFrontMatterTags(text, oldTag, newTag string, lists bool) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		rest, ok := strings.CutPrefix(line, "tags:")
+		if !ok {
+			continue
+		}
+
+		if value := strings.TrimSpace(rest); value != "" {
+			// Inline: tags: a, b
+			if renamed, changed := renameTagItems(inlineTagItems(value), oldTag, newTag); changed {
+				lines[i] = "tags: " + strings.Join(renamed, ", ")
+			}
+			return strings.Join(lines, "\n")
+		}
+		if !lists {
+			continue
+		}
+
+		// A list: "- item" lines up to the next key
+		end := i + 1
+		var items []string
+		var itemLines []int
+		for ; end < len(lines); end++ {
+			trimmed := strings.TrimSpace(lines[end])
+			if trimmed == "" {
+				continue
+			}
+			item, ok := strings.CutPrefix(trimmed, "-")
+			if !ok {
+				break
+			}
+			items = append(items, strings.TrimSpace(item)) // As written, quotes and all
+			itemLines = append(itemLines, end)
+		}
+		renamed, changed := renameTagItems(items, oldTag, newTag)
+		if !changed {
+			return text
+		}
+
+		// Rewrite the list items in place, keeping their indentation
+		indent := lines[itemLines[0]][:strings.Index(lines[itemLines[0]], "-")]
+		var out []string
+		out = append(out, lines[:itemLines[0]]...)
+		for _, item := range renamed {
+			out = append(out, indent+"- "+item)
+		}
+		out = append(out, lines[itemLines[len(itemLines)-1]+1:]...)
+		return strings.Join(out, "\n")
+	}
+	return text
+}
+
+// renameTagItems renames oldTag to newTag in a list of tags, dropping repeats, and
+// reports whether oldTag was there
+func renameTagItems(items []string, oldTag, newTag string) ([]string, bool) {
+	changed := false
+	seen := make(map[string]bool)
+	var renamed []string
+	for _, item := range items {
+		if normalizeTag(item) == oldTag {
+			item = newTag
+			changed = true
+		}
+		if normalizeTag(item) == "" || seen[normalizeTag(item)] {
+			continue
+		}
+		seen[normalizeTag(item)] = true
+		renamed = append(renamed, item)
+	}
+	return renamed, changed
+}
+
+// replaceHashtags renames #oldTag to #newTag in markdown text, skipping the code
+// blocks, code spans and mid-word #s that bodyHashtags skips
+func replaceHashtags(body, oldTag, newTag string) string {
+	lines := strings.Split(body, "\n")
+	inFence := false
+
+	for i, line := range lines {
+		if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence || !strings.Contains(line, "#") {
+			continue
+		}
+
+		// Inline code spans run between pairs of backticks
+		var codeSpans [][]int
+		ticks := strings.Count(line, "`")
+		for start, n := 0, 0; n+1 < ticks; n += 2 {
+			open := start + strings.IndexByte(line[start:], '`')
+			end := open + 1 + strings.IndexByte(line[open+1:], '`')
+			codeSpans = append(codeSpans, []int{open, end + 1})
+			start = end + 1
+		}
+
+		var b strings.Builder
+		last := 0
+		for _, match := range hashtagRegex.FindAllStringSubmatchIndex(line, -1) {
+			nameStart, nameEnd := match[2], match[3]
+			if strings.ToLower(line[nameStart:nameEnd]) != oldTag || withinSpans(nameStart-1, codeSpans) {
+				continue
+			}
+			b.WriteString(line[last:nameStart])
+			b.WriteString(newTag)
+			last = nameEnd
+		}
+		b.WriteString(line[last:])
+		lines[i] = b.String()
+	}
+
+	return strings.Join(lines, "\n")
+}
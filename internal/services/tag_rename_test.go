@@ -0,0 +1,89 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestRenameTag(t *testing.T) {
+	notesDir := t.TempDir()
+	s := NewNotesService(notesDir)
+	files := map[string]string{
+		"inline.md":       "---\ntags: Meetng, work\n---\nBody\n",
+		"work/list.md":    "---\ntitle: Standup\ntags:\n  - meetng\n  - meeting\nkeywords: go\n---\nBody\n",
+		"hashtags.md":     "Notes from the #meetng.\r\n\r\n```css\r\na { color: #meetng; }\r\n```\r\n\r\nKeep `#meetng` and page#meetng, and #meetngs is another tag.\r\n",
+		"untouched.md":    "---\ntags: work\n---\nNo meetng tag here, #meeting is fine\n",
+		".templates/t.md": "---\ntags: meetng\n---\n",
+	}
+	for rel, content := range files {
+		path := filepath.Join(notesDir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	read := func(rel string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(notesDir, rel))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	// A dry run reports the changes without making them
+	preview, err := s.RenameTagWithReport("#meetng", "meeting", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for rel, content := range files {
+		if got := read(rel); got != content {
+			t.Errorf("dry run changed %s to %q", rel, got)
+		}
+	}
+
+	result, err := s.RenameTagWithReport("#meetng", "meeting", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(preview.Changed, result.Changed) {
+		t.Errorf("dry run reported %v, the rename changed %v", preview.Changed, result.Changed)
+	}
+
+	want := []TagChange{
+		{RelPath: "hashtags.md", Before: []string{"meetng", "meetngs"}, After: []string{"meeting", "meetngs"}},
+		{RelPath: "inline.md", Before: []string{"meetng", "work"}, After: []string{"meeting", "work"}},
+		{RelPath: filepath.Join("work", "list.md"), Before: []string{"meeting", "meetng"}, After: []string{"meeting"}},
+	}
+	if !reflect.DeepEqual(result.Changed, want) {
+		t.Errorf("Changed = %+v, want %+v", result.Changed, want)
+	}
+	if result.Checked != 4 {
+		t.Errorf("Checked = %d, want 4 (templates are skipped)", result.Checked)
+	}
+
+	wantContent := map[string]string{
+		"inline.md":       "---\ntags: meeting, work\n---\nBody\n",
+		"work/list.md":    "---\ntitle: Standup\ntags:\n  - meeting\nkeywords: go\n---\nBody\n",
+		"hashtags.md":     "Notes from the #meeting.\r\n\r\n```css\r\na { color: #meetng; }\r\n```\r\n\r\nKeep `#meetng` and page#meetng, and #meetngs is another tag.\r\n",
+		"untouched.md":    files["untouched.md"],
+		".templates/t.md": files[".templates/t.md"],
+	}
+	for rel, want := range wantContent {
+		if got := read(rel); got != want {
+			t.Errorf("%s = %q, want %q", rel, got, want)
+		}
+	}
+
+	// Nothing left to rename
+	if n, err := s.RenameTag("meetng", "meeting"); err != nil || n != 0 {
+		t.Errorf("RenameTag again = %d, %v; want 0, nil", n, err)
+	}
+	if _, err := s.RenameTag("work", "two words"); err == nil {
+		t.Error("RenameTag should refuse a name that can't be a #tag")
+	}
+}
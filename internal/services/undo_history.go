@@ -0,0 +1,74 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// MaxUndoHistory is how many undo entries are kept in a note's undo history file
+const MaxUndoHistory = 20
+
+// UndoEntry is one earlier state of a note in its saved undo history
+type UndoEntry struct {
+	Content string `json:"content"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+}
+
+// undoHistory is the JSON saved in a note's .undo sidecar file
+type undoHistory struct {
+	Saved   string      `json:"saved"` // The note's content when the history was written
+	Entries []UndoEntry `json:"entries"`
+}
+
+// UndoHistoryPath is the sidecar file holding a note's undo history
+func UndoHistoryPath(notePath string) string {
+	return notePath + ".undo"
+}
+
+// SaveUndoHistory writes the most recent MaxUndoHistory entries (oldest first) next
+// to the note, along with the content just saved. With no entries the file is removed.
+func (s *NotesService) SaveUndoHistory(notePath, saved string, entries []UndoEntry) error {
+	path := UndoHistoryPath(notePath)
+	if len(entries) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove undo history: %w", err)
+		}
+		return nil
+	}
+
+	if len(entries) > MaxUndoHistory {
+		entries = entries[len(entries)-MaxUndoHistory:]
+	}
+	data, err := json.Marshal(undoHistory{Saved: saved, Entries: entries})
+	if err != nil {
+		return fmt.Errorf("failed to encode undo history: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to save undo history: %w", err)
+	}
+	return nil
+}
+
+// LoadUndoHistory reads a note's saved undo history, oldest first. It returns no
+// entries if there is none, or if the note has changed since it was saved, e.g. in
+// another editor, since the history would no longer lead back from its content.
+func (s *NotesService) LoadUndoHistory(notePath, current string) ([]UndoEntry, error) {
+	data, err := os.ReadFile(UndoHistoryPath(notePath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read undo history: %w", err)
+	}
+
+	var history undoHistory
+	if err := json.Unmarshal(data, &history); err != nil {
+		return nil, fmt.Errorf("failed to parse undo history: %w", err)
+	}
+	if history.Saved != current {
+		return nil, nil
+	}
+	return history.Entries, nil
+}
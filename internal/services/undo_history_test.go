@@ -0,0 +1,53 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestUndoHistoryRoundTrip(t *testing.T) {
+	s := NewNotesService(t.TempDir())
+	notePath, err := s.CreateNote("note")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var entries []UndoEntry
+	for i := 0; i < MaxUndoHistory+5; i++ {
+		entries = append(entries, UndoEntry{Content: fmt.Sprintf("version %d", i), Line: i})
+	}
+	if err := s.SaveUndoHistory(notePath, "saved", entries); err != nil {
+		t.Fatalf("SaveUndoHistory returned error: %v", err)
+	}
+
+	// Only the most recent entries are kept
+	got, err := s.LoadUndoHistory(notePath, "saved")
+	if err != nil {
+		t.Fatalf("LoadUndoHistory returned error: %v", err)
+	}
+	if want := entries[5:]; !reflect.DeepEqual(got, want) {
+		t.Errorf("loaded %d entries starting at %q, want the last %d", len(got), got[0].Content, len(want))
+	}
+
+	// A note changed since the history was saved gets none
+	if got, err := s.LoadUndoHistory(notePath, "edited elsewhere"); err != nil || got != nil {
+		t.Errorf("stale history = %v, %v; want none", got, err)
+	}
+
+	// Moving the note takes its history along, and deleting it removes the history
+	movedPath, err := s.MoveNote(notePath, "archive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(UndoHistoryPath(movedPath)); err != nil {
+		t.Errorf("undo history didn't move with the note: %v", err)
+	}
+	if err := s.DeleteNote(movedPath); err != nil {
+		t.Fatalf("DeleteNote returned error: %v", err)
+	}
+	if _, err := os.Stat(UndoHistoryPath(movedPath)); !os.IsNotExist(err) {
+		t.Errorf("undo history left behind after deleting the note: %v", err)
+	}
+}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// wikiLinkRegex matches [[name]] references, optionally with a #heading or |alias:
+// [[name#heading]], [[name|shown text]]
+var wikiLinkRegex = regexp.MustCompile(`\[\[([^\[\]|#]+)(#[^\[\]|]*)?(?:\|([^\[\]]*))?\]\]`)
+
+// wikiLinkKey returns the name a [[name]] reference or note path is matched by: the
+// filename without its .md extension, lowercased
+func wikiLinkKey(name string) string {
+	name = path.Base(filepath.ToSlash(strings.TrimSpace(name)))
+	return strings.ToLower(strings.TrimSuffix(name, ".md"))
+}
+
+// WikiLinkNames returns the note names referenced by [[name]] links in content,
+// skipping fenced code blocks and inline code
+func WikiLinkNames(content string) []string {
+	var names []string
+	forEachWikiLink(content, func(match []string) string {
+		names = append(names, strings.TrimSpace(match[1]))
+		return match[0]
+	})
+	return names
+}
+
+// forEachWikiLink calls replace for each [[name]] link outside code, with the regex
+// submatches, and returns content with each link replaced by what it returns
+func forEachWikiLink(content string, replace func(match []string) string) string {
+	lines := strings.Split(content, "\n")
+	inFence := false
+
+	for i, line := range lines {
+		if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence || !strings.Contains(line, "[[") {
+			continue
+		}
+
+		// Odd-numbered pieces between backticks are inline code
+		pieces := strings.Split(line, "`")
+		for j := 0; j < len(pieces); j += 2 {
+			pieces[j] = wikiLinkRegex.ReplaceAllStringFunc(pieces[j], func(link string) string {
+				return replace(wikiLinkRegex.FindStringSubmatch(link))
+			})
+		}
+		lines[i] = strings.Join(pieces, "`")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// FindBacklinks returns the notes that link to targetNote with [[name]], matching by
+// filename without extension (case-insensitive). targetNote may be a name or a path.
+// Notes with the target's name and templates are skipped; results are sorted by name.
+func (s *NotesService) FindBacklinks(targetNote string) ([]Note, error) {
+	key := wikiLinkKey(targetNote)
+	if key == "" || key == "." {
+		return nil, fmt.Errorf("no note name given")
+	}
+
+	notes, err := s.ListNotes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notes: %w", err)
+	}
+
+	var backlinks []Note
+	for _, note := range notes {
+		if wikiLinkKey(note.Name) == key {
+			continue // The target itself
+		}
+
+		content, err := os.ReadFile(note.FilePath)
+		if err != nil {
+			continue // Skip notes we can't read
+		}
+
+		for _, name := range WikiLinkNames(string(content)) {
+			if wikiLinkKey(name) == key {
+				backlinks = append(backlinks, note)
+				break
+			}
+		}
+	}
+
+	sort.Slice(backlinks, func(i, j int) bool {
+		return backlinks[i].Name < backlinks[j].Name
+	})
+
+	return backlinks, nil
+}
+
+// ResolveWikiLinks rewrites [[name]] links in content into markdown links to the
+// matching notes, relative to sourcePath's directory, for the preview. [[name|text]]
+// shows text and [[name#heading]] links to the heading. Links to notes that don't
+// exist are left as they are.
+func (s *NotesService) ResolveWikiLinks(content, sourcePath string) string {
+	if !strings.Contains(content, "[[") {
+		return content
+	}
+
+	// Index notes by name; the shallowest path wins when names repeat
+	paths := make(map[string]string)
+	_ = filepath.Walk(s.notesDir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() && (p == s.templatesDir || (p != s.notesDir && strings.HasPrefix(info.Name(), "."))) {
+			return filepath.SkipDir
+		}
+		if info.IsDir() || !strings.HasSuffix(info.Name(), ".md") {
+			return nil
+		}
+		key := wikiLinkKey(info.Name())
+		if existing, ok := paths[key]; !ok || strings.Count(p, string(filepath.Separator)) < strings.Count(existing, string(filepath.Separator)) {
+			paths[key] = p
+		}
+		return nil
+	})
+
+	sourceDir := filepath.Dir(sourcePath)
+	return forEachWikiLink(content, func(match []string) string {
+		target, ok := paths[wikiLinkKey(match[1])]
+		if !ok {
+			return match[0]
+		}
+
+		relPath, err := filepath.Rel(sourceDir, target)
+		if err != nil {
+			relPath = target
+		}
+		link := filepath.ToSlash(relPath)
+		if heading := strings.TrimPrefix(match[2], "#"); heading != "" {
+			link += "#" + headingAnchor(heading)
+		}
+
+		text := strings.TrimSpace(match[3])
+		if text == "" {
+			text = strings.TrimSpace(match[1])
+		}
+		return fmt.Sprintf("[%s](<%s>)", text, link)
+	})
+}
+
+// headingAnchor approximates the id goldmark's auto heading IDs give a heading
+func headingAnchor(heading string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(heading)) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteRune('-')
+		}
+	}
+	return b.String()
+}
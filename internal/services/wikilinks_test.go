@@ -0,0 +1,81 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeTestNotes(t *testing.T, notesDir string, notes map[string]string) {
+	t.Helper()
+	for name, content := range notes {
+		path := filepath.Join(notesDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestFindBacklinks(t *testing.T) {
+	notesDir := t.TempDir()
+	writeTestNotes(t, notesDir, map[string]string{
+		"project.md":           "# Project\n\nSee [[Standup]] and [[project]].\n",
+		"work/standup.md":      "# Standup\n",
+		"work/retro.md":        "Follow up in [[work/standup|the standup]].\n",
+		"ideas.md":             "Not a link: `[[standup]]`\n\n```\n[[standup]]\n```\n",
+		".templates/status.md": "[[standup]]\n",
+		"other.md":             "[[standup-notes]]\n",
+	})
+	s := NewNotesService(notesDir)
+
+	backlinks, err := s.FindBacklinks(filepath.Join(notesDir, "work", "standup.md"))
+	if err != nil {
+		t.Fatalf("FindBacklinks returned error: %v", err)
+	}
+	var names []string
+	for _, note := range backlinks {
+		names = append(names, note.Name)
+	}
+	if want := []string{"project.md", filepath.Join("work", "retro.md")}; !reflect.DeepEqual(names, want) {
+		t.Errorf("backlinks = %v, want %v", names, want)
+	}
+
+	// A note linking to itself isn't its own backlink
+	backlinks, err = s.FindBacklinks("project")
+	if err != nil || len(backlinks) != 0 {
+		t.Errorf("FindBacklinks(project) = %v, %v; want none", backlinks, err)
+	}
+}
+
+func TestResolveWikiLinks(t *testing.T) {
+	notesDir := t.TempDir()
+	writeTestNotes(t, notesDir, map[string]string{
+		"project.md":      "",
+		"work/standup.md": "",
+	})
+	s := NewNotesService(notesDir)
+
+	content := "See [[Standup]], [[project|the project]] and [[standup#Action Items]].\n" +
+		"Missing [[nowhere]] and code `[[project]]`.\n"
+	got := s.ResolveWikiLinks(content, filepath.Join(notesDir, "journal-notes", "today.md"))
+
+	want := "See [Standup](<../work/standup.md>), [the project](<../project.md>) and [standup](<../work/standup.md#action-items>).\n" +
+		"Missing [[nowhere]] and code `[[project]]`.\n"
+	if got != want {
+		t.Errorf("ResolveWikiLinks =\n%q\nwant\n%q", got, want)
+	}
+
+	// The anchor matches the id the preview gives the heading
+	html, err := NewPreviewService(PreviewOptions{}).markdownToHTML("## Action Items\n", "standup.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(html, `id="action-items"`) {
+		t.Error("heading anchor doesn't match the preview's heading id")
+	}
+}
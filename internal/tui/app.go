@@ -14,88 +14,112 @@ type AppModel struct {
 	journalService *services.JournalService
 	notesService   *services.NotesService
 	cfg            *config.Config
+	opts           Options
 	journalDir     string
 	notesDir       string
 	width          int
 	height         int
+
+	// Where the browsers were left, restored when returning from one of their items
+	notesBrowserPos   *notesBrowserPosition
+	journalBrowserPos *journalBrowserPosition
+
+	trash *noteTrash // Quick-deleted notes, kept across notes browsers until their undo expires
+
+	idleGen int  // Counts keypresses, so only the latest idle timer locks
+	locked  bool // Blanked by the idle lock until the next keypress
 }
 
 // NewAppModel creates a new app model with dashboard as initial view
 func NewAppModel(cfg *config.Config) AppModel {
-	journalService := services.NewJournalService(cfg.JournalDir)
-	notesService := services.NewNotesService(cfg.NotesDir)
-
-	return AppModel{
-		currentView:    NewDashboard(),
-		journalService: journalService,
-		notesService:   notesService,
-		cfg:            cfg,
-		journalDir:     cfg.JournalDir,
-		notesDir:       cfg.NotesDir,
-	}
+	return newAppModel(cfg, func(m AppModel) tea.Model {
+		return NewDashboard(m.journalService)
+	})
 }
 
 // NewJournalBrowserApp creates a new app model starting at the journal browser
-func NewJournalBrowserApp(journalDir, notesDir string) AppModel {
-	journalService := services.NewJournalService(journalDir)
-	notesService := services.NewNotesService(notesDir)
-
-	return AppModel{
-		currentView:    NewJournalBrowser(journalService, journalDir, 0, 0),
-		journalService: journalService,
-		notesService:   notesService,
-		journalDir:     journalDir,
-		notesDir:       notesDir,
-	}
+func NewJournalBrowserApp(cfg *config.Config) AppModel {
+	return newAppModel(cfg, func(m AppModel) tea.Model {
+		return NewJournalBrowser(m.journalService, m.opts, m.journalDir, 0, 0)
+	})
 }
 
 // NewNotesBrowserApp creates a new app model starting at the notes browser
-func NewNotesBrowserApp(journalDir, notesDir string) AppModel {
-	journalService := services.NewJournalService(journalDir)
-	notesService := services.NewNotesService(notesDir)
-
-	return AppModel{
-		currentView:    NewNotesBrowser(notesService, 0, 0),
-		journalService: journalService,
-		notesService:   notesService,
-		journalDir:     journalDir,
-		notesDir:       notesDir,
-	}
+func NewNotesBrowserApp(cfg *config.Config) AppModel {
+	return newAppModel(cfg, func(m AppModel) tea.Model {
+		return m.newNotesBrowser()
+	})
 }
 
 // NewSearchBrowserApp creates a new app model starting at the search browser
-func NewSearchBrowserApp(journalDir, notesDir string, query string) AppModel {
-	journalService := services.NewJournalService(journalDir)
-	notesService := services.NewNotesService(notesDir)
-
-	return AppModel{
-		currentView:    NewSearchBrowserWithQuery(journalService, notesService, 0, 0, query),
-		journalService: journalService,
-		notesService:   notesService,
-		journalDir:     journalDir,
-		notesDir:       notesDir,
-	}
+// When fullFile is set, note content matching includes frontmatter
+func NewSearchBrowserApp(cfg *config.Config, query string, fullFile bool) AppModel {
+	return newAppModel(cfg, func(m AppModel) tea.Model {
+		browser := NewSearchBrowserWithQuery(m.journalService, m.notesService, m.opts, 0, 0, query)
+		browser.fullFileSearch = fullFile
+		return browser
+	})
 }
 
 // NewTodayJournalApp creates a new app model starting with today's journal open
-func NewTodayJournalApp(journalDir, notesDir string) AppModel {
-	journalService := services.NewJournalService(journalDir)
-	notesService := services.NewNotesService(notesDir)
-
-	return AppModel{
-		currentView:    NewJournalEditor(journalService, time.Now()),
-		journalService: journalService,
-		notesService:   notesService,
-		journalDir:     journalDir,
-		notesDir:       notesDir,
+// (in the read-only view first when journal.today.readonly is set)
+func NewTodayJournalApp(cfg *config.Config) AppModel {
+	return newAppModel(cfg, func(m AppModel) tea.Model {
+		if cfg.JournalTodayReadOnly {
+			return NewJournalModel(m.journalService, m.opts)
+		}
+		return NewJournalEditor(m.journalService, m.opts, time.Now())
+	})
+}
+
+// newAppModel creates an app model with the services and options cfg sets, starting
+// at the view start returns
+func newAppModel(cfg *config.Config, start func(m AppModel) tea.Model) AppModel {
+	opts := OptionsFromConfig(cfg)
+	m := AppModel{
+		journalService: services.NewJournalServiceWithOptions(cfg.JournalDir, opts.Journal),
+		notesService:   services.NewNotesServiceWithOptions(cfg.NotesDir, opts.Notes),
+		cfg:            cfg,
+		opts:           opts,
+		journalDir:     cfg.JournalDir,
+		notesDir:       cfg.NotesDir,
+		trash:          newNoteTrash(),
 	}
+	m.currentView = start(m)
+	return m
 }
 
 func (m AppModel) Init() tea.Cmd {
-	return m.currentView.Init()
+	return tea.Batch(m.currentView.Init(), m.idleTimer())
 }
 
 func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case idleTickMsg:
+		if msg.gen != m.idleGen || m.locked {
+			return m, nil
+		}
+		return m.lock()
+
+	case tea.KeyMsg:
+		if m.opts.IdleLock <= 0 {
+			break
+		}
+		idleCmd := m.resetIdleTimer()
+		if m.locked {
+			// The key only unlocks
+			m.locked = false
+			return m, idleCmd
+		}
+		model, cmd := m.update(msg)
+		return model, tea.Batch(cmd, idleCmd)
+	}
+
+	return m.update(msg)
+}
+
+// update routes a message to the current view, switching views as the messages ask
+func (m AppModel) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	// Track window size
@@ -104,13 +128,16 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 	}
 
+	// Remember where the browser is before a message replaces it
+	m.rememberBrowserPosition()
+
 	// Handle menu selections
 	switch msg := msg.(type) {
 	case MenuSelectionMsg:
 		switch msg.Selection {
 		case "today-journal":
 			// Open today's journal in editor
-			m.currentView = NewJournalEditor(m.journalService, time.Now())
+			m.currentView = NewJournalEditor(m.journalService, m.opts, time.Now())
 			// Send window size to new view
 			if m.width > 0 && m.height > 0 {
 				m.currentView, cmd = m.currentView.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
@@ -118,19 +145,30 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Batch(cmd, m.currentView.Init())
 		case "journals":
 			// Open journal browser
-			m.currentView = NewJournalBrowser(m.journalService, m.journalDir, m.width, m.height)
+			m.currentView = NewJournalBrowser(m.journalService, m.opts, m.journalDir, m.width, m.height)
 			return m, m.currentView.Init()
 		case "notes":
 			// Open notes browser
-			m.currentView = NewNotesBrowser(m.notesService, m.width, m.height)
+			m.currentView = m.newNotesBrowser()
+			return m, m.currentView.Init()
+		case "recent-notes":
+			// List the most recently modified notes to jump back into
+			m.currentView = NewRecentNotes(m.notesService, m.opts, m.width, m.height)
 			return m, m.currentView.Init()
+		case "scratch":
+			// Open an empty scratchpad, only written to disk if saved as a note
+			m.currentView = NewScratchEditor(m.notesService, m.opts)
+			if m.width > 0 && m.height > 0 {
+				m.currentView, cmd = m.currentView.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+			}
+			return m, tea.Batch(cmd, m.currentView.Init())
 		case "search":
 			// Open search browser
-			m.currentView = NewSearchBrowser(m.journalService, m.notesService, m.width, m.height)
+			m.currentView = NewSearchBrowser(m.journalService, m.notesService, m.opts, m.width, m.height)
 			return m, m.currentView.Init()
 		case "import-export":
 			// Open import/export menu
-			m.currentView = NewImportExportMenu(m.width, m.height)
+			m.currentView = NewImportExportMenu(m.notesDir, m.journalDir, m.width, m.height)
 			return m, m.currentView.Init()
 		case "clean":
 			// Open clean menu
@@ -139,7 +177,7 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	case OpenJournalMsg:
 		// Open specific journal date in editor
-		m.currentView = NewJournalEditor(m.journalService, msg.date)
+		m.currentView = NewJournalEditor(m.journalService, m.opts, msg.date)
 		// Send window size to new view
 		if m.width > 0 && m.height > 0 {
 			m.currentView, cmd = m.currentView.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
@@ -147,7 +185,7 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, tea.Batch(cmd, m.currentView.Init())
 	case OpenJournalEditorMsg:
 		// Open journal editor for specific date
-		m.currentView = NewJournalEditor(m.journalService, msg.date)
+		m.currentView = NewJournalEditor(m.journalService, m.opts, msg.date)
 		// Send window size to new view
 		if m.width > 0 && m.height > 0 {
 			m.currentView, cmd = m.currentView.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
@@ -155,23 +193,31 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, tea.Batch(cmd, m.currentView.Init())
 	case CreateJournalWithNameMsg:
 		// Create new journal with custom filepath
-		m.currentView = NewJournalEditorWithFilename(m.journalService, msg.filepath)
+		m.currentView = NewJournalEditorWithFilename(m.journalService, m.opts, msg.filepath)
 		// Send window size to new view
 		if m.width > 0 && m.height > 0 {
 			m.currentView, cmd = m.currentView.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
 		}
 		return m, tea.Batch(cmd, m.currentView.Init())
 	case OpenNoteMsg:
-		// Open specific note in editor
-		m.currentView = NewNotesEditor(m.notesService, msg.filePath)
+		// Open specific note in editor, at the search match if it came from a search
+		editor := NewNotesEditorWithQuery(m.notesService, m.opts, msg.filePath, msg.query, msg.searchOpts)
+		editor.noteSort = msg.sort
+		m.currentView = editor
 		// Send window size to new view
 		if m.width > 0 && m.height > 0 {
 			m.currentView, cmd = m.currentView.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
 		}
 		return m, tea.Batch(cmd, m.currentView.Init())
+	case ViewNoteMsg:
+		// Show the note read-only
+		viewer := NewNoteViewer(m.notesService, m.opts, msg.note, m.width, m.height)
+		viewer.sort = msg.sort
+		m.currentView = viewer
+		return m, m.currentView.Init()
 	case CreateNoteMsg:
 		// Create new note
-		m.currentView = NewNotesEditorForNew(m.notesService)
+		m.currentView = NewNotesEditorForNew(m.notesService, m.opts)
 		// Send window size to new view
 		if m.width > 0 && m.height > 0 {
 			m.currentView, cmd = m.currentView.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
@@ -179,19 +225,37 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, tea.Batch(cmd, m.currentView.Init())
 	case CreateNoteFromTemplateMsg:
 		// Create new note from template
-		m.currentView = NewNotesEditorForNewWithTemplate(m.notesService, msg.templatePath, msg.targetPath)
+		m.currentView = NewNotesEditorForNewWithTemplate(m.notesService, m.opts, msg.templatePath, msg.targetPath)
 		// Send window size to new view
 		if m.width > 0 && m.height > 0 {
 			m.currentView, cmd = m.currentView.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
 		}
 		return m, tea.Batch(cmd, m.currentView.Init())
+	case OpenTagCloudMsg:
+		// Open the tag cloud overview
+		m.currentView = NewTagCloud(m.notesService, m.opts, m.width, m.height)
+		return m, m.currentView.Init()
+	case FilterNotesByTagMsg:
+		// Return to the notes browser filtered by the chosen tag
+		browser := m.newNotesBrowser()
+		browser.applyTagFilter(msg.tag)
+		m.currentView = browser
+		return m, m.currentView.Init()
 	case BackToNotesBrowserMsg:
-		// Return to notes browser
-		m.currentView = NewNotesBrowser(m.notesService, m.width, m.height)
+		// Return to notes browser where it was left
+		browser := m.newNotesBrowser()
+		if m.notesBrowserPos != nil {
+			browser.restorePosition(*m.notesBrowserPos)
+		}
+		m.currentView = browser
 		return m, m.currentView.Init()
 	case BackToJournalBrowserMsg:
-		// Return to journal browser
-		m.currentView = NewJournalBrowser(m.journalService, m.journalDir, m.width, m.height)
+		// Return to journal browser where it was left
+		browser := NewJournalBrowser(m.journalService, m.opts, m.journalDir, m.width, m.height)
+		if m.journalBrowserPos != nil {
+			browser.restorePosition(*m.journalBrowserPos)
+		}
+		m.currentView = browser
 		return m, m.currentView.Init()
 	case OpenWeeklySummaryMenuMsg:
 		// Open weekly summary menu
@@ -203,7 +267,7 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, tea.Batch(cmd, m.currentView.Init())
 	case OpenWeeklySummaryFileMsg:
 		// Open weekly summary file in editor
-		m.currentView = NewNotesEditor(m.notesService, msg.filePath)
+		m.currentView = NewNotesEditor(m.notesService, m.opts, msg.filePath)
 		// Send window size to new view
 		if m.width > 0 && m.height > 0 {
 			m.currentView, cmd = m.currentView.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
@@ -211,7 +275,7 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, tea.Batch(cmd, m.currentView.Init())
 	case BackToDashboardMsg:
 		// Return to dashboard
-		m.currentView = NewDashboard()
+		m.currentView = NewDashboard(m.journalService)
 		// Send window size to new view
 		if m.width > 0 && m.height > 0 {
 			m.currentView, cmd = m.currentView.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
@@ -225,12 +289,41 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Forward to current view to handle
 		m.currentView, cmd = m.currentView.Update(msg)
 		return m, cmd
+	case undoExpiredMsg:
+		// The delete is final even if the browser that made it is gone
+		m.trash.expire(msg.id)
 	}
 
 	m.currentView, cmd = m.currentView.Update(msg)
 	return m, cmd
 }
 
+// newNotesBrowser creates a notes browser sized to the window, sharing the app's trash
+func (m AppModel) newNotesBrowser() NotesBrowserModel {
+	browser := NewNotesBrowser(m.notesService, m.opts, m.width, m.height)
+	browser.trash = m.trash
+	return browser
+}
+
+// rememberBrowserPosition records the current browser's position, if the current
+// view is one
+func (m *AppModel) rememberBrowserPosition() {
+	switch view := m.currentView.(type) {
+	case NotesBrowserModel:
+		pos := view.position()
+		m.notesBrowserPos = &pos
+	case JournalBrowserModel:
+		pos := view.position()
+		m.journalBrowserPos = &pos
+	}
+}
+
 func (m AppModel) View() string {
+	if m.locked {
+		return m.lockedView()
+	}
+	if view, small := tooSmallView(m.width, m.height); small {
+		return view
+	}
 	return m.currentView.View()
 }
@@ -0,0 +1,215 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/redjax/notetkr/internal/config"
+)
+
+// testConfig returns the default config with its directories in temporary ones
+func testConfig(t *testing.T, journalDir, notesDir string) *config.Config {
+	t.Helper()
+	cfg := config.DefaultConfig()
+	cfg.DataDir = t.TempDir()
+	cfg.JournalDir = journalDir
+	cfg.NotesDir = notesDir
+	return cfg
+}
+
+func TestNewTodayJournalAppReadOnly(t *testing.T) {
+	journalDir := t.TempDir()
+	notesDir := t.TempDir()
+
+	cfg := testConfig(t, journalDir, notesDir)
+	cfg.JournalTodayReadOnly = true
+	app := NewTodayJournalApp(cfg)
+	if _, ok := app.currentView.(JournalModel); !ok {
+		t.Errorf("read-only today app starts with %T, want JournalModel", app.currentView)
+	}
+
+	cfg.JournalTodayReadOnly = false
+	app = NewTodayJournalApp(cfg)
+	if _, ok := app.currentView.(JournalEditorModel); !ok {
+		t.Errorf("default today app starts with %T, want JournalEditorModel", app.currentView)
+	}
+}
+
+func TestTooSmallTerminalShowsMessage(t *testing.T) {
+	app := NewNotesBrowserApp(testConfig(t, t.TempDir(), t.TempDir()))
+
+	updated, _ := app.Update(tea.WindowSizeMsg{Width: 30, Height: 8})
+	if view := updated.View(); !strings.Contains(view, "Terminal too small") || !strings.Contains(view, "40x10") {
+		t.Errorf("30x8 view should show the small-terminal message, got %q", view)
+	}
+
+	updated, _ = updated.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	if view := updated.View(); strings.Contains(view, "Terminal too small") {
+		t.Error("80x24 view should render normally")
+	}
+
+	// Size not known yet
+	if _, small := tooSmallView(0, 0); small {
+		t.Error("unknown size should not be treated as too small")
+	}
+	if _, small := tooSmallView(MinTerminalWidth, MinTerminalHeight-1); !small {
+		t.Error("one row short of the minimum should be too small")
+	}
+}
+
+func TestReturningToNotesBrowserRestoresPosition(t *testing.T) {
+	notesDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(notesDir, "work"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"a.md", "b.md", "c.md"} {
+		if err := os.WriteFile(filepath.Join(notesDir, "work", name), []byte("# "+name+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var app tea.Model = NewNotesBrowserApp(testConfig(t, t.TempDir(), notesDir))
+	press := func(key tea.KeyMsg) tea.Cmd {
+		var cmd tea.Cmd
+		app, cmd = app.Update(key)
+		return cmd
+	}
+
+	// Into the work category, down to the third note, and open it
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	press(tea.KeyMsg{Type: tea.KeyDown})
+	press(tea.KeyMsg{Type: tea.KeyDown})
+	selected := app.(AppModel).currentView.(NotesBrowserModel).position().selected
+	cmd := press(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter on a note should open it")
+	}
+	app, _ = app.Update(cmd())
+	if _, ok := app.(AppModel).currentView.(NotesEditorModel); !ok {
+		t.Fatalf("opening a note shows %T, want NotesEditorModel", app.(AppModel).currentView)
+	}
+
+	app, _ = app.Update(BackToNotesBrowserMsg{})
+	browser, ok := app.(AppModel).currentView.(NotesBrowserModel)
+	if !ok {
+		t.Fatalf("returning shows %T, want NotesBrowserModel", app.(AppModel).currentView)
+	}
+	if browser.currentPath != "work" || browser.cursor != 2 {
+		t.Errorf("returned to path %q cursor %d, want \"work\" cursor 2", browser.currentPath, browser.cursor)
+	}
+	if got := browser.position().selected; got != selected {
+		t.Errorf("returned with %q selected, want %q", got, selected)
+	}
+}
+
+func TestReturningToJournalBrowserRestoresPosition(t *testing.T) {
+	journalDir := t.TempDir()
+	monthDir := filepath.Join(journalDir, "2025", "03")
+	if err := os.MkdirAll(monthDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"2025-03-01.md", "2025-03-02.md"} {
+		if err := os.WriteFile(filepath.Join(monthDir, name), []byte("# Journal\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	app := NewJournalBrowserApp(testConfig(t, journalDir, t.TempDir()))
+	browser := app.currentView.(JournalBrowserModel)
+	browser.breadcrumb = []string{"2025", "03"}
+	browser.loadItems()
+	browser.cursor = len(browser.items) - 1
+	app.currentView = browser
+
+	// Opening an entry records where the browser was
+	updated, _ := app.Update(OpenJournalMsg{date: time.Date(2025, 3, 2, 0, 0, 0, 0, time.Local)})
+	updated, _ = updated.Update(BackToJournalBrowserMsg{})
+	restored := updated.(AppModel).currentView.(JournalBrowserModel)
+	if strings.Join(restored.breadcrumb, "/") != "2025/03" || restored.cursor != browser.cursor {
+		t.Errorf("returned to %v cursor %d, want [2025 03] cursor %d", restored.breadcrumb, restored.cursor, browser.cursor)
+	}
+}
+
+func TestIdleLockSavesEditorAndBlanksScreen(t *testing.T) {
+	notesDir := t.TempDir()
+	notePath := filepath.Join(notesDir, "secret.md")
+	if err := os.WriteFile(notePath, []byte("# Secret\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := testConfig(t, t.TempDir(), notesDir)
+	cfg.IdleLockMinutes = 1
+	app := NewNotesBrowserApp(cfg)
+	updated, _ := app.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	updated, _ = updated.Update(OpenNoteMsg{filePath: notePath})
+	app = updated.(AppModel)
+	editor := app.currentView.(NotesEditorModel)
+	updated, _ = app.Update(editor.loadNote())
+	for _, key := range []string{"G", "o", "password"} {
+		updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+	}
+	app = updated.(AppModel)
+
+	// A timer from before the last keypress is ignored
+	updated, _ = app.Update(idleTickMsg{gen: app.idleGen - 1})
+	if updated.(AppModel).locked {
+		t.Fatal("a stale idle timer locked the app")
+	}
+
+	updated, _ = app.Update(idleTickMsg{gen: app.idleGen})
+	app = updated.(AppModel)
+	if !app.locked {
+		t.Fatal("the idle timer running out should lock the app")
+	}
+	if view := app.View(); strings.Contains(view, "password") || !strings.Contains(view, "Locked") {
+		t.Errorf("locked view should be blank apart from the lock message, got %q", view)
+	}
+	if _, ok := app.currentView.(DashboardModel); !ok {
+		t.Errorf("locking should close the editor, current view is %T", app.currentView)
+	}
+	if content, _ := os.ReadFile(notePath); !strings.Contains(string(content), "password") {
+		t.Errorf("locking should save the editor first, note is %q", content)
+	}
+
+	// Any key unlocks, without acting on the view
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	app = updated.(AppModel)
+	if app.locked {
+		t.Error("a keypress should unlock the app")
+	}
+	if dashboard := app.currentView.(DashboardModel); dashboard.cursor != 0 {
+		t.Error("the unlocking key should not reach the dashboard")
+	}
+}
+
+func TestQuickDeleteExpiresAfterLeavingTheBrowser(t *testing.T) {
+	notesDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(notesDir, "oops.md"), []byte("# oops\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := testConfig(t, t.TempDir(), notesDir)
+	cfg.NotesQuickDelete = true
+
+	var app tea.Model = NewNotesBrowserApp(cfg)
+	app, _ = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	id := app.(AppModel).currentView.(NotesBrowserModel).undoID
+	if len(app.(AppModel).trash.notes) != 1 {
+		t.Fatal("quick delete should keep the note in the app's trash")
+	}
+
+	// A browser opened later shares the trash, and the toast can expire while
+	// another view is showing
+	app, _ = app.Update(BackToNotesBrowserMsg{})
+	if app.(AppModel).currentView.(NotesBrowserModel).trash != app.(AppModel).trash {
+		t.Error("a new notes browser should share the app's trash")
+	}
+	app, _ = app.Update(BackToDashboardMsg{})
+	app, _ = app.Update(undoExpiredMsg{id: id})
+	if n := len(app.(AppModel).trash.notes); n != 0 {
+		t.Errorf("the expired toast should empty the trash, %d note(s) left", n)
+	}
+}
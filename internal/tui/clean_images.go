@@ -28,6 +28,7 @@ type CleanImagesApp struct {
 
 type cleanupCompleteMsg struct {
 	stats           *services.CleanupStats
+	brokenRefs      []services.ImageReference
 	notesDeleted    int
 	journalsDeleted int
 	err             error
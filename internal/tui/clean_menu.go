@@ -2,6 +2,8 @@ package tui
 
 import (
 	"fmt"
+	"path/filepath"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
@@ -31,8 +33,9 @@ type CleanMenuApp struct {
 	spinner         spinner.Model
 	running         bool
 	done            bool
-	cleanupType     string // "images", "notes", or "journals"
+	cleanupType     string // "images", "broken-links", "notes", "whitespace-notes", or "journals"
 	stats           *services.CleanupStats
+	brokenRefs      []services.ImageReference
 	notesDeleted    int
 	journalsDeleted int
 	width           int
@@ -63,11 +66,21 @@ func NewCleanMenuApp(cfg *config.Config) *CleanMenuApp {
 				description: "Remove unused images and deduplicate duplicates",
 				command:     "images",
 			},
+			{
+				name:        "Check Broken Links",
+				description: "List image links in notes and journals whose file no longer exists",
+				command:     "broken-links",
+			},
 			{
 				name:        "Clean Empty Notes",
 				description: "Remove notes that only contain the default template",
 				command:     "notes",
 			},
+			{
+				name:        "Clean Whitespace-Only Notes",
+				description: "Remove notes that were cleared and contain only whitespace or empty frontmatter",
+				command:     "whitespace-notes",
+			},
 			{
 				name:        "Clean Empty Journals",
 				description: "Remove journal entries that only contain the default template",
@@ -111,6 +124,7 @@ func (m *CleanMenuApp) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.done = false
 				m.running = false
 				m.stats = nil
+				m.brokenRefs = nil
 				m.notesDeleted = 0
 				m.journalsDeleted = 0
 				m.err = nil
@@ -164,6 +178,15 @@ func (m *CleanMenuApp) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.runImageCleanup,
 				)
 			}
+			if selected.command == "broken-links" {
+				// Start the broken image link check
+				m.running = true
+				m.cleanupType = "broken-links"
+				return m, tea.Batch(
+					m.spinner.Tick,
+					m.runBrokenLinksCheck,
+				)
+			}
 			if selected.command == "notes" {
 				// Start the notes cleanup
 				m.running = true
@@ -173,6 +196,15 @@ func (m *CleanMenuApp) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.runNotesCleanup,
 				)
 			}
+			if selected.command == "whitespace-notes" {
+				// Start the whitespace-only notes cleanup
+				m.running = true
+				m.cleanupType = "whitespace-notes"
+				return m, tea.Batch(
+					m.spinner.Tick,
+					m.runWhitespaceNotesCleanup,
+				)
+			}
 			if selected.command == "journals" {
 				// Start the journals cleanup
 				m.running = true
@@ -188,6 +220,7 @@ func (m *CleanMenuApp) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.running = false
 		m.done = true
 		m.stats = msg.stats
+		m.brokenRefs = msg.brokenRefs
 		m.notesDeleted = msg.notesDeleted
 		m.journalsDeleted = msg.journalsDeleted
 		m.err = msg.err
@@ -205,13 +238,19 @@ func (m *CleanMenuApp) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *CleanMenuApp) View() string {
+	if view, small := tooSmallView(m.width, m.height); small {
+		return view
+	}
+
 	// If cleanup is done, show results
 	if m.done {
 		title := "🧹 Cleanup Complete"
 		switch m.cleanupType {
 		case "images":
 			title = "🧹 Image Cleanup"
-		case "notes":
+		case "broken-links":
+			title = "🔗 Broken Image Links"
+		case "notes", "whitespace-notes":
 			title = "🧹 Notes Cleanup"
 		case "journals":
 			title = "🧹 Journals Cleanup"
@@ -221,6 +260,8 @@ func (m *CleanMenuApp) View() string {
 		if m.err != nil {
 			s += errorStyle.Render("❌ Cleanup failed!") + "\n\n"
 			s += statusStyle.Render(fmt.Sprintf("Error: %v", m.err)) + "\n"
+		} else if m.cleanupType == "broken-links" {
+			s += m.renderBrokenRefs()
 		} else {
 			s += successStyle.Render("✓ Cleanup completed successfully!") + "\n\n"
 
@@ -230,6 +271,8 @@ func (m *CleanMenuApp) View() string {
 				s += renderStats(m.stats)
 			case "notes":
 				s += statusStyle.Render(fmt.Sprintf("Deleted %d empty note(s)", m.notesDeleted))
+			case "whitespace-notes":
+				s += statusStyle.Render(fmt.Sprintf("Deleted %d whitespace-only note(s)", m.notesDeleted))
 			case "journals":
 				s += statusStyle.Render(fmt.Sprintf("Deleted %d empty journal(s)", m.journalsDeleted))
 			}
@@ -256,9 +299,15 @@ func (m *CleanMenuApp) View() string {
 		case "images":
 			title = "🧹 Image Cleanup"
 			message = "Cleaning up images..."
+		case "broken-links":
+			title = "🔗 Broken Image Links"
+			message = "Checking image links..."
 		case "notes":
 			title = "🧹 Notes Cleanup"
 			message = "Cleaning up empty notes..."
+		case "whitespace-notes":
+			title = "🧹 Notes Cleanup"
+			message = "Cleaning up whitespace-only notes..."
 		case "journals":
 			title = "🧹 Journals Cleanup"
 			message = "Cleaning up empty journals..."
@@ -326,6 +375,11 @@ func (m *CleanMenuApp) runImageCleanup() tea.Msg {
 	return cleanupCompleteMsg{stats: stats, err: err}
 }
 
+func (m *CleanMenuApp) runBrokenLinksCheck() tea.Msg {
+	refs, err := m.cleanupService.FindBrokenImageReferences()
+	return cleanupCompleteMsg{brokenRefs: refs, err: err}
+}
+
 func (m *CleanMenuApp) runNotesCleanup() tea.Msg {
 	deleted, err := m.cleanupService.CleanEmptyNotes()
 	return cleanupCompleteMsg{notesDeleted: deleted, err: err}
@@ -335,3 +389,44 @@ func (m *CleanMenuApp) runJournalsCleanup() tea.Msg {
 	deleted, err := m.cleanupService.CleanEmptyJournals()
 	return cleanupCompleteMsg{journalsDeleted: deleted, err: err}
 }
+
+func (m *CleanMenuApp) runWhitespaceNotesCleanup() tea.Msg {
+	deleted, err := m.cleanupService.CleanWhitespaceNotes()
+	return cleanupCompleteMsg{notesDeleted: deleted, err: err}
+}
+
+// renderBrokenRefs lists each broken image link as file:line and the missing path,
+// trimmed to fit the screen
+func (m *CleanMenuApp) renderBrokenRefs() string {
+	if len(m.brokenRefs) == 0 {
+		return successStyle.Render("✓ No broken image links found") + "\n"
+	}
+
+	s := errorStyle.Render(fmt.Sprintf("Found %d broken image link(s):", len(m.brokenRefs))) + "\n\n"
+
+	maxItems := len(m.brokenRefs)
+	if m.height > 0 {
+		// Leave room for the title, summary and help lines
+		maxItems = max(m.height-10, 3)
+	}
+	for i, ref := range m.brokenRefs {
+		if i >= maxItems {
+			s += statusStyle.Render(fmt.Sprintf("…and %d more", len(m.brokenRefs)-i)) + "\n"
+			break
+		}
+		s += fmt.Sprintf("%s  %s\n",
+			statusStyle.Render(fmt.Sprintf("%s:%d", m.displayPath(ref.FilePath), ref.LineNum)),
+			ref.ImagePath)
+	}
+	return s
+}
+
+// displayPath shortens a note or journal path to be relative to its directory
+func (m *CleanMenuApp) displayPath(path string) string {
+	for _, dir := range []string{m.cfg.NotesDir, m.cfg.JournalDir} {
+		if rel, err := filepath.Rel(dir, path); err == nil && !strings.HasPrefix(rel, "..") {
+			return rel
+		}
+	}
+	return path
+}
@@ -1,16 +1,21 @@
 package tui
 
 import (
+	"fmt"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/redjax/notetkr/internal/services"
 )
 
 type DashboardModel struct {
-	choices  []string
-	cursor   int
-	selected int
-	width    int
-	height   int
+	choices        []string
+	cursor         int
+	selected       int
+	width          int
+	height         int
+	journalService *services.JournalService
+	stats          *services.JournalStats // Journal streak and entry counts, nil until loaded
 }
 
 var (
@@ -24,26 +29,49 @@ var (
 	selectedItemStyle = lipgloss.NewStyle().
 				Foreground(lipgloss.Color("170")).
 				Bold(true)
+
+	dashboardStatsStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("99"))
 )
 
-func NewDashboard() DashboardModel {
+// NewDashboard creates the main menu, showing journal statistics from journalService
+// once they're loaded
+func NewDashboard(journalService *services.JournalService) DashboardModel {
 	return DashboardModel{
 		choices: []string{
 			"Today's Journal",
 			"Journals",
 			"Notes",
+			"Recent Notes",
+			"Scratchpad",
 			"Search",
 			"Import/Export",
 			"Clean",
 			"Quit",
 		},
-		cursor:   0,
-		selected: -1,
+		cursor:         0,
+		selected:       -1,
+		journalService: journalService,
 	}
 }
 
+// dashboardStatsMsg carries the journal statistics loaded for the dashboard
+type dashboardStatsMsg struct {
+	stats services.JournalStats
+}
+
 func (m DashboardModel) Init() tea.Cmd {
-	return nil
+	if m.journalService == nil {
+		return nil
+	}
+	// Walk the journal in the background so the menu shows straight away
+	return func() tea.Msg {
+		stats, err := m.journalService.GetStats()
+		if err != nil {
+			return nil
+		}
+		return dashboardStatsMsg{stats: stats}
+	}
 }
 
 func (m DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -53,6 +81,10 @@ func (m DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 		return m, nil
 
+	case dashboardStatsMsg:
+		m.stats = &msg.stats
+		return m, nil
+
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "ctrl+c", "q":
@@ -85,19 +117,27 @@ func (m DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, func() tea.Msg {
 					return MenuSelectionMsg{Selection: "notes"}
 				}
-			case 3: // Search
+			case 3: // Recent Notes
+				return m, func() tea.Msg {
+					return MenuSelectionMsg{Selection: "recent-notes"}
+				}
+			case 4: // Scratchpad
+				return m, func() tea.Msg {
+					return MenuSelectionMsg{Selection: "scratch"}
+				}
+			case 5: // Search
 				return m, func() tea.Msg {
 					return MenuSelectionMsg{Selection: "search"}
 				}
-			case 4: // Import/Export
+			case 6: // Import/Export
 				return m, func() tea.Msg {
 					return MenuSelectionMsg{Selection: "import-export"}
 				}
-			case 5: // Clean
+			case 7: // Clean
 				return m, func() tea.Msg {
 					return MenuSelectionMsg{Selection: "clean"}
 				}
-			case 6: // Quit
+			case 8: // Quit
 				return m, tea.Quit
 			}
 		}
@@ -107,7 +147,11 @@ func (m DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m DashboardModel) View() string {
-	s := titleStyle.Render("📝 Notetkr") + "\n\n"
+	s := titleStyle.Render("📝 Notetkr") + "\n"
+	if m.stats != nil {
+		s += dashboardStatsStyle.Render(journalStatsLine(*m.stats))
+	}
+	s += "\n\n"
 
 	for i, choice := range m.choices {
 		cursor := "  "
@@ -135,6 +179,22 @@ func (m DashboardModel) View() string {
 	return s
 }
 
+// journalStatsLine describes the journal streaks and entry count in one line, e.g.
+// "🔥 3 day streak • longest 12 days • 40 entries"
+func journalStatsLine(stats services.JournalStats) string {
+	return fmt.Sprintf("🔥 %d day streak • longest %s • %s",
+		stats.CurrentStreak, countNoun(stats.LongestStreak, "day", "days"),
+		countNoun(stats.TotalEntries, "entry", "entries"))
+}
+
+// countNoun formats a count with the singular or plural noun, e.g. "1 day" or "3 days"
+func countNoun(n int, singular, plural string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, singular)
+	}
+	return fmt.Sprintf("%d %s", n, plural)
+}
+
 type MenuSelectionMsg struct {
 	Selection string
 }
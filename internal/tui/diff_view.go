@@ -0,0 +1,225 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	diffAddStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	diffRemoveStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	diffHunkStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("86"))
+)
+
+// diffContextLines is how many unchanged lines are shown around each change
+const diffContextLines = 2
+
+// maxDiffCells caps the LCS table size; larger changes are shown as a plain replace
+const maxDiffCells = 4_000_000
+
+// diffLine is one line of a line diff: ' ' unchanged, '-' removed, '+' added
+type diffLine struct {
+	op      byte
+	text    string
+	oldLine int // 1-based line in the old text, 0 for added lines
+	newLine int // 1-based line in the new text, 0 for removed lines
+}
+
+// diffLines returns a line diff turning before into after, using the longest common
+// subsequence of lines so unchanged lines between edits stay unchanged
+func diffLines(before, after string) []diffLine {
+	a := strings.Split(before, "\n")
+	b := strings.Split(after, "\n")
+
+	// Common prefix and suffix don't need the table
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var result []diffLine
+	for i := 0; i < prefix; i++ {
+		result = append(result, diffLine{op: ' ', text: a[i], oldLine: i + 1, newLine: i + 1})
+	}
+
+	midA := a[prefix : len(a)-suffix]
+	midB := b[prefix : len(b)-suffix]
+	result = append(result, diffMiddle(midA, midB, prefix)...)
+
+	for i := 0; i < suffix; i++ {
+		oldIdx := len(a) - suffix + i
+		newIdx := len(b) - suffix + i
+		result = append(result, diffLine{op: ' ', text: a[oldIdx], oldLine: oldIdx + 1, newLine: newIdx + 1})
+	}
+
+	return result
+}
+
+// diffMiddle diffs the differing middle of two texts whose first offset lines match
+func diffMiddle(a, b []string, offset int) []diffLine {
+	var result []diffLine
+
+	if len(a)*len(b) > maxDiffCells {
+		for i, line := range a {
+			result = append(result, diffLine{op: '-', text: line, oldLine: offset + i + 1})
+		}
+		for j, line := range b {
+			result = append(result, diffLine{op: '+', text: line, newLine: offset + j + 1})
+		}
+		return result
+	}
+
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			result = append(result, diffLine{op: ' ', text: a[i], oldLine: offset + i + 1, newLine: offset + j + 1})
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] > lcs[i+1][j]):
+			result = append(result, diffLine{op: '+', text: b[j], newLine: offset + j + 1})
+			j++
+		default:
+			result = append(result, diffLine{op: '-', text: a[i], oldLine: offset + i + 1})
+			i++
+		}
+	}
+
+	return result
+}
+
+// unifiedDiff formats a line diff as git-style hunks with a few lines of context,
+// one string per output line. It returns nil when nothing changed.
+func unifiedDiff(lines []diffLine) []string {
+	var out []string
+
+	for start := 0; start < len(lines); {
+		// Find the next change
+		first := start
+		for first < len(lines) && lines[first].op == ' ' {
+			first++
+		}
+		if first == len(lines) {
+			break
+		}
+
+		// Extend the hunk until the unchanged run between changes is too long to bridge
+		last := first
+		for k := first; k < len(lines); k++ {
+			if lines[k].op != ' ' {
+				last = k
+			} else if k-last > 2*diffContextLines {
+				break
+			}
+		}
+
+		from := max(first-diffContextLines, start)
+		to := min(last+diffContextLines+1, len(lines))
+		out = append(out, hunkHeader(lines[from:to]))
+		for _, line := range lines[from:to] {
+			out = append(out, string(line.op)+line.text)
+		}
+		start = to
+	}
+
+	return out
+}
+
+// hunkHeader returns the "@@ -old,count +new,count @@" line for a hunk
+func hunkHeader(hunk []diffLine) string {
+	oldStart, newStart, oldCount, newCount := 0, 0, 0, 0
+	for _, line := range hunk {
+		if line.op != '+' {
+			if oldStart == 0 {
+				oldStart = line.oldLine
+			}
+			oldCount++
+		}
+		if line.op != '-' {
+			if newStart == 0 {
+				newStart = line.newLine
+			}
+			newCount++
+		}
+	}
+	return fmt.Sprintf("@@ -%d,%d +%d,%d @@", oldStart, oldCount, newStart, newCount)
+}
+
+// diffOverlay shows the unsaved changes in an editor as a scrollable unified diff
+type diffOverlay struct {
+	lines  []string
+	offset int
+}
+
+// newDiffOverlay diffs the saved content against the editor's current content
+func newDiffOverlay(saved, current string) *diffOverlay {
+	return &diffOverlay{lines: unifiedDiff(diffLines(saved, current))}
+}
+
+// update scrolls the diff, reporting true when the key closes it. height is the
+// number of lines the overlay is shown in.
+func (d *diffOverlay) update(key string, height int) bool {
+	page := max(height-2, 1)
+	maxOffset := max(len(d.lines)-page, 0)
+	switch key {
+	case "esc", "q", "D":
+		return true
+	case "down", "j":
+		d.offset = min(d.offset+1, maxOffset)
+	case "up", "k":
+		d.offset = max(d.offset-1, 0)
+	case "ctrl+d", "pgdown", " ":
+		d.offset = min(d.offset+page, maxOffset)
+	case "ctrl+u", "pgup":
+		d.offset = max(d.offset-page, 0)
+	}
+	return false
+}
+
+// view renders the diff in height lines from the current scroll position
+func (d *diffOverlay) view(height int) string {
+	if len(d.lines) == 0 {
+		return "No unsaved changes\n"
+	}
+
+	page := max(height-2, 1)
+	end := min(d.offset+page, len(d.lines))
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Unsaved changes (lines %d-%d of %d):\n\n", d.offset+1, end, len(d.lines)))
+	for _, line := range d.lines[d.offset:end] {
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			b.WriteString(diffHunkStyle.Render(line))
+		case strings.HasPrefix(line, "+"):
+			b.WriteString(diffAddStyle.Render(line))
+		case strings.HasPrefix(line, "-"):
+			b.WriteString(diffRemoveStyle.Render(line))
+		default:
+			b.WriteString(line)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,51 @@
+package tui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiffLines(t *testing.T) {
+	before := "# Title\none\ntwo\nthree\nfour\nfive\nsix\nseven\neight"
+	after := "# Title\none\n2\nthree\nfour\nfive\nsix\nseven\neight\nnine"
+
+	var added, removed []string
+	for _, line := range diffLines(before, after) {
+		switch line.op {
+		case '+':
+			added = append(added, line.text)
+		case '-':
+			removed = append(removed, line.text)
+		}
+	}
+	if want := []string{"2", "nine"}; !reflect.DeepEqual(added, want) {
+		t.Errorf("added = %q, want %q", added, want)
+	}
+	if want := []string{"two"}; !reflect.DeepEqual(removed, want) {
+		t.Errorf("removed = %q, want %q", removed, want)
+	}
+
+	// The two changes are too far apart to share a hunk
+	got := unifiedDiff(diffLines(before, after))
+	want := []string{
+		"@@ -1,5 +1,5 @@",
+		" # Title",
+		" one",
+		"-two",
+		"+2",
+		" three",
+		" four",
+		"@@ -8,2 +8,3 @@",
+		" seven",
+		" eight",
+		"+nine",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unifiedDiff =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	if got := unifiedDiff(diffLines(before, before)); got != nil {
+		t.Errorf("unchanged text gave a diff: %q", got)
+	}
+}
@@ -1,6 +1,393 @@
 package tui
 
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/redjax/notetkr/internal/services"
+	"github.com/redjax/notetkr/internal/utils"
+)
+
 // isWordChar returns true if the character is part of a word (alphanumeric or underscore)
 func isWordChar(r rune) bool {
 	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_'
 }
+
+// markdownFormat is an inline markdown format the insert-mode shortcuts wrap words in
+type markdownFormat struct {
+	before, after string
+	emptyAfter    string // Closing text when there's no word to wrap, if it differs from after
+	cursorBack    int    // Runes before the end of the wrapped word to leave the cursor at
+}
+
+var (
+	boldFormat   = markdownFormat{before: "**", after: "**"}
+	italicFormat = markdownFormat{before: "*", after: "*"}
+	// A wrapped word becomes the link text with the cursor in the empty (url)
+	linkFormat = markdownFormat{before: "[", after: "]()", emptyAfter: "](url)", cursorBack: 1}
+)
+
+// formatWordAt applies format to the word touching rune column col of line, or inserts
+// the format's empty skeleton at col when there's no word there. Returns the new line
+// and the rune column to move the cursor to: after the formatted word, or between the
+// skeleton's markers.
+func formatWordAt(line string, col int, format markdownFormat) (string, int) {
+	runes := []rune(line)
+	col = min(max(col, 0), len(runes))
+
+	start, end := col, col
+	for start > 0 && isWordChar(runes[start-1]) {
+		start--
+	}
+	for end < len(runes) && isWordChar(runes[end]) {
+		end++
+	}
+
+	head, tail := string(runes[:start]), string(runes[end:])
+	before := []rune(format.before)
+	if start == end {
+		after := format.after
+		if format.emptyAfter != "" {
+			after = format.emptyAfter
+		}
+		return head + format.before + after + tail, col + len(before)
+	}
+
+	word := string(runes[start:end])
+	wrapped := format.before + word + format.after
+	return head + wrapped + tail, start + len([]rune(wrapped)) - format.cursorBack
+}
+
+// taskLineRegex matches markdown task list items like "- [ ] todo" or "* [x] done"
+var taskLineRegex = regexp.MustCompile(`^\s*[-*+]\s+\[[ xX]\]`)
+
+// findTaskLines returns the zero-based line numbers of all task list items in content
+func findTaskLines(content string) []int {
+	var taskLines []int
+	for i, line := range strings.Split(content, "\n") {
+		if taskLineRegex.MatchString(line) {
+			taskLines = append(taskLines, i)
+		}
+	}
+	return taskLines
+}
+
+// adjacentTaskLine returns the task line after (forward) or before the current line, or -1 if there is none
+func adjacentTaskLine(content string, currentLine int, forward bool) int {
+	taskLines := findTaskLines(content)
+	if forward {
+		for _, line := range taskLines {
+			if line > currentLine {
+				return line
+			}
+		}
+	} else {
+		for i := len(taskLines) - 1; i >= 0; i-- {
+			if taskLines[i] < currentLine {
+				return taskLines[i]
+			}
+		}
+	}
+	return -1
+}
+
+// Lines the editors reserve around the textarea: title, mode and status above, help below.
+// Focus mode keeps only a one-line status indicator.
+const (
+	editorChromeLines = 7
+	focusChromeLines  = 1
+)
+
+// editorTextareaHeight returns the textarea height for a window windowHeight lines tall,
+// with extraLines more reserved for chrome outside focus mode. It is never less than 1.
+func editorTextareaHeight(windowHeight, extraLines int, focus bool) int {
+	height := windowHeight - editorChromeLines - extraLines
+	if focus {
+		height = windowHeight - focusChromeLines
+	}
+	return max(height, 1)
+}
+
+// focusIndicator is the single status line focus mode shows under the textarea: a
+// pending status message if there is one, otherwise whether there are unsaved changes
+func focusIndicator(status string, unsaved bool) string {
+	switch {
+	case status != "":
+		return status
+	case unsaved:
+		return "● unsaved • F: exit focus"
+	default:
+		return "F: exit focus"
+	}
+}
+
+// wordCountStatus describes a word count and its estimated reading time for the
+// editors' title line, e.g. "312 words • 2 min read"
+func wordCountStatus(words int) string {
+	unit := "words"
+	if words == 1 {
+		unit = "word"
+	}
+	return fmt.Sprintf("%d %s • %d min read", words, unit, services.ReadingMinutes(words))
+}
+
+// imageBaseNameRegex matches runs of characters that aren't safe in an attachment filename
+var imageBaseNameRegex = regexp.MustCompile(`[^a-z0-9]+`)
+
+// clipboardImageBaseName derives a pasted image's base name from the file being edited,
+// e.g. "Meeting Notes.md" -> "meeting-notes". Falls back to "image" if nothing usable remains.
+func clipboardImageBaseName(filePath string) string {
+	name := strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
+	name = imageBaseNameRegex.ReplaceAllString(strings.ToLower(name), "-")
+	name = strings.Trim(name, "-")
+	if name == "" || name == "." {
+		return "image"
+	}
+	return name
+}
+
+// imagePaste is a clipboard image paste waiting to be saved as an attachment
+type imagePaste struct {
+	imgsDir  string
+	baseName string
+}
+
+// imagePastedMsg reports the paste at the head of a pasteQueue saved, or why it wasn't
+type imagePastedMsg struct {
+	filename string
+	err      error
+}
+
+// pasteQueue holds an editor's clipboard image pastes, oldest first. Pastes are saved
+// one at a time in a tea.Cmd, so a slow clipboard doesn't freeze the editor and quick
+// repeated pastes are linked in the order they were made.
+type pasteQueue []imagePaste
+
+// push queues p, returning the command that saves it unless an earlier paste is still saving
+func (q pasteQueue) push(h *utils.ClipboardImageHandler, p imagePaste) (pasteQueue, tea.Cmd) {
+	q = append(q, p)
+	if len(q) > 1 {
+		return q, nil
+	}
+	return q, savePaste(h, p)
+}
+
+// pop drops the paste that was just saved, returning the command that saves the next one
+func (q pasteQueue) pop(h *utils.ClipboardImageHandler) (pasteQueue, tea.Cmd) {
+	if len(q) > 0 {
+		q = q[1:]
+	}
+	if len(q) == 0 {
+		return nil, nil
+	}
+	return q, savePaste(h, q[0])
+}
+
+// savePaste returns the command that saves the image (or image file) on the clipboard for p
+func savePaste(h *utils.ClipboardImageHandler, p imagePaste) tea.Cmd {
+	return func() tea.Msg {
+		if !h.HasImage() {
+			return imagePastedMsg{err: fmt.Errorf("no image or image file path in clipboard")}
+		}
+		filename, err := h.SaveClipboardImage(p.imgsDir, p.baseName)
+		return imagePastedMsg{filename: filename, err: err}
+	}
+}
+
+// isVerticalMoveKey reports whether key moves the cursor up or down in normal mode
+func isVerticalMoveKey(key string) bool {
+	switch key {
+	case "j", "k", "up", "down":
+		return true
+	}
+	return false
+}
+
+// verticalMove computes where the cursor lands when moving delta lines from
+// (line, col), keeping a goal column like real editors do. goal is the column
+// remembered from earlier vertical moves, or -1 if none. Returns the new line,
+// column and goal column.
+func verticalMove(lines []string, line, col, goal, delta int) (int, int, int) {
+	if goal < 0 {
+		goal = col
+	}
+
+	target := line + delta
+	if target < 0 {
+		target = 0
+	}
+	if target > len(lines)-1 {
+		target = len(lines) - 1
+	}
+
+	// Restore the goal column if the line is long enough, otherwise clamp to its end
+	newCol := goal
+	if lineLen := len([]rune(lines[target])); newCol > lineLen {
+		newCol = lineLen
+	}
+
+	return target, newCol, goal
+}
+
+// splitFrontMatter splits content into its leading --- delimited frontmatter block
+// (through the closing delimiter's newline) and the remaining body. ok is false if
+// the content doesn't start with a complete frontmatter block.
+func splitFrontMatter(content string) (header, body string, ok bool) {
+	lines := strings.SplitAfter(content, "\n")
+	if len(lines) < 2 || strings.TrimSpace(lines[0]) != "---" {
+		return "", content, false
+	}
+
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			header = strings.Join(lines[:i+1], "")
+			return header, content[len(header):], true
+		}
+	}
+	return "", content, false
+}
+
+// frontMatterSummary condenses a frontmatter block into one line of its non-empty
+// fields, e.g. "tags: work, meeting". List items are joined onto their key.
+func frontMatterSummary(header string) string {
+	var fields []string
+	var key string
+	var values []string
+
+	flush := func() {
+		if key != "" && len(values) > 0 {
+			fields = append(fields, key+": "+strings.Join(values, ", "))
+		}
+		key, values = "", nil
+	}
+
+	for _, line := range strings.Split(header, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || trimmed == "---" {
+			continue
+		}
+
+		if item, isItem := strings.CutPrefix(trimmed, "- "); isItem {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+			continue
+		}
+
+		flush()
+		name, value, found := strings.Cut(trimmed, ":")
+		if !found {
+			continue
+		}
+		key = strings.TrimSpace(name)
+		value = strings.Trim(strings.TrimSpace(value), "[]")
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+	}
+	flush()
+
+	if len(fields) == 0 {
+		return "(empty)"
+	}
+	return strings.Join(fields, " • ")
+}
+
+// foldedLine maps a line of the full content to the line it is shown on while a
+// frontmatter block of headerLines lines is folded. Lines inside the block land on
+// the first body line.
+func foldedLine(line, headerLines int) int {
+	if line < headerLines {
+		return 0
+	}
+	return line - headerLines
+}
+
+// unfoldedLine maps a line shown while the frontmatter is folded back to its line
+// in the full content
+func unfoldedLine(line, headerLines int) int {
+	return line + headerLines
+}
+
+// timeSectionRegex matches time-stamped journal headings like "## 14:30" or "### 9:05 standup"
+var timeSectionRegex = regexp.MustCompile(`^#{1,6}\s+\d{1,2}:\d{2}\b`)
+
+// lastTimeSectionLine returns the zero-based line of the last time section heading in content, or -1 if there is none
+func lastTimeSectionLine(content string) int {
+	lines := strings.Split(content, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if timeSectionRegex.MatchString(lines[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// isToday reports whether date falls on the current local day
+func isToday(date time.Time) bool {
+	y1, m1, d1 := date.Date()
+	y2, m2, d2 := time.Now().Date()
+	return y1 == y2 && m1 == m2 && d1 == d2
+}
+
+// deleteLineAt removes a zero-based line from content. It returns the new content and
+// the line the cursor belongs on: the same line number, or the new last line when the
+// last line was deleted. Deleting the only line leaves empty content. ok is false if
+// line is out of range.
+func deleteLineAt(content string, line int) (newContent string, cursorLine int, ok bool) {
+	lines := strings.Split(content, "\n")
+	if line < 0 || line >= len(lines) {
+		return content, line, false
+	}
+
+	lines = append(lines[:line], lines[line+1:]...)
+	if len(lines) == 0 {
+		return "", 0, true
+	}
+	if line >= len(lines) {
+		line = len(lines) - 1
+	}
+	return strings.Join(lines, "\n"), line, true
+}
+
+// listItemRegex matches markdown list items, including empty ones like "- "
+var listItemRegex = regexp.MustCompile(`^\s*[-*+](\s|$)`)
+
+// moveTaskLine swaps a list item in a journal's "## Tasks" section with the one delta
+// lines away (-1 for up, 1 for down), leaving every other line untouched. It returns
+// the new content and the moved line's new number. ok is false if the line isn't a
+// list item in the Tasks section or the neighbouring line isn't one too.
+func moveTaskLine(content string, line, delta int) (newContent string, newLine int, ok bool) {
+	lines := strings.Split(content, "\n")
+
+	// Find the section between "## Tasks" and the next "##" heading
+	start, end := -1, len(lines)
+	for i, l := range lines {
+		trimmed := strings.TrimSpace(l)
+		if start < 0 {
+			if strings.HasPrefix(trimmed, "## Tasks") {
+				start = i
+			}
+		} else if strings.HasPrefix(trimmed, "##") {
+			end = i
+			break
+		}
+	}
+
+	target := line + delta
+	if start < 0 || line <= start || line >= end || target <= start || target >= end {
+		return content, line, false
+	}
+	if !listItemRegex.MatchString(lines[line]) || !listItemRegex.MatchString(lines[target]) {
+		return content, line, false
+	}
+
+	lines[line], lines[target] = lines[target], lines[line]
+	return strings.Join(lines, "\n"), target, true
+}
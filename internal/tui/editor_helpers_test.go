@@ -0,0 +1,356 @@
+package tui
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/redjax/notetkr/internal/services"
+)
+
+func TestFindTaskLines(t *testing.T) {
+	content := "# Journal Entry\n\n## Tasks\n\n- [ ] write report\n- plain bullet\n  - [x] nested done\n* [X] star task\nnot a task [ ]\n"
+
+	got := findTaskLines(content)
+	want := []int{4, 6, 7}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("findTaskLines() = %v, want %v", got, want)
+	}
+}
+
+func TestAdjacentTaskLine(t *testing.T) {
+	content := "intro\n- [ ] one\ntext\n- [x] two\nend"
+
+	tests := []struct {
+		current int
+		forward bool
+		want    int
+	}{
+		{0, true, 1},
+		{1, true, 3},
+		{3, true, -1},
+		{4, false, 3},
+		{3, false, 1},
+		{1, false, -1},
+	}
+
+	for _, tt := range tests {
+		if got := adjacentTaskLine(content, tt.current, tt.forward); got != tt.want {
+			t.Errorf("adjacentTaskLine(line %d, forward=%v) = %d, want %d", tt.current, tt.forward, got, tt.want)
+		}
+	}
+}
+
+func TestClipboardImageBaseName(t *testing.T) {
+	tests := []struct {
+		filePath string
+		want     string
+	}{
+		{"/notes/work/Meeting Notes.md", "meeting-notes"},
+		{"/notes/todo_list.md", "todo-list"},
+		{"/journal/2025/01/2025-01-15.md", "2025-01-15"},
+		{"/notes/!!!.md", "image"},
+		{"", "image"},
+	}
+
+	for _, tt := range tests {
+		if got := clipboardImageBaseName(tt.filePath); got != tt.want {
+			t.Errorf("clipboardImageBaseName(%q) = %q, want %q", tt.filePath, got, tt.want)
+		}
+	}
+}
+
+func TestVerticalMoveKeepsGoalColumn(t *testing.T) {
+	lines := []string{
+		"a long line of text",
+		"short",
+		"",
+		"another long line here",
+	}
+
+	// Start at column 10 on the first line and move down through short lines
+	line, col, goal := verticalMove(lines, 0, 10, -1, 1)
+	if line != 1 || col != 5 || goal != 10 {
+		t.Fatalf("down to short line = (%d, %d, %d), want (1, 5, 10)", line, col, goal)
+	}
+
+	line, col, goal = verticalMove(lines, line, col, goal, 1)
+	if line != 2 || col != 0 || goal != 10 {
+		t.Fatalf("down to empty line = (%d, %d, %d), want (2, 0, 10)", line, col, goal)
+	}
+
+	// The goal column is restored once the line is long enough
+	line, col, goal = verticalMove(lines, line, col, goal, 1)
+	if line != 3 || col != 10 || goal != 10 {
+		t.Fatalf("down to long line = (%d, %d, %d), want (3, 10, 10)", line, col, goal)
+	}
+
+	// Moving past the last line stays put
+	line, col, _ = verticalMove(lines, line, col, goal, 1)
+	if line != 3 || col != 10 {
+		t.Errorf("down past end = (%d, %d), want (3, 10)", line, col)
+	}
+
+	// A reset goal (-1) uses the current column instead
+	_, col, goal = verticalMove(lines, 1, 2, -1, -1)
+	if col != 2 || goal != 2 {
+		t.Errorf("up with reset goal = (col %d, goal %d), want (2, 2)", col, goal)
+	}
+}
+
+func TestFrontMatterFoldLines(t *testing.T) {
+	content := "---\ntags:\n  - work\n  - meeting\nkeywords: [standup]\nweight:\n---\n# Title\n\nbody\n"
+
+	header, body, ok := splitFrontMatter(content)
+	if !ok {
+		t.Fatal("expected frontmatter to be found")
+	}
+	if header+body != content {
+		t.Errorf("header + body should rebuild the content, got %q + %q", header, body)
+	}
+	if body != "# Title\n\nbody\n" {
+		t.Errorf("body = %q", body)
+	}
+
+	headerLines := strings.Count(header, "\n")
+	if headerLines != 7 {
+		t.Fatalf("headerLines = %d, want 7", headerLines)
+	}
+
+	tests := []struct {
+		line, folded int
+	}{
+		{0, 0}, // Inside the frontmatter
+		{6, 0}, // Closing delimiter
+		{7, 0}, // "# Title"
+		{9, 2}, // "body"
+	}
+	for _, tt := range tests {
+		if got := foldedLine(tt.line, headerLines); got != tt.folded {
+			t.Errorf("foldedLine(%d) = %d, want %d", tt.line, got, tt.folded)
+		}
+	}
+	if got := unfoldedLine(2, headerLines); got != 9 {
+		t.Errorf("unfoldedLine(2) = %d, want 9", got)
+	}
+
+	if got, want := frontMatterSummary(header), "tags: work, meeting • keywords: standup"; got != want {
+		t.Errorf("frontMatterSummary() = %q, want %q", got, want)
+	}
+
+	if _, _, ok := splitFrontMatter("# No frontmatter\n---\n"); ok {
+		t.Error("content not starting with --- should have no frontmatter")
+	}
+	if _, _, ok := splitFrontMatter("---\ntags: open\n"); ok {
+		t.Error("unterminated frontmatter should not be folded")
+	}
+}
+
+func TestNotesEditorFoldKeepsContent(t *testing.T) {
+	m := newTestNotesEditor(t)
+	content := "---\ntags: work\n---\n# Note\n"
+	m.textarea.SetValue(content)
+	m.initialContent = content
+	m.lastContent = content
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z")})
+	m = updated.(NotesEditorModel)
+	if m.textarea.Value() != "# Note\n" {
+		t.Errorf("folded textarea = %q, want only the body", m.textarea.Value())
+	}
+	if m.content() != content || m.hasUnsavedChanges() {
+		t.Errorf("folding changed the note content: %q", m.content())
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z")})
+	m = updated.(NotesEditorModel)
+	if m.textarea.Value() != content || m.foldedHeader != "" {
+		t.Errorf("unfolded textarea = %q, want %q", m.textarea.Value(), content)
+	}
+}
+
+func TestDeleteLineAt(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		line       int
+		want       string
+		wantCursor int
+	}{
+		{"only line", "just one line", 0, "", 0},
+		{"last line", "one\ntwo\nthree", 2, "one\ntwo", 1},
+		{"middle line", "one\ntwo\nthree", 1, "one\nthree", 1},
+		{"first line", "one\ntwo", 0, "two", 0},
+		{"trailing empty line", "one\n", 1, "one", 0},
+	}
+
+	for _, tt := range tests {
+		got, cursor, ok := deleteLineAt(tt.content, tt.line)
+		if !ok || got != tt.want || cursor != tt.wantCursor {
+			t.Errorf("%s: deleteLineAt() = %q, %d, %v; want %q, %d", tt.name, got, cursor, ok, tt.want, tt.wantCursor)
+		}
+	}
+
+	if _, _, ok := deleteLineAt("one", 3); ok {
+		t.Error("deleting a line past the end should fail")
+	}
+}
+
+func TestEditorsDeleteLineCursor(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		line     int
+		want     string
+		wantLine int
+	}{
+		{"only line", "héllo wörld", 0, "", 0},
+		{"last line", "one\ntwo\nthree", 2, "one\ntwo", 1},
+		{"middle line", "one\ntwo\nthree", 1, "one\nthree", 1},
+	}
+
+	for _, tt := range tests {
+		notes := newTestNotesEditor(t)
+		notes.textarea.SetValue(tt.content)
+		notes.moveToLine(tt.line)
+		notes.deleteLine()
+
+		journal := NewJournalEditor(services.NewJournalService(t.TempDir(), time.Sunday), DefaultOptions(), time.Now())
+		journal.textarea.SetValue(tt.content)
+		journal.moveToLine(tt.line)
+		journal.deleteLine()
+
+		for editor, ta := range map[string]textarea.Model{"notes": notes.textarea, "journal": journal.textarea} {
+			if got := ta.Value(); got != tt.want {
+				t.Errorf("%s editor, %s: content = %q, want %q", editor, tt.name, got, tt.want)
+			}
+			if ta.Line() != tt.wantLine || ta.LineInfo().ColumnOffset != 0 {
+				t.Errorf("%s editor, %s: cursor at line %d col %d, want the start of line %d",
+					editor, tt.name, ta.Line(), ta.LineInfo().ColumnOffset, tt.wantLine)
+			}
+		}
+	}
+}
+
+func TestMoveTaskLine(t *testing.T) {
+	content := "# Journal Entry\n\n## Tasks\n\n- [ ] first\n- [x] second\n  - [ ] third\n\n## Notes\n\n- not a task"
+
+	tests := []struct {
+		name     string
+		line     int
+		delta    int
+		want     string
+		wantLine int
+		wantOK   bool
+	}{
+		{"down", 4, 1, "# Journal Entry\n\n## Tasks\n\n- [x] second\n- [ ] first\n  - [ ] third\n\n## Notes\n\n- not a task", 5, true},
+		{"up", 6, -1, "# Journal Entry\n\n## Tasks\n\n- [ ] first\n  - [ ] third\n- [x] second\n\n## Notes\n\n- not a task", 5, true},
+		{"first task up onto blank line", 4, -1, content, 4, false},
+		{"last task down onto blank line", 6, 1, content, 6, false},
+		{"heading", 2, 1, content, 2, false},
+		{"outside Tasks section", 10, -1, content, 10, false},
+	}
+
+	for _, tt := range tests {
+		got, line, ok := moveTaskLine(content, tt.line, tt.delta)
+		if got != tt.want || line != tt.wantLine || ok != tt.wantOK {
+			t.Errorf("%s: moveTaskLine() = %q, %d, %v; want %q, %d, %v", tt.name, got, line, ok, tt.want, tt.wantLine, tt.wantOK)
+		}
+	}
+
+	// The editor keeps the cursor on the moved task and can undo the move
+	m := NewJournalEditor(services.NewJournalService(t.TempDir(), time.Sunday), DefaultOptions(), time.Now())
+	m.textarea.SetValue(content)
+	m.trackContentChange()
+	m.moveToLine(4)
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}, Alt: true})
+	m = updated.(JournalEditorModel)
+	if m.textarea.Line() != 5 || !strings.Contains(m.textarea.Value(), "- [x] second\n- [ ] first") {
+		t.Errorf("after alt+j: line %d, content %q", m.textarea.Line(), m.textarea.Value())
+	}
+	m.undo()
+	if m.textarea.Value() != content {
+		t.Errorf("undo after moving a task = %q, want the original", m.textarea.Value())
+	}
+}
+
+func TestFormatWordAt(t *testing.T) {
+	tests := []struct {
+		name       string
+		line       string
+		col        int
+		format     markdownFormat
+		want       string
+		wantCursor int
+	}{
+		{"bold word", "make this bold", 6, boldFormat, "make **this** bold", 13},
+		{"bold at word end", "make this", 9, boldFormat, "make **this**", 13},
+		{"bold nothing", "a  b", 2, boldFormat, "a **** b", 4},
+		{"italic word", "so ünïcode", 1, italicFormat, "*so* ünïcode", 4},
+		{"link word", "see docs now", 5, linkFormat, "see [docs]() now", 11},
+		{"link skeleton", "", 0, linkFormat, "[](url)", 1},
+	}
+
+	for _, tt := range tests {
+		got, cursor := formatWordAt(tt.line, tt.col, tt.format)
+		if got != tt.want || cursor != tt.wantCursor {
+			t.Errorf("%s: formatWordAt() = %q, %d; want %q, %d", tt.name, got, cursor, tt.want, tt.wantCursor)
+		}
+	}
+}
+
+func TestNotesEditorFormatShortcutsUndo(t *testing.T) {
+	m := newTestNotesEditor(t)
+	m.mode = ModeInsert
+	m.moveToLine(1)
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("\nimportant point")})
+	m = updated.(NotesEditorModel)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlB})
+	m = updated.(NotesEditorModel)
+	if got := m.textarea.Value(); got != "# Note\n\nimportant **point**" {
+		t.Fatalf("ctrl+b content = %q", got)
+	}
+	if m.textarea.Line() != 2 || m.textarea.LineInfo().ColumnOffset != 19 {
+		t.Errorf("cursor at line %d col %d, want after the bold word", m.textarea.Line(), m.textarea.LineInfo().ColumnOffset)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlZ})
+	m = updated.(NotesEditorModel)
+	if got := m.textarea.Value(); got != "# Note\n\nimportant point" {
+		t.Errorf("undo after ctrl+b content = %q", got)
+	}
+}
+
+func TestPastedImagesAreSavedOneAtATime(t *testing.T) {
+	m := newTestNotesEditor(t)
+
+	var first, second tea.Cmd
+	m.pastes, first = m.pastes.push(m.clipboardHandler, imagePaste{baseName: "note"})
+	m.pastes, second = m.pastes.push(m.clipboardHandler, imagePaste{baseName: "note"})
+	if first == nil || second != nil {
+		t.Fatal("only the first paste should start saving while it is in progress")
+	}
+
+	updated, next := m.Update(imagePastedMsg{filename: "note-0123456789ab.png"})
+	m = updated.(NotesEditorModel)
+	if !strings.Contains(m.textarea.Value(), "![Pasted image](<.attachments/imgs/note-0123456789ab.png>)") {
+		t.Errorf("content = %q, want the pasted image linked", m.textarea.Value())
+	}
+	if len(m.pastes) != 1 || next == nil {
+		t.Fatalf("the queued paste should start saving once the first is done (%d left)", len(m.pastes))
+	}
+
+	updated, next = m.Update(imagePastedMsg{err: fmt.Errorf("no image data in clipboard")})
+	m = updated.(NotesEditorModel)
+	if len(m.pastes) != 0 || next != nil {
+		t.Errorf("queue should be empty, %d left", len(m.pastes))
+	}
+	if !strings.HasPrefix(m.saveMsg, "❌") {
+		t.Errorf("saveMsg = %q, want the error", m.saveMsg)
+	}
+}
@@ -0,0 +1,157 @@
+package tui
+
+// EditorKeymap selects the key binding style of the notes and journal editors
+type EditorKeymap string
+
+const (
+	KeymapVim   EditorKeymap = "vim"   // Modal: NORMAL mode for commands and movement, INSERT mode for typing
+	KeymapEmacs EditorKeymap = "emacs" // A single editing mode with ctrl/alt shortcuts
+)
+
+// modal reports whether the editors use vim's NORMAL and INSERT modes. Without
+// them the editors stay in insert mode and commands come from ctrl/alt shortcuts.
+func (k EditorKeymap) modal() bool {
+	return k != KeymapEmacs
+}
+
+// initialMode is the mode the editors open in
+func (k EditorKeymap) initialMode() EditorMode {
+	if k.modal() {
+		return ModeNormal
+	}
+	return ModeInsert
+}
+
+// placeholder is the hint shown in an empty editor
+func (k EditorKeymap) placeholder() string {
+	if k.modal() {
+		return "Press 'i' to enter insert mode and start writing..."
+	}
+	return "Start writing..."
+}
+
+// Editor actions the keymaps bind keys to. Like the browser actions, their values are
+// not valid key names, so editorAction can substitute them into a key switch.
+const (
+	editorActionSave       = "<save>"
+	editorActionUndo       = "<undo>"
+	editorActionRedo       = "<redo>"
+	editorActionPreview    = "<preview>"
+	editorActionBack       = "<back>" // Leave the editor, asking first about unsaved changes
+	editorActionPasteImage = "<paste-image>"
+	editorActionBold       = "<bold>"
+	editorActionItalic     = "<italic>"
+	editorActionLink       = "<link>"
+	editorActionFocus      = "<focus>"
+	editorActionDiff       = "<diff>"
+	editorActionNextEntry  = "<next-entry>" // The next note in the directory, or the next day's journal entry
+	editorActionPrevEntry  = "<prev-entry>"
+	editorActionDeleteLine = "<delete-line>"
+	editorActionSelect     = "<select-lines>"
+	editorActionPasteLines = "<paste-lines>"
+	editorActionNextTask   = "<next-task>" // Journal editor only
+	editorActionPrevTask   = "<prev-task>" // Journal editor only
+	editorActionFind       = "<find>"      // Notes editor only
+	editorActionFindNext   = "<find-next>" // Notes editor only
+	editorActionFindPrev   = "<find-prev>" // Notes editor only
+	editorActionReplace    = "<replace>"   // Notes editor only
+	editorActionFold       = "<fold>"      // Notes editor only
+	editorActionBacklinks  = "<backlinks>" // Notes editor only
+)
+
+// vimNormalBindings are the shared actions in vim's NORMAL mode. Its movement and
+// insert keys (hjkl, w/b, i/a/o, x, ...) stay in the editors' own switches, since
+// the textarea has its own keys for them in the emacs keymap.
+var vimNormalBindings = map[string]string{
+	"q":      editorActionBack,
+	"ctrl+s": editorActionSave,
+	"ctrl+z": editorActionUndo,
+	"ctrl+y": editorActionRedo,
+	"P":      editorActionPreview,
+	"F":      editorActionFocus,
+	"D":      editorActionDiff,
+	"]":      editorActionNextEntry,
+	"[":      editorActionPrevEntry,
+	"d":      editorActionDeleteLine,
+	"v":      editorActionSelect,
+	"p":      editorActionPasteLines,
+	"}":      editorActionNextTask,
+	"{":      editorActionPrevTask,
+	"/":      editorActionFind,
+	"n":      editorActionFindNext,
+	"N":      editorActionFindPrev,
+	"R":      editorActionReplace,
+	"z":      editorActionFold,
+	"B":      editorActionBacklinks,
+}
+
+// vimInsertBindings are the shared actions in vim's INSERT mode
+var vimInsertBindings = map[string]string{
+	"ctrl+s": editorActionSave,
+	"ctrl+z": editorActionUndo,
+	"ctrl+y": editorActionRedo,
+	"alt+v":  editorActionPasteImage,
+	"ctrl+b": editorActionBold,
+	"alt+i":  editorActionItalic, // Terminals send ctrl+i as tab
+	"ctrl+k": editorActionLink,
+}
+
+// emacsBindings are the shared actions in the emacs keymap's single mode. Cursor and
+// kill keys like ctrl+a/e/k, ctrl+f/b/n/p and alt+f/b are left to the textarea, so the
+// formatting shortcuts move to shifted alt keys.
+var emacsBindings = map[string]string{
+	"esc":    editorActionBack,
+	"ctrl+s": editorActionSave,
+	"ctrl+z": editorActionUndo,
+	"ctrl+_": editorActionUndo,
+	"ctrl+y": editorActionRedo,
+	"alt+p":  editorActionPreview,
+	"alt+v":  editorActionPasteImage,
+	"alt+B":  editorActionBold,
+	"alt+I":  editorActionItalic,
+	"alt+K":  editorActionLink,
+	"alt+F":  editorActionFocus,
+	"alt+D":  editorActionDiff,
+	"alt+N":  editorActionNextEntry,
+	"alt+P":  editorActionPrevEntry,
+	"alt+X":  editorActionDeleteLine,
+	"alt+V":  editorActionSelect,
+	"alt+Y":  editorActionPasteLines,
+	"alt+}":  editorActionNextTask,
+	"alt+{":  editorActionPrevTask,
+	"alt+s":  editorActionFind,
+	"alt+g":  editorActionFindNext,
+	"alt+G":  editorActionFindPrev,
+	"alt+%":  editorActionReplace,
+	"alt+z":  editorActionFold,
+	"alt+L":  editorActionBacklinks,
+}
+
+// action returns the action key is bound to in mode under this keymap, or key
+// itself if it isn't bound to a shared action
+func (k EditorKeymap) action(mode EditorMode, key string) string {
+	bindings := vimInsertBindings
+	switch {
+	case !k.modal():
+		bindings = emacsBindings
+	case mode == ModeNormal:
+		bindings = vimNormalBindings
+	}
+
+	if action, ok := bindings[key]; ok {
+		return action
+	}
+	return key
+}
+
+// modeLabel is the mode indicator shown in the editors' title line
+func (k EditorKeymap) modeLabel(mode EditorMode) string {
+	switch {
+	case !k.modal():
+		return "-- EMACS --"
+	case mode == ModeInsert:
+		return "-- INSERT --"
+	default:
+		return "-- NORMAL --"
+	}
+}
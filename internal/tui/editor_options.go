@@ -0,0 +1,17 @@
+package tui
+
+// CtrlCBehavior controls what ctrl+c does in the editors
+type CtrlCBehavior string
+
+const (
+	CtrlCConfirm CtrlCBehavior = "confirm" // Ask to save first when there are unsaved changes
+	CtrlCQuit    CtrlCBehavior = "quit"    // Quit immediately, discarding unsaved changes
+)
+
+// NewNoteCancelDestination is where esc at the new-note name prompt returns to
+type NewNoteCancelDestination string
+
+const (
+	CancelToBrowser   NewNoteCancelDestination = "browser"   // Back to the notes browser
+	CancelToDashboard NewNoteCancelDestination = "dashboard" // Back to the dashboard
+)
@@ -0,0 +1,562 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/redjax/notetkr/internal/services"
+)
+
+func newTestNotesEditor(t *testing.T) NotesEditorModel {
+	t.Helper()
+	return newTestNotesEditorWithOptions(t, DefaultOptions())
+}
+
+func newTestNotesEditorWithOptions(t *testing.T, opts Options) NotesEditorModel {
+	t.Helper()
+
+	notesDir := t.TempDir()
+	filePath := filepath.Join(notesDir, "note.md")
+	if err := os.WriteFile(filePath, []byte("# Note\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewNotesEditor(services.NewNotesService(notesDir), opts, filePath)
+	updated, _ := m.Update(m.loadNote())
+	return updated.(NotesEditorModel)
+}
+
+func TestCtrlCWithUnsavedChangesAsksToConfirm(t *testing.T) {
+	m := newTestNotesEditor(t)
+	m.textarea.SetValue("# Note\n\nunsaved edit\n")
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	m = updated.(NotesEditorModel)
+	if cmd != nil {
+		if _, ok := cmd().(tea.QuitMsg); ok {
+			t.Fatal("ctrl+c quit immediately despite unsaved changes")
+		}
+	}
+	if !m.showQuitConfirm {
+		t.Error("ctrl+c with unsaved changes should show the save confirmation")
+	}
+
+	// Declining to save then quits the program
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if cmd == nil {
+		t.Fatal("expected a quit command after declining to save")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("declining to save after ctrl+c should quit")
+	}
+}
+
+func TestCtrlCQuitBehaviorSkipsConfirm(t *testing.T) {
+	opts := DefaultOptions()
+	opts.CtrlC = CtrlCQuit
+	m := newTestNotesEditorWithOptions(t, opts)
+	m.textarea.SetValue("# Note\n\nunsaved edit\n")
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	if cmd == nil {
+		t.Fatal("expected a quit command")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("ctrl+c should quit immediately when configured to")
+	}
+}
+
+func TestCtrlCQuitsFromBinaryFileScreen(t *testing.T) {
+	dir := t.TempDir()
+	binaryPath := filepath.Join(dir, "photo.md")
+	if err := os.WriteFile(binaryPath, []byte{0x89, 'P', 'N', 'G', 0x00, 0x01, 0xff}, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	notes := NewNotesEditor(services.NewNotesService(dir), DefaultOptions(), binaryPath)
+	journal := NewJournalEditorWithFilename(services.NewJournalService(dir, time.Sunday), DefaultOptions(), binaryPath)
+	for _, editor := range []tea.Model{notes, journal} {
+		var load tea.Msg
+		switch e := editor.(type) {
+		case NotesEditorModel:
+			load = e.loadNote()
+		case JournalEditorModel:
+			load = e.loadJournal()
+		}
+		updated, _ := editor.Update(load)
+
+		_, cmd := updated.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+		if cmd == nil {
+			t.Fatalf("%T: ctrl+c on the binary file screen did nothing", editor)
+		}
+		if _, ok := cmd().(tea.QuitMsg); !ok {
+			t.Errorf("%T: ctrl+c on the binary file screen should quit", editor)
+		}
+	}
+}
+
+func TestSaveBeforeQuitStaysOnFailure(t *testing.T) {
+	m := newTestNotesEditor(t)
+	m.textarea.SetValue("# Note\n\nunsaved edit\n")
+
+	// Make the save fail by putting a directory where the note was
+	if err := os.Remove(m.filePath); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(m.filePath, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	updated, cmd := updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if cmd == nil {
+		t.Fatal("expected a save command")
+	}
+	updated, cmd = updated.Update(cmd())
+	m = updated.(NotesEditorModel)
+	if cmd != nil {
+		t.Fatal("a failed save shouldn't quit")
+	}
+	if !strings.Contains(m.saveMsg, "Save failed") || !strings.Contains(m.content(), "unsaved edit") {
+		t.Errorf("a failed save should keep the text and show the error, got %q", m.saveMsg)
+	}
+
+	// Once saving works, confirming quits after the save
+	if err := os.Remove(m.filePath); err != nil {
+		t.Fatal(err)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	updated, cmd = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if _, cmd = updated.Update(cmd()); cmd == nil {
+		t.Fatal("expected a quit command after saving")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("a successful save should then quit")
+	}
+}
+
+func TestNotesEditorPreservesCRLFOnSave(t *testing.T) {
+	notesDir := t.TempDir()
+	filePath := filepath.Join(notesDir, "windows.md")
+	if err := os.WriteFile(filePath, []byte("# Note\r\nfirst\r\nsecond\r\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewNotesEditor(services.NewNotesService(notesDir), DefaultOptions(), filePath)
+	updated, _ := m.Update(m.loadNote())
+	m = updated.(NotesEditorModel)
+	if strings.Contains(m.textarea.Value(), "\r") {
+		t.Fatalf("editor content should use \\n endings, got %q", m.textarea.Value())
+	}
+
+	// Delete the "first" line and save
+	m.moveToLine(1)
+	m.deleteLine()
+	if msg := m.saveNote(); msg != (NotesSavedMsg{}) {
+		t.Fatalf("saveNote returned %#v", msg)
+	}
+
+	saved, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "# Note\r\nsecond\r\n"; string(saved) != want {
+		t.Errorf("saved content = %q, want %q", saved, want)
+	}
+}
+
+func TestTodayJournalJumpsToLatestTimeSection(t *testing.T) {
+	content := "# Today\n\n## 09:00\n- standup\n\n## 13:30 review\n- notes\n\n## Tasks\n- [ ] follow up\n"
+	if got := lastTimeSectionLine(content); got != 5 {
+		t.Fatalf("lastTimeSectionLine() = %d, want 5", got)
+	}
+
+	journalService := services.NewJournalService(t.TempDir(), time.Sunday)
+	today := time.Now()
+	if err := journalService.EnsureJournalDirExists(today); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(journalService.GetJournalPathForDate(today), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := DefaultOptions()
+	opts.JumpToLatestTimeSection = true
+	m := NewJournalEditor(journalService, opts, today)
+	updated, cmd := m.Update(m.loadJournal())
+	if cmd == nil {
+		t.Fatal("loading today's journal should jump to the latest time section")
+	}
+	updated, _ = updated.Update(cmd())
+	m = updated.(JournalEditorModel)
+	if m.textarea.Line() != 5 {
+		t.Errorf("cursor on line %d, want the last time section on line 5", m.textarea.Line())
+	}
+}
+
+func TestNewNoteNameDiscardConfirm(t *testing.T) {
+	m := NewNotesEditorForNew(services.NewNotesService(t.TempDir()), DefaultOptions())
+	m.textarea.SetValue("half-typed name")
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(NotesEditorModel)
+	if cmd != nil || !m.confirmDiscard {
+		t.Fatal("esc with a typed name should ask before discarding")
+	}
+	if !strings.Contains(m.View(), "Discard this new note?") {
+		t.Error("view should show the discard confirmation")
+	}
+
+	// n keeps the name
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	m = updated.(NotesEditorModel)
+	if m.confirmDiscard || m.textarea.Value() != "half-typed name" {
+		t.Errorf("n should cancel the discard and keep the name, got %q", m.textarea.Value())
+	}
+
+	// y discards and returns to the notes browser
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	_, cmd = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if cmd == nil {
+		t.Fatal("confirming the discard should leave the prompt")
+	}
+	if _, ok := cmd().(BackToNotesBrowserMsg); !ok {
+		t.Error("discarding should return to the notes browser by default")
+	}
+
+	// An empty prompt leaves straight away, to the configured destination
+	opts := DefaultOptions()
+	opts.NewNoteCancel = CancelToDashboard
+	m = NewNotesEditorForNew(services.NewNotesService(t.TempDir()), opts)
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd == nil {
+		t.Fatal("esc with an empty name should leave immediately")
+	}
+	if _, ok := cmd().(BackToDashboardMsg); !ok {
+		t.Error("cancel destination should follow the configured dashboard setting")
+	}
+}
+
+func TestFocusModeExpandsTextarea(t *testing.T) {
+	key := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'F'}}
+
+	notes := newTestNotesEditor(t)
+	updated, _ := notes.Update(tea.WindowSizeMsg{Width: 80, Height: 30})
+	notes = updated.(NotesEditorModel)
+	normalHeight := notes.textarea.Height()
+
+	updated, _ = notes.Update(key)
+	notes = updated.(NotesEditorModel)
+	if !notes.focusMode || notes.textarea.Height() <= normalHeight {
+		t.Fatalf("focus mode textarea height = %d, want more than %d", notes.textarea.Height(), normalHeight)
+	}
+	view := notes.View()
+	if strings.Contains(view, "-- NORMAL --") || strings.Contains(view, "note.md") {
+		t.Error("focus mode should hide the title and mode")
+	}
+	if lines := strings.Count(view, "\n") + 1; lines != 30 {
+		t.Errorf("focus mode view is %d lines, want the full 30", lines)
+	}
+
+	// Unsaved changes stay visible
+	notes.textarea.SetValue("# Note\nmore")
+	if !strings.Contains(notes.View(), "● unsaved") {
+		t.Error("focus mode should show unsaved changes")
+	}
+
+	updated, _ = notes.Update(key)
+	notes = updated.(NotesEditorModel)
+	if notes.focusMode || notes.textarea.Height() != normalHeight {
+		t.Errorf("leaving focus mode: height = %d, want %d", notes.textarea.Height(), normalHeight)
+	}
+
+	journal := NewJournalEditor(services.NewJournalService(t.TempDir(), time.Sunday), DefaultOptions(), time.Now())
+	updated, _ = journal.Update(tea.WindowSizeMsg{Width: 80, Height: 30})
+	journal = updated.(JournalEditorModel)
+	normalHeight = journal.textarea.Height()
+
+	updated, _ = journal.Update(key)
+	journal = updated.(JournalEditorModel)
+	if !journal.focusMode || journal.textarea.Height() <= normalHeight {
+		t.Errorf("journal focus mode textarea height = %d, want more than %d", journal.textarea.Height(), normalHeight)
+	}
+}
+
+func TestNotesEditorBacklinks(t *testing.T) {
+	m := newTestNotesEditor(t)
+	notesDir := filepath.Dir(m.filePath)
+	linking := filepath.Join(notesDir, "plan.md")
+	if err := os.WriteFile(linking, []byte("# Plan\n\nBased on [[note]].\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	key := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'B'}}
+	updated, _ := m.Update(key)
+	m = updated.(NotesEditorModel)
+	if len(m.backlinks) != 1 || !strings.Contains(m.View(), "Plan (plan.md)") {
+		t.Fatalf("backlinks = %v, want plan.md listed", m.backlinks)
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter should open the selected backlink")
+	}
+	if msg, ok := cmd().(OpenNoteMsg); !ok || msg.filePath != linking {
+		t.Errorf("enter opened %v, want %s", msg, linking)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(NotesEditorModel)
+	if m.backlinks != nil {
+		t.Error("esc should close the backlinks")
+	}
+
+	// A note nothing links to says so
+	if err := os.Remove(linking); err != nil {
+		t.Fatal(err)
+	}
+	updated, _ = m.Update(key)
+	m = updated.(NotesEditorModel)
+	if m.backlinks != nil || !strings.Contains(m.saveMsg, "No notes link to [[note]]") {
+		t.Errorf("saveMsg = %q, want a no-backlinks message", m.saveMsg)
+	}
+}
+
+func TestNotesEditorOpensAtSearchMatch(t *testing.T) {
+	notesDir := t.TempDir()
+	filePath := filepath.Join(notesDir, "note.md")
+	content := "# Note\n\nfirst Widget\nnothing here\nsecond widget\n"
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewNotesEditorWithQuery(services.NewNotesService(notesDir), DefaultOptions(), filePath, "widget", services.SearchOptions{})
+	updated, _ := m.Update(m.loadNote())
+	m = updated.(NotesEditorModel)
+	if m.textarea.Line() != 2 || !strings.Contains(m.saveMsg, "Match 1 of 2") {
+		t.Fatalf("opened at line %d with %q, want the first match on line 2", m.textarea.Line(), m.saveMsg)
+	}
+
+	press := func(r rune) {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = updated.(NotesEditorModel)
+	}
+	press('n')
+	if m.textarea.Line() != 4 {
+		t.Errorf("n moved to line %d, want 4", m.textarea.Line())
+	}
+	press('n')
+	if m.textarea.Line() != 2 {
+		t.Errorf("n should wrap to the first match, got line %d", m.textarea.Line())
+	}
+	press('N')
+	if m.textarea.Line() != 4 {
+		t.Errorf("N should wrap to the last match, got line %d", m.textarea.Line())
+	}
+
+	// The find prompt starts with the seeded query
+	press('/')
+	if m.findInput.Value() != "widget" {
+		t.Errorf("find prompt = %q, want the seeded query", m.findInput.Value())
+	}
+}
+
+func TestNotesEditorReplace(t *testing.T) {
+	notesDir := t.TempDir()
+	filePath := filepath.Join(notesDir, "note.md")
+	if err := os.WriteFile(filePath, []byte("# Note\n\ncat one\ncat two\ncat three"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewNotesEditorWithQuery(services.NewNotesService(notesDir), DefaultOptions(), filePath, "cat", services.SearchOptions{})
+	updated, _ := m.Update(m.loadNote())
+	m = updated.(NotesEditorModel)
+
+	send := func(msg tea.KeyMsg) {
+		updated, _ := m.Update(msg)
+		m = updated.(NotesEditorModel)
+	}
+	replaceWith := func(text string, key tea.KeyMsg) {
+		send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'R'}})
+		send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text)})
+		send(key)
+	}
+
+	// enter replaces the match under the cursor and moves on to the next one
+	replaceWith("dog", tea.KeyMsg{Type: tea.KeyEnter})
+	if got := m.content(); got != "# Note\n\ndog one\ncat two\ncat three" {
+		t.Fatalf("after replacing one match content = %q", got)
+	}
+	if m.textarea.Line() != 3 {
+		t.Errorf("cursor on line %d, want the next match on 3", m.textarea.Line())
+	}
+
+	// ctrl+r replaces the rest
+	replaceWith("dog", tea.KeyMsg{Type: tea.KeyCtrlR})
+	if got := m.content(); got != "# Note\n\ndog one\ndog two\ndog three" {
+		t.Fatalf("after replacing all content = %q", got)
+	}
+	if !strings.Contains(m.saveMsg, "Replaced 2") {
+		t.Errorf("saveMsg = %q, want the replacement count", m.saveMsg)
+	}
+
+	// Both replacements can be undone
+	send(tea.KeyMsg{Type: tea.KeyCtrlZ})
+	send(tea.KeyMsg{Type: tea.KeyCtrlZ})
+	if got := m.content(); got != "# Note\n\ncat one\ncat two\ncat three" {
+		t.Errorf("after undo content = %q", got)
+	}
+
+	// Nothing left to replace says so
+	replaceWith("dog", tea.KeyMsg{Type: tea.KeyCtrlR})
+	replaceWith("dog", tea.KeyMsg{Type: tea.KeyCtrlR})
+	if !strings.Contains(m.saveMsg, `No lines match "cat"`) {
+		t.Errorf("saveMsg = %q, want a no-match message", m.saveMsg)
+	}
+}
+
+func TestWordCountUpdatesWhileTyping(t *testing.T) {
+	m := newTestNotesEditor(t)
+	m.mode = ModeInsert
+	m.textarea.CursorEnd()
+	if m.wordCount != 1 {
+		t.Fatalf("wordCount = %d for %q, want 1", m.wordCount, m.content())
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(" more words")})
+	m = updated.(NotesEditorModel)
+	if m.wordCount != 3 || !strings.Contains(m.View(), "3 words") {
+		t.Errorf("wordCount = %d after typing, want 3", m.wordCount)
+	}
+}
+
+func TestEmacsKeymapEditsWithoutModes(t *testing.T) {
+	opts := DefaultOptions()
+	opts.EditorKeymap = KeymapEmacs
+	m := newTestNotesEditorWithOptions(t, opts)
+	if m.mode != ModeInsert || !strings.Contains(m.View(), "-- EMACS --") {
+		t.Fatalf("emacs keymap should open in its single editing mode, got mode %v", m.mode)
+	}
+
+	// Keys that are commands in vim's NORMAL mode are typed as text
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	m = updated.(NotesEditorModel)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlA})
+	m = updated.(NotesEditorModel)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	m = updated.(NotesEditorModel)
+	if got := m.content(); got != "# Note\npq" {
+		t.Fatalf("content = %q, want %q", got, "# Note\npq")
+	}
+
+	// Formatting and undo come from the shared actions
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("B"), Alt: true})
+	m = updated.(NotesEditorModel)
+	if got := m.content(); got != "# Note\n**pq**" {
+		t.Fatalf("content after alt+B = %q, want %q", got, "# Note\n**pq**")
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlZ})
+	m = updated.(NotesEditorModel)
+	if got := m.content(); got != "# Note\npq" {
+		t.Errorf("content after ctrl+z = %q, want %q", got, "# Note\npq")
+	}
+
+	// esc leaves the editor, asking first about unsaved changes
+	m.textarea.SetValue("# Note\n\nunsaved edit\n")
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(NotesEditorModel)
+	if !m.showQuitConfirm || m.mode != ModeInsert {
+		t.Errorf("esc with unsaved changes should ask to confirm, got confirm=%v mode=%v", m.showQuitConfirm, m.mode)
+	}
+}
+
+func TestPersistUndoSurvivesReopening(t *testing.T) {
+	opts := DefaultOptions()
+	opts.PersistUndo = true
+	m := newTestNotesEditorWithOptions(t, opts)
+	m.moveToLine(0)
+	m.deleteLine()
+	updated, _ := m.Update(m.saveNote())
+	m = updated.(NotesEditorModel)
+	if _, err := os.Stat(services.UndoHistoryPath(m.filePath)); err != nil {
+		t.Fatalf("saving didn't write the undo history: %v", err)
+	}
+
+	// Reopen the note and undo the deletion from before it was closed
+	reopened := NewNotesEditor(m.notesService, opts, m.filePath)
+	updated, _ = reopened.Update(reopened.loadNote())
+	reopened = updated.(NotesEditorModel)
+	updated, _ = reopened.Update(tea.KeyMsg{Type: tea.KeyCtrlZ})
+	reopened = updated.(NotesEditorModel)
+	if got := reopened.content(); got != "# Note\n" {
+		t.Errorf("content after undo in the reopened note = %q, want %q", got, "# Note\n")
+	}
+
+	// Without the option, a reopened note starts with no undo history
+	fresh := NewNotesEditor(m.notesService, DefaultOptions(), m.filePath)
+	updated, _ = fresh.Update(fresh.loadNote())
+	if fresh = updated.(NotesEditorModel); len(fresh.undoStack) != 0 {
+		t.Errorf("undo stack has %d entries with persistence off", len(fresh.undoStack))
+	}
+}
+
+func TestScratchpadOnlySavedWithSaveAs(t *testing.T) {
+	key := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	send := func(m NotesEditorModel, msgs ...tea.Msg) (NotesEditorModel, tea.Cmd) {
+		var cmd tea.Cmd
+		for _, msg := range msgs {
+			var updated tea.Model
+			updated, cmd = m.Update(msg)
+			m = updated.(NotesEditorModel)
+		}
+		return m, cmd
+	}
+	notesDir := t.TempDir()
+	notesService := services.NewNotesService(notesDir)
+
+	// Leaving without saving discards the text and writes nothing
+	m, _ := send(NewScratchEditor(notesService, DefaultOptions()), key("i"), key("2 + 2 = 4"), tea.KeyMsg{Type: tea.KeyEsc}, key("q"))
+	if !m.showQuitConfirm {
+		t.Fatal("leaving a scratchpad with text should ask to save it")
+	}
+	_, cmd := send(m, key("n"))
+	if cmd == nil {
+		t.Fatal("n should leave the scratchpad")
+	}
+	if _, ok := cmd().(BackToDashboardMsg); !ok {
+		t.Error("a scratchpad should return to the dashboard")
+	}
+	if entries, _ := os.ReadDir(notesDir); len(entries) != 0 {
+		t.Fatalf("discarded scratchpad wrote %d files", len(entries))
+	}
+
+	// ctrl+s asks for a name, and saving as creates the note
+	m, _ = send(NewScratchEditor(notesService, DefaultOptions()), key("i"), key("keep this"), tea.KeyMsg{Type: tea.KeyCtrlS})
+	if !m.savingAs {
+		t.Fatal("saving a scratchpad should ask for a name")
+	}
+	m, cmd = send(m, key("ideas/kept"), tea.KeyMsg{Type: tea.KeyEnter})
+	path := filepath.Join(notesDir, "ideas", "kept.md")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("save-as did not create the note: %v", err)
+	}
+	if !strings.HasSuffix(string(content), "keep this\n") {
+		t.Errorf("note content = %q", content)
+	}
+	if m.filePath != path || m.savingAs {
+		t.Errorf("editor should now edit %s, got %q", path, m.filePath)
+	}
+
+	// Later saves write the note like any other
+	m, _ = send(m, cmd(), tea.KeyMsg{Type: tea.KeyEsc}, key("G"), key("i"), key("too"))
+	_, cmd = send(m, tea.KeyMsg{Type: tea.KeyCtrlS})
+	cmd()
+	if content, _ := os.ReadFile(path); !strings.Contains(string(content), "too") {
+		t.Errorf("saving after save-as did not update the note: %q", content)
+	}
+}
@@ -0,0 +1,65 @@
+package tui
+
+import "strings"
+
+// helpEntry is one "keys: description" item in a help line
+type helpEntry struct {
+	keys string
+	desc string
+}
+
+// renderHelp joins help entries into the "key: action • key: action" format used at the bottom of each view
+func renderHelp(entries ...helpEntry) string {
+	parts := make([]string, len(entries))
+	for i, e := range entries {
+		parts[i] = e.keys + ": " + e.desc
+	}
+	return strings.Join(parts, " • ")
+}
+
+// notesBrowserHelp returns the notes browser's main help line for a key map
+func notesBrowserHelp(km KeyMap, dirSort, noteSort string) string {
+	return renderHelp(
+		helpEntry{km.HelpKeys(ActionPrev), "up"},
+		helpEntry{km.HelpKeys(ActionNext), "down"},
+		helpEntry{km.HelpKeys(ActionOpen), "open"},
+		helpEntry{"space", "view"},
+		helpEntry{"p", "preview"},
+		helpEntry{"e", "copy as html"},
+		helpEntry{"n", "new"},
+		helpEntry{"v", "new from clipboard"},
+		helpEntry{"m", "move"},
+		helpEntry{"R", "rename"},
+		helpEntry{"D", "duplicate"},
+		helpEntry{"P", "pin"},
+		helpEntry{"a", "archive"},
+		helpEntry{"/", "search"},
+		helpEntry{"f", "all notes"},
+		helpEntry{"t", "tags"},
+		helpEntry{"T", "tag cloud"},
+		helpEntry{"Y", "copy tags"},
+		helpEntry{"c", "clear filter"},
+		helpEntry{"r", "refresh"},
+		helpEntry{"s", "sort dirs (" + dirSort + ")"},
+		helpEntry{"w", "sort notes (" + noteSort + ")"},
+		helpEntry{"d", "delete"},
+		helpEntry{km.HelpKeys(ActionUp), "back"},
+		helpEntry{km.HelpKeys(ActionQuit), "quit"},
+	)
+}
+
+// journalBrowserHelp returns the journal browser's help line for a key map
+func journalBrowserHelp(km KeyMap) string {
+	return renderHelp(
+		helpEntry{"n", "new entry"},
+		helpEntry{km.HelpKeys(ActionPrev), "up"},
+		helpEntry{km.HelpKeys(ActionNext), "down"},
+		helpEntry{km.HelpKeys(ActionOpen), "open"},
+		helpEntry{km.HelpKeys(ActionUp), "back"},
+		helpEntry{"t", "filter by tag"},
+		helpEntry{"g", "weekly summary"},
+		helpEntry{"y", "copy path"},
+		helpEntry{"d", "delete"},
+		helpEntry{km.HelpKeys(ActionQuit), "quit"},
+	)
+}
@@ -0,0 +1,41 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/redjax/notetkr/internal/services"
+)
+
+func TestRenderHelp(t *testing.T) {
+	got := renderHelp(helpEntry{"↑/k", "up"}, helpEntry{"q", "quit"})
+	if want := "↑/k: up • q: quit"; got != want {
+		t.Errorf("renderHelp() = %q, want %q", got, want)
+	}
+}
+
+func TestHelpFollowsKeyMap(t *testing.T) {
+	help := notesBrowserHelp(DefaultKeyMap(), "name", "recent")
+	for _, want := range []string{"↑/k: up", "↓/j: down", "enter/l: open", "esc/h: back", "q: quit"} {
+		if !strings.Contains(help, want) {
+			t.Errorf("default help %q missing %q", help, want)
+		}
+	}
+
+	opts := DefaultOptions()
+	opts.Keys = KeyMap{Up: []string{"esc", "backspace"}, Quit: []string{"x"}}.withDefaults()
+
+	help = notesBrowserHelp(opts.Keys, "name", "recent")
+	if !strings.Contains(help, "esc/backspace: back") || !strings.Contains(help, "x: quit") {
+		t.Errorf("help %q does not reflect the rebound keys", help)
+	}
+	if strings.Contains(help, "esc/h: back") {
+		t.Errorf("help %q still shows the old binding", help)
+	}
+
+	// The rendered view uses the same help
+	m := NewNotesBrowser(services.NewNotesService(t.TempDir()), opts, 0, 0)
+	if view := m.View(); !strings.Contains(view, "esc/backspace: back") {
+		t.Errorf("notes browser view does not show the rebound back key")
+	}
+}
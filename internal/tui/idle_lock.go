@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// idleTickMsg is sent when the idle timer started by keypress number gen runs out
+type idleTickMsg struct {
+	gen int
+}
+
+// idleTimer starts waiting for the idle lock, if it's on
+func (m AppModel) idleTimer() tea.Cmd {
+	if m.opts.IdleLock <= 0 {
+		return nil
+	}
+	gen := m.idleGen
+	return tea.Tick(m.opts.IdleLock, func(time.Time) tea.Msg {
+		return idleTickMsg{gen: gen}
+	})
+}
+
+// resetIdleTimer restarts the idle wait after input. Timers started before it are
+// ignored when they run out.
+func (m *AppModel) resetIdleTimer() tea.Cmd {
+	m.idleGen++
+	return m.idleTimer()
+}
+
+// lock blanks the screen. An open editor is saved and closed first, so unlocking
+// goes back to the dashboard rather than the note. An editor that can't be saved,
+// like an unsaved scratchpad, stays open behind the lock.
+func (m AppModel) lock() (AppModel, tea.Cmd) {
+	m.locked = true
+
+	saved := false
+	switch view := m.currentView.(type) {
+	case NotesEditorModel:
+		if view.isNewNote || view.unsavedScratch() || view.err != nil {
+			break
+		}
+		if view.hasUnsavedChanges() {
+			if _, failed := view.saveNote().(NotesSaveFailedMsg); failed {
+				break
+			}
+		}
+		saved = true
+	case JournalEditorModel:
+		if view.err != nil {
+			break
+		}
+		if view.hasUnsavedChanges() {
+			if _, failed := view.saveJournal().(JournalSaveFailedMsg); failed {
+				break
+			}
+		}
+		saved = true
+	}
+	if !saved {
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.currentView = NewDashboard(m.journalService)
+	if m.width > 0 && m.height > 0 {
+		m.currentView, cmd = m.currentView.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+	}
+	return m, tea.Batch(cmd, m.currentView.Init())
+}
+
+// lockedView is shown instead of the current view while the app is locked
+func (m AppModel) lockedView() string {
+	msg := "🔒 Locked while idle\n\npress any key to continue"
+	if m.width <= 0 || m.height <= 0 {
+		return msg
+	}
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, helpStyle.Render(msg))
+}
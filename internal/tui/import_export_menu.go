@@ -8,6 +8,7 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/redjax/notetkr/internal/services"
 )
 
 type ImportExportMenuModel struct {
@@ -15,20 +16,25 @@ type ImportExportMenuModel struct {
 	cursor        int
 	width         int
 	height        int
-	inputMode     string // "", "export-path", "import-path"
+	inputMode     string // "", "export-type", "import-type", "export-path", "import-path"
+	typeCursor    int    // Highlighted entry in dataTypeChoices while choosing what to export/import
 	pathInput     textinput.Model
 	exportType    []string
 	importType    []string
 	statusMessage string
+	notesDir      string
+	journalDir    string
+	exportSummary services.ExportSummary
+	summaryErr    error
 }
 
-func NewImportExportMenu(width, height int) ImportExportMenuModel {
+func NewImportExportMenu(notesDir, journalDir string, width, height int) ImportExportMenuModel {
 	ti := textinput.New()
 	ti.Placeholder = "Enter file path..."
 	ti.CharLimit = 256
 	ti.Width = 60
 
-	return ImportExportMenuModel{
+	m := ImportExportMenuModel{
 		choices: []string{
 			"Export Data",
 			"Import Data",
@@ -40,7 +46,60 @@ func NewImportExportMenu(width, height int) ImportExportMenuModel {
 		pathInput:  ti,
 		exportType: []string{"both"},
 		importType: []string{"both"},
+		notesDir:   notesDir,
+		journalDir: journalDir,
 	}
+	m.loadExportSummary()
+	return m
+}
+
+// dataTypeChoices are the options for what to export or import, matching the CLI's -t values
+var dataTypeChoices = []struct {
+	label string
+	value string
+}{
+	{"Notes and journals", "both"},
+	{"Notes only", "notes"},
+	{"Journals only", "journals"},
+}
+
+// dataTypeIndex returns the position of a type filter in dataTypeChoices, 0 if it isn't one
+func dataTypeIndex(dataType []string) int {
+	if len(dataType) == 1 {
+		for i, choice := range dataTypeChoices {
+			if choice.value == dataType[0] {
+				return i
+			}
+		}
+	}
+	return 0
+}
+
+// loadExportSummary computes how much data the current export type would include
+func (m *ImportExportMenuModel) loadExportSummary() {
+	includeNotes, includeJournals := exportTypeIncludes(m.exportType)
+	m.exportSummary, m.summaryErr = services.SummarizeExport(m.notesDir, m.journalDir, includeNotes, includeJournals)
+}
+
+// exportTypeIncludes converts the menu's export type filter into notes/journals flags
+func exportTypeIncludes(exportType []string) (includeNotes, includeJournals bool) {
+	if len(exportType) == 0 {
+		return true, true
+	}
+
+	for _, t := range exportType {
+		switch t {
+		case "both":
+			includeNotes = true
+			includeJournals = true
+		case "notes":
+			includeNotes = true
+		case "journals":
+			includeJournals = true
+		}
+	}
+
+	return includeNotes, includeJournals
 }
 
 func (m ImportExportMenuModel) Init() tea.Cmd {
@@ -90,9 +149,16 @@ func (m ImportExportMenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.statusMessage = fmt.Sprintf("✓ Import successful from: %s", msg.FilePath)
 		}
 		m.inputMode = ""
+		// Imported files change what the next export would include
+		m.loadExportSummary()
 		return m, nil
 
 	case tea.KeyMsg:
+		// Handle choosing what to export/import
+		if m.inputMode == "export-type" || m.inputMode == "import-type" {
+			return m.updateTypeSelect(msg)
+		}
+
 		// Handle input mode
 		if m.inputMode != "" {
 			switch msg.String() {
@@ -158,18 +224,16 @@ func (m ImportExportMenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "enter", "l", "right", " ":
 			switch m.cursor {
 			case 0: // Export Data
-				m.inputMode = "export-path"
-				m.pathInput.SetValue("")
-				m.pathInput.Focus()
-				m.statusMessage = "Enter output path for export (e.g., backup.zip)"
-				return m, textinput.Blink
+				m.inputMode = "export-type"
+				m.typeCursor = dataTypeIndex(m.exportType)
+				m.statusMessage = "What do you want to export?"
+				return m, nil
 
 			case 1: // Import Data
-				m.inputMode = "import-path"
-				m.pathInput.SetValue("")
-				m.pathInput.Focus()
-				m.statusMessage = "Enter path to ZIP file to import"
-				return m, textinput.Blink
+				m.inputMode = "import-type"
+				m.typeCursor = dataTypeIndex(m.importType)
+				m.statusMessage = "What do you want to import?"
+				return m, nil
 
 			case 2: // Back to Main Menu
 				return m, func() tea.Msg {
@@ -187,10 +251,65 @@ func (m ImportExportMenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// updateTypeSelect handles keys while choosing what to export or import, then
+// moves on to asking for the archive path
+func (m ImportExportMenuModel) updateTypeSelect(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, func() tea.Msg {
+			return BackToDashboardMsg{}
+		}
+
+	case "esc", "q", "h", "left":
+		m.inputMode = ""
+		m.statusMessage = ""
+
+	case "up", "k":
+		if m.typeCursor > 0 {
+			m.typeCursor--
+		}
+
+	case "down", "j":
+		if m.typeCursor < len(dataTypeChoices)-1 {
+			m.typeCursor++
+		}
+
+	case "enter", "l", "right", " ":
+		selected := []string{dataTypeChoices[m.typeCursor].value}
+		if m.inputMode == "export-type" {
+			m.exportType = selected
+			m.loadExportSummary()
+			m.inputMode = "export-path"
+			m.statusMessage = "Enter output path for export (e.g., backup.zip or backup.tar.gz)"
+		} else {
+			m.importType = selected
+			m.inputMode = "import-path"
+			m.statusMessage = "Enter path to ZIP file to import"
+		}
+		m.pathInput.SetValue("")
+		m.pathInput.Focus()
+		return m, textinput.Blink
+	}
+
+	return m, nil
+}
+
 func (m ImportExportMenuModel) View() string {
 	var s string
 
-	if m.inputMode != "" {
+	if m.inputMode == "export-type" || m.inputMode == "import-type" {
+		// Show the export/import type choices
+		s = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("170")).Render("📦 Import/Export") + "\n\n"
+		s += m.statusMessage + "\n\n"
+		for i, choice := range dataTypeChoices {
+			if m.typeCursor == i {
+				s += selectedItemStyle.Render("▶ "+choice.label) + "\n"
+			} else {
+				s += menuItemStyle.Render("  "+choice.label) + "\n"
+			}
+		}
+		s += "\n" + helpStyle.Render("↑/k: up • ↓/j: down • enter: select • esc: cancel")
+	} else if m.inputMode != "" {
 		// Show input mode
 		s = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("170")).Render("📦 Import/Export") + "\n\n"
 		s += m.statusMessage + "\n\n"
@@ -214,7 +333,8 @@ func (m ImportExportMenuModel) View() string {
 			}
 		}
 
-		s += "\n" + helpStyle.Render("↑/k: up • ↓/j: down • enter/l: select • esc/q: back")
+		s += "\n" + m.renderExportSummary() + "\n"
+		s += helpStyle.Render("↑/k: up • ↓/j: down • enter/l: select • esc/q: back")
 	}
 
 	// Center the content
@@ -230,6 +350,16 @@ func (m ImportExportMenuModel) View() string {
 	return s
 }
 
+// renderExportSummary renders the footer describing what an export would include
+func (m ImportExportMenuModel) renderExportSummary() string {
+	if m.summaryErr != nil {
+		return statusStyle.Render(fmt.Sprintf("Export size unavailable: %v", m.summaryErr))
+	}
+
+	return statusStyle.Render(fmt.Sprintf("Export will include %d file(s), %s",
+		m.exportSummary.Files, formatBytes(m.exportSummary.Bytes)))
+}
+
 // Message types
 type ExportDataMsg struct {
 	OutputPath string
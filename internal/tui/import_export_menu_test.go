@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestImportExportMenuTypeFlowsIntoMessages(t *testing.T) {
+	m := NewImportExportMenu(t.TempDir(), t.TempDir(), 80, 24)
+	press := func(keys ...tea.KeyMsg) tea.Cmd {
+		var cmd tea.Cmd
+		for _, key := range keys {
+			var updated tea.Model
+			updated, cmd = m.Update(key)
+			m = updated.(ImportExportMenuModel)
+		}
+		return cmd
+	}
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+	down := tea.KeyMsg{Type: tea.KeyDown}
+
+	// Export Data -> Notes only -> path
+	press(enter, down, enter)
+	if m.inputMode != "export-path" {
+		t.Fatalf("input mode after choosing a type = %q, want export-path", m.inputMode)
+	}
+	m.pathInput.SetValue("backup.zip")
+	cmd := press(enter)
+	if cmd == nil {
+		t.Fatal("confirming the export path returned no command")
+	}
+	export, ok := cmd().(ExportDataMsg)
+	if !ok || export.OutputPath != "backup.zip" || !reflect.DeepEqual(export.ExportType, []string{"notes"}) {
+		t.Errorf("export message = %#v, want notes only to backup.zip", export)
+	}
+
+	// Import Data -> Journals only -> path
+	press(down, enter, down, down, enter)
+	if m.inputMode != "import-path" {
+		t.Fatalf("input mode after choosing a type = %q, want import-path", m.inputMode)
+	}
+	m.pathInput.SetValue("backup.zip")
+	imp, ok := press(enter)().(ImportDataMsg)
+	if !ok || !reflect.DeepEqual(imp.ImportType, []string{"journals"}) {
+		t.Errorf("import message = %#v, want journals only", imp)
+	}
+
+	// The last choice is preselected next time, and esc backs out to the menu
+	press(tea.KeyMsg{Type: tea.KeyUp}, enter)
+	if m.inputMode != "export-type" || m.typeCursor != 1 {
+		t.Errorf("export type step: mode %q cursor %d, want export-type with notes preselected", m.inputMode, m.typeCursor)
+	}
+	press(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.inputMode != "" {
+		t.Errorf("esc left input mode %q, want the menu", m.inputMode)
+	}
+}
@@ -9,10 +9,12 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/redjax/notetkr/internal/services"
+	"github.com/redjax/notetkr/internal/utils"
 )
 
 type JournalModel struct {
 	journalService *services.JournalService
+	opts           Options
 	date           time.Time
 	content        string
 	filePath       string
@@ -31,9 +33,10 @@ var (
 			Padding(1, 2)
 )
 
-func NewJournalModel(journalService *services.JournalService) JournalModel {
+func NewJournalModel(journalService *services.JournalService, opts Options) JournalModel {
 	return JournalModel{
 		journalService: journalService,
+		opts:           opts,
 		date:           time.Now(),
 	}
 }
@@ -86,11 +89,12 @@ func (m JournalModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case "esc":
 			// Return to dashboard
-			return NewDashboard(), nil
+			dashboard := NewDashboard(m.journalService)
+			return dashboard, dashboard.Init()
 
 		case "n":
 			// Open today's journal in built-in editor
-			editor := NewJournalEditor(m.journalService, m.date)
+			editor := NewJournalEditor(m.journalService, m.opts, m.date)
 			return editor, editor.Init()
 
 		case "e":
@@ -111,14 +115,8 @@ func (m JournalModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m JournalModel) openInEditor() tea.Msg {
-	// Get editor from environment, default to vi
-	editor := os.Getenv("EDITOR")
-	if editor == "" {
-		editor = "vi"
-	}
-
 	// Create command to open editor
-	cmd := exec.Command(editor, m.filePath)
+	cmd := exec.Command(utils.EditorCommand(), m.filePath)
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -12,10 +12,12 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/redjax/notetkr/internal/services"
+	"github.com/redjax/notetkr/internal/utils"
 )
 
 type JournalBrowserModel struct {
 	journalService   *services.JournalService
+	opts             Options
 	journalDir       string
 	breadcrumb       []string // Track navigation path: ["2025", "10", "15"]
 	items            []string
@@ -28,6 +30,12 @@ type JournalBrowserModel struct {
 	deleteTargetPath string
 	creatingNew      bool
 	nameInput        textinput.Model
+	statusMsg        string // Result of the last copy action, cleared on the next key
+	showingTags      bool
+	allTags          []string
+	tagCursor        int
+	tagFilter        string                  // Tag the list is filtered by, "" when browsing folders
+	taggedEntries    []services.JournalEntry // Entries listed while filtering by tag, in item order
 }
 
 var (
@@ -59,7 +67,7 @@ var (
 				Foreground(lipgloss.Color("196"))
 )
 
-func NewJournalBrowser(journalService *services.JournalService, journalDir string, width, height int) JournalBrowserModel {
+func NewJournalBrowser(journalService *services.JournalService, opts Options, journalDir string, width, height int) JournalBrowserModel {
 	nameInput := textinput.New()
 	nameInput.Placeholder = "Enter journal filename (e.g., 2025-11-05)..."
 	nameInput.CharLimit = 100
@@ -67,6 +75,7 @@ func NewJournalBrowser(journalService *services.JournalService, journalDir strin
 
 	m := JournalBrowserModel{
 		journalService: journalService,
+		opts:           opts,
 		journalDir:     journalDir,
 		breadcrumb:     []string{},
 		cursor:         0,
@@ -82,6 +91,11 @@ func (m *JournalBrowserModel) loadItems() {
 	m.items = []string{}
 	m.cursor = 0
 
+	if m.tagFilter != "" {
+		m.loadTaggedItems()
+		return
+	}
+
 	// Add "Today's Journal" only at root level (no breadcrumb)
 	if len(m.breadcrumb) == 0 {
 		m.items = append(m.items, "📔 Today's Journal")
@@ -153,6 +167,81 @@ func (m *JournalBrowserModel) loadItems() {
 	}
 }
 
+// loadTaggedItems lists the entries tagged with the tag filter, from every folder
+func (m *JournalBrowserModel) loadTaggedItems() {
+	entries, err := m.journalService.FilterJournalsByTag(m.tagFilter)
+	if err != nil {
+		m.err = err
+		return
+	}
+
+	m.taggedEntries = entries
+	for _, entry := range entries {
+		m.items = append(m.items, "📄 "+strings.TrimSuffix(filepath.Base(entry.FilePath), ".md"))
+	}
+}
+
+// selectedPath is the full path of the selected item, "" for items without one
+// ("Today's Journal")
+func (m JournalBrowserModel) selectedPath() string {
+	if len(m.items) == 0 || m.cursor >= len(m.items) {
+		return ""
+	}
+	if m.tagFilter != "" {
+		if m.cursor < len(m.taggedEntries) {
+			return m.taggedEntries[m.cursor].FilePath
+		}
+		return ""
+	}
+	return journalItemPath(m.journalDir, m.breadcrumb, m.items[m.cursor])
+}
+
+// journalBrowserPosition is where the journal browser was left when opening an
+// entry, so returning to it can restore the folder and selection
+type journalBrowserPosition struct {
+	breadcrumb []string
+	tagFilter  string
+	cursor     int
+	selected   string // Selected item, found again if the list changed
+}
+
+// position records the browser's current folder and selection
+func (m JournalBrowserModel) position() journalBrowserPosition {
+	pos := journalBrowserPosition{
+		breadcrumb: append([]string(nil), m.breadcrumb...),
+		tagFilter:  m.tagFilter,
+		cursor:     m.cursor,
+	}
+	if m.cursor < len(m.items) {
+		pos.selected = m.items[m.cursor]
+	}
+	return pos
+}
+
+// restorePosition goes back to a recorded position. The selection follows the
+// recorded item if it is still listed, otherwise the cursor stays in range.
+func (m *JournalBrowserModel) restorePosition(pos journalBrowserPosition) {
+	m.breadcrumb = append([]string(nil), pos.breadcrumb...)
+	m.tagFilter = pos.tagFilter
+	m.loadItems()
+	if m.err != nil {
+		// The folder is gone; start from the root instead
+		m.err = nil
+		m.breadcrumb = nil
+		m.tagFilter = ""
+		m.loadItems()
+		return
+	}
+
+	for i, item := range m.items {
+		if item == pos.selected {
+			m.cursor = i
+			return
+		}
+	}
+	m.cursor = max(0, min(pos.cursor, len(m.items)-1))
+}
+
 func (m JournalBrowserModel) Init() tea.Cmd {
 	return nil
 }
@@ -165,6 +254,9 @@ func (m JournalBrowserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		// Clear the copy status on the next keypress
+		m.statusMsg = ""
+
 		// Handle filename input for new journal
 		if m.creatingNew {
 			switch msg.String() {
@@ -208,6 +300,39 @@ func (m JournalBrowserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		// Handle the tag selection overlay
+		if m.showingTags {
+			switch msg.String() {
+			case "q", "ctrl+c":
+				return m, tea.Quit
+
+			case "esc":
+				m.showingTags = false
+				return m, nil
+
+			case "up", "k":
+				if m.tagCursor > 0 {
+					m.tagCursor--
+				}
+				return m, nil
+
+			case "down", "j":
+				if m.tagCursor < len(m.allTags)-1 {
+					m.tagCursor++
+				}
+				return m, nil
+
+			case "enter", "l":
+				if m.tagCursor < len(m.allTags) {
+					m.tagFilter = m.allTags[m.tagCursor]
+					m.showingTags = false
+					m.loadItems()
+				}
+				return m, nil
+			}
+			return m, nil
+		}
+
 		// Handle delete confirmation
 		if m.confirmDelete {
 			switch msg.String() {
@@ -232,9 +357,9 @@ func (m JournalBrowserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
-		// Normal navigation
-		switch msg.String() {
-		case "ctrl+c", "q":
+		// Normal navigation (navigation keys are configurable, see KeyMap)
+		switch m.opts.Keys.Resolve(msg.String()) {
+		case ActionQuit, "ctrl+c":
 			return m, tea.Quit
 
 		case "n":
@@ -243,62 +368,71 @@ func (m JournalBrowserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.nameInput.Focus()
 			return m, textinput.Blink
 
-		case "esc", "h", "left":
-			// Go back/up one level
+		case ActionUp, "left":
+			// Clear the tag filter, then go back/up one level
+			if m.tagFilter != "" {
+				m.tagFilter = ""
+				m.taggedEntries = nil
+				m.loadItems()
+				return m, nil
+			}
 			if len(m.breadcrumb) > 0 {
 				m.breadcrumb = m.breadcrumb[:len(m.breadcrumb)-1]
 				m.loadItems()
-			} else {
-				// Return to dashboard
-				return m, func() tea.Msg {
-					return BackToDashboardMsg{}
-				}
+				return m, nil
+			}
+			// Return to dashboard
+			return m, func() tea.Msg {
+				return BackToDashboardMsg{}
 			}
 
-		case "up", "k":
+		case ActionPrev:
 			if m.cursor > 0 {
 				m.cursor--
 			}
 
-		case "down", "j":
+		case ActionNext:
 			if m.cursor < len(m.items)-1 {
 				m.cursor++
 			}
 
+		case "t":
+			// Filter entries by a tag
+			tags, err := m.journalService.GetAllTags()
+			if err != nil {
+				m.statusMsg = errorStyle.Render(fmt.Sprintf("❌ %v", err))
+				return m, nil
+			}
+			m.allTags = tags
+			m.tagCursor = 0
+			m.showingTags = true
+
 		case "d":
 			// Delete current item (with confirmation)
 			if len(m.items) == 0 || m.cursor >= len(m.items) {
 				return m, nil
 			}
 
-			selected := m.items[m.cursor]
-
-			// Can't delete "Today's Journal" entry
-			if strings.HasPrefix(selected, "📔") {
-				return m, nil
-			}
+			// Build path to delete ("Today's Journal" has no path and can't be deleted)
+			targetPath := m.selectedPath()
 
-			// Build path to delete
-			currentPath := m.journalDir
-			for _, part := range m.breadcrumb {
-				currentPath = filepath.Join(currentPath, part)
+			if targetPath != "" {
+				m.confirmDelete = true
+				m.deleteTarget = m.items[m.cursor]
+				m.deleteTargetPath = targetPath
 			}
 
-			var targetPath string
-			if strings.HasPrefix(selected, "📁") {
-				// Deleting a folder
-				folderName := strings.TrimPrefix(selected, "📁 ")
-				targetPath = filepath.Join(currentPath, folderName)
-			} else if strings.HasPrefix(selected, "📄") {
-				// Deleting a file
-				fileName := strings.TrimPrefix(selected, "📄 ")
-				targetPath = filepath.Join(currentPath, fileName+".md")
+		case "y":
+			// Copy the selected item's full path to the clipboard
+			targetPath := m.selectedPath()
+			if targetPath == "" {
+				return m, nil
 			}
 
-			if targetPath != "" {
-				m.confirmDelete = true
-				m.deleteTarget = selected
-				m.deleteTargetPath = targetPath
+			if err := utils.CopyToClipboard(targetPath); err != nil {
+				m.statusMsg = errorStyle.Render(fmt.Sprintf("❌ %v", err))
+			} else {
+				m.statusMsg = successStyle.Render("✓ Copied " + targetPath)
 			}
 
 		case "g":
@@ -307,7 +441,7 @@ func (m JournalBrowserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return OpenWeeklySummaryMenuMsg{}
 			}
 
-		case "enter", "l", "right", " ":
+		case ActionOpen, "right", " ":
 			if len(m.items) == 0 {
 				return m, nil
 			}
@@ -348,7 +482,7 @@ func (m JournalBrowserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 
 				// Regular journal file - try to parse date
-				date, err := time.Parse("2006-01-02", fileName)
+				date, err := m.journalService.ParseJournalFilename(fileName + ".md")
 				if err == nil {
 					return m, func() tea.Msg {
 						return OpenJournalMsg{date: date}
@@ -368,8 +502,10 @@ func (m JournalBrowserModel) View() string {
 
 	s := browserTitleStyle.Render("📚 Journals") + "\n"
 
-	// Show breadcrumb
-	if len(m.breadcrumb) > 0 {
+	// Show breadcrumb, or the tag the list is filtered by
+	if m.tagFilter != "" {
+		s += breadcrumbStyle.Render("Journals tagged #"+m.tagFilter) + "\n"
+	} else if len(m.breadcrumb) > 0 {
 		path := "Journals"
 		for _, part := range m.breadcrumb {
 			path += " > " + part
@@ -378,6 +514,15 @@ func (m JournalBrowserModel) View() string {
 	}
 	s += "\n"
 
+	if m.showingTags {
+		s += tagListStyle.Render(m.renderTagList())
+		s += "\n\n" + helpStyle.Render("↑/k: up • ↓/j: down • enter/l: select tag • esc: back")
+		if m.width > 0 && m.height > 0 {
+			return lipgloss.NewStyle().Width(m.width).Height(m.height).Render(s)
+		}
+		return s
+	}
+
 	// Show filename input if creating new journal
 	if m.creatingNew {
 		inputBox := lipgloss.NewStyle().
@@ -397,7 +542,9 @@ func (m JournalBrowserModel) View() string {
 	}
 
 	// Show items
-	if len(m.items) == 0 {
+	if len(m.items) == 0 && m.tagFilter != "" {
+		s += "  No journals tagged #" + m.tagFilter + ".\n"
+	} else if len(m.items) == 0 {
 		s += "  No journals found.\n"
 	} else {
 		for i, item := range m.items {
@@ -409,7 +556,11 @@ func (m JournalBrowserModel) View() string {
 		}
 	}
 
-	s += "\n" + helpStyle.Render("n: new entry • ↑/k: up • ↓/j: down • enter/l: open • esc/h: back • g: weekly summary • d: delete • q: quit")
+	if m.statusMsg != "" {
+		s += "\n" + m.statusMsg + "\n"
+	}
+
+	s += "\n" + helpStyle.Render(journalBrowserHelp(m.opts.Keys))
 
 	// Fill the screen
 	if m.width > 0 && m.height > 0 {
@@ -422,6 +573,21 @@ func (m JournalBrowserModel) View() string {
 	return s
 }
 
+func (m JournalBrowserModel) renderTagList() string {
+	s := "📌 Select a Tag\n\n"
+	if len(m.allTags) == 0 {
+		return s + "  No tags found\n"
+	}
+	for i, tag := range m.allTags {
+		if i == m.tagCursor {
+			s += selectedStyle.Render("▶ #"+tag) + "\n"
+		} else {
+			s += "  #" + tag + "\n"
+		}
+	}
+	return s
+}
+
 type OpenJournalMsg struct {
 	date time.Time
 }
@@ -441,3 +607,21 @@ type OpenWeeklySummaryMenuMsg struct{}
 type OpenWeeklySummaryFileMsg struct {
 	filePath string
 }
+
+// journalItemPath builds the full path of a browser item from the journal dir, the
+// breadcrumb and the item label. Returns "" for items without a path ("Today's Journal").
+func journalItemPath(journalDir string, breadcrumb []string, item string) string {
+	currentPath := journalDir
+	for _, part := range breadcrumb {
+		currentPath = filepath.Join(currentPath, part)
+	}
+
+	if strings.HasPrefix(item, "📁") {
+		return filepath.Join(currentPath, strings.TrimPrefix(item, "📁 "))
+	}
+	if strings.HasPrefix(item, "📄") {
+		return filepath.Join(currentPath, strings.TrimPrefix(item, "📄 ")+".md")
+	}
+
+	return ""
+}
@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/redjax/notetkr/internal/services"
+)
+
+func TestJournalItemPath(t *testing.T) {
+	journalDir := filepath.Join("home", "user", "journal")
+
+	tests := []struct {
+		name       string
+		breadcrumb []string
+		item       string
+		want       string
+	}{
+		{"file in week folder", []string{"2025", "10", "Week3"}, "📄 2025-10-15", filepath.Join(journalDir, "2025", "10", "Week3", "2025-10-15.md")},
+		{"folder at root", nil, "📁 2025", filepath.Join(journalDir, "2025")},
+		{"today's journal", nil, "📔 Today's Journal", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := journalItemPath(journalDir, tt.breadcrumb, tt.item); got != tt.want {
+				t.Errorf("journalItemPath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJournalBrowserTagFilter(t *testing.T) {
+	journalDir := t.TempDir()
+	js := services.NewJournalService(journalDir, time.Sunday)
+	for d, content := range map[int]string{2: "Flight #travel\n", 5: "Train #travel\n", 6: "Desk day #work\n"} {
+		if err := js.WriteJournal(time.Date(2025, time.June, d, 0, 0, 0, 0, time.Local), content); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := NewJournalBrowser(js, DefaultOptions(), journalDir, 80, 24)
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	m = updated.(JournalBrowserModel)
+	if !m.showingTags || strings.Join(m.allTags, ",") != "travel,work" {
+		t.Fatalf("t should list the journal tags, got %v", m.allTags)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(JournalBrowserModel)
+	if want := []string{"📄 2025-06-05", "📄 2025-06-02"}; strings.Join(m.items, ",") != strings.Join(want, ",") {
+		t.Errorf("travel items = %v, want %v", m.items, want)
+	}
+	if got, want := m.selectedPath(), js.GetJournalPathForDate(time.Date(2025, time.June, 5, 0, 0, 0, 0, time.Local)); got != want {
+		t.Errorf("selectedPath = %q, want %q", got, want)
+	}
+	if view := m.View(); !strings.Contains(view, "#travel") {
+		t.Error("view should show the tag filter")
+	}
+
+	// Back clears the filter
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(JournalBrowserModel)
+	if m.tagFilter != "" || len(m.items) == 0 || m.items[0] != "📔 Today's Journal" {
+		t.Errorf("esc should return to the folders, got %v", m.items)
+	}
+}
@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -24,6 +25,7 @@ const (
 
 type JournalEditorModel struct {
 	journalService   *services.JournalService
+	opts             Options
 	date             time.Time
 	filePath         string
 	textarea         textarea.Model
@@ -37,10 +39,20 @@ type JournalEditorModel struct {
 	redoStack        []undoState
 	lastContent      string
 	clipboardHandler *utils.ClipboardImageHandler
+	pastes           pasteQueue // Clipboard image pastes waiting to be linked, the first one saving
 	showQuitConfirm  bool
+	diff             *diffOverlay     // Unsaved changes being reviewed, nil when not showing them
+	visual           *visualSelection // Line-wise visual mode selection, nil outside visual mode
 	initialContent   string
+	wordCount        int  // Words in the content, excluding frontmatter
 	wasJustCreated   bool // Track if this journal was created in this session
 	previewService   *services.PreviewService
+	goalCol          int       // Column to restore on vertical moves, -1 when unset
+	quitToShell      bool      // The pending quit confirmation came from ctrl+c
+	lineEnding       string    // Line ending of the loaded file, restored on save
+	navigateTo       time.Time // Day the pending confirmation would open, zero when quitting
+	leaveAfterSave   bool      // Leave the editor once the pending save succeeds
+	focusMode        bool      // Chrome hidden, textarea filling the window
 }
 
 var (
@@ -69,9 +81,9 @@ var (
 			Bold(true)
 )
 
-func NewJournalEditor(journalService *services.JournalService, date time.Time) JournalEditorModel {
+func NewJournalEditor(journalService *services.JournalService, opts Options, date time.Time) JournalEditorModel {
 	ta := textarea.New()
-	ta.Placeholder = "Press 'i' to enter insert mode and start writing..."
+	ta.Placeholder = opts.EditorKeymap.placeholder()
 	ta.Focus() // Keep focused so cursor is visible
 	ta.CharLimit = 0
 	ta.ShowLineNumbers = false
@@ -79,49 +91,53 @@ func NewJournalEditor(journalService *services.JournalService, date time.Time) J
 	ta.SetWidth(80)
 	ta.SetHeight(20)
 
-	clipboardHandler := utils.NewClipboardImageHandler()
+	clipboardHandler := utils.NewClipboardImageHandler(opts.Images)
 	_ = clipboardHandler.Initialize()
 
 	m := JournalEditorModel{
 		journalService:   journalService,
+		opts:             opts,
 		date:             date,
 		textarea:         ta,
-		mode:             ModeNormal,
+		mode:             opts.EditorKeymap.initialMode(),
 		saved:            false,
 		undoStack:        []undoState{},
+		goalCol:          -1,
 		redoStack:        []undoState{},
 		lastContent:      "",
 		clipboardHandler: clipboardHandler,
-		previewService:   services.NewPreviewService(),
+		previewService:   services.NewPreviewService(opts.Preview),
 	}
 
 	return m
 }
 
 // NewJournalEditorWithFilename creates a new journal editor with a custom filepath
-func NewJournalEditorWithFilename(journalService *services.JournalService, filepath string) JournalEditorModel {
+func NewJournalEditorWithFilename(journalService *services.JournalService, opts Options, filepath string) JournalEditorModel {
 	ta := textarea.New()
-	ta.Placeholder = "Press 'i' to enter insert mode and start writing..."
+	ta.Placeholder = opts.EditorKeymap.placeholder()
 	ta.Focus()
 	ta.CharLimit = 0
 	ta.ShowLineNumbers = false
 	ta.SetWidth(80)
 	ta.SetHeight(20)
 
-	clipboardHandler := utils.NewClipboardImageHandler()
+	clipboardHandler := utils.NewClipboardImageHandler(opts.Images)
 	_ = clipboardHandler.Initialize()
 
 	m := JournalEditorModel{
 		journalService:   journalService,
+		opts:             opts,
 		filePath:         filepath,
 		textarea:         ta,
-		mode:             ModeNormal,
+		mode:             opts.EditorKeymap.initialMode(),
 		saved:            false,
 		undoStack:        []undoState{},
+		goalCol:          -1,
 		redoStack:        []undoState{},
 		lastContent:      "",
 		clipboardHandler: clipboardHandler,
-		previewService:   services.NewPreviewService(),
+		previewService:   services.NewPreviewService(opts.Preview),
 		wasJustCreated:   true, // Mark as newly created
 	}
 
@@ -173,9 +189,12 @@ func (m JournalEditorModel) loadJournal() tea.Msg {
 // createOrReadCustomJournal creates or reads a custom-named journal file
 func (m JournalEditorModel) createOrReadCustomJournal() (string, error) {
 	// Check if file exists
-	content, err := os.ReadFile(m.filePath)
+	content, err := m.journalService.ReadJournalFile(m.filePath)
 	if err == nil {
-		return string(content), nil
+		return content, nil
+	}
+	if errors.Is(err, services.ErrBinaryContent) {
+		return "", err
 	}
 
 	// File doesn't exist, create it with default template
@@ -196,7 +215,7 @@ func (m JournalEditorModel) createOrReadCustomJournal() (string, error) {
 }
 
 func (m JournalEditorModel) saveJournal() tea.Msg {
-	content := m.textarea.Value()
+	content := services.RestoreLineEndings(m.textarea.Value(), m.lineEnding)
 
 	var err error
 	// If we have a custom filepath, write directly to it
@@ -207,7 +226,7 @@ func (m JournalEditorModel) saveJournal() tea.Msg {
 	}
 
 	if err != nil {
-		return JournalEditorErrorMsg{err: err}
+		return JournalSaveFailedMsg{err: err}
 	}
 
 	return JournalSavedMsg{}
@@ -222,22 +241,34 @@ func (m JournalEditorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 		// Reserve space for title and help text
 		m.textarea.SetWidth(msg.Width - 4)
-		m.textarea.SetHeight(msg.Height - 7)
+		m.resizeTextarea()
 		return m, nil
 
 	case JournalEditorLoadedMsg:
 		m.filePath = msg.filePath
+		// Edit with "\n" endings, restoring the file's own endings on save
+		m.lineEnding = services.DetectLineEnding(msg.content)
+		msg.content = services.NormalizeLineEndings(msg.content)
 		m.textarea.SetValue(msg.content)
 		m.wasJustCreated = msg.wasCreated // Track if this was newly created
 		// Initialize undo stack with the loaded content
 		m.lastContent = msg.content
 		m.initialContent = msg.content
+		m.updateWordCount()
 		// Position cursor appropriately - return a command to do this after SetValue processes
 		if msg.wasCreated {
 			return m, func() tea.Msg {
 				return PositionCursorMsg{}
 			}
 		}
+		// Continue logging today's entry from its latest time section
+		if m.opts.JumpToLatestTimeSection && isToday(m.date) {
+			if line := lastTimeSectionLine(msg.content); line >= 0 {
+				return m, func() tea.Msg {
+					return JumpToTimeSectionMsg{line: line}
+				}
+			}
+		}
 		return m, nil
 
 	case PositionCursorMsg:
@@ -251,11 +282,31 @@ func (m JournalEditorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.textarea.CursorEnd()
 		return m, nil
 
+	case JumpToTimeSectionMsg:
+		m.textarea.SetCursor(0)
+		for m.textarea.Line() > 0 {
+			m.textarea.CursorUp()
+		}
+		for m.textarea.Line() < msg.line && m.textarea.Line() < m.textarea.LineCount()-1 {
+			m.textarea.CursorDown()
+		}
+		m.textarea.CursorEnd()
+		return m, nil
+
 	case JournalEditorErrorMsg:
 		m.err = msg.err
 		m.saveMsg = ""
 		return m, nil
 
+	case JournalSaveFailedMsg:
+		// Stay in the editor so the unsaved text isn't lost
+		m.saved = false
+		m.leaveAfterSave = false
+		m.quitToShell = false
+		m.navigateTo = time.Time{}
+		m.saveMsg = fmt.Sprintf("❌ Save failed: %v", msg.err)
+		return m, nil
+
 	case JournalSavedMsg:
 		m.saved = true
 		m.saveMsg = "✓ Saved"
@@ -269,6 +320,10 @@ func (m JournalEditorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		m.initialContent = m.textarea.Value()
+		if m.leaveAfterSave {
+			m.leaveAfterSave = false
+			return m, m.afterConfirm()
+		}
 		// Clear save message after 2 seconds
 		return m, tea.Tick(time.Second*2, func(t time.Time) tea.Msg {
 			return ClearSaveMsg{}
@@ -278,64 +333,91 @@ func (m JournalEditorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.saveMsg = ""
 		return m, nil
 
+	case imagePastedMsg:
+		if msg.err != nil {
+			m.saveMsg = fmt.Sprintf("❌ Error: %v", msg.err)
+		} else {
+			m.insertImageLink(msg.filename)
+			m.saveMsg = "✓ Image inserted successfully!"
+		}
+		m.pastes, cmd = m.pastes.pop(m.clipboardHandler)
+		return m, cmd
+
 	case tea.KeyMsg:
-		// Handle mode-specific keys
-		if m.mode == ModeNormal {
-			// Handle quit confirmation dialog
-			if m.showQuitConfirm {
-				switch msg.String() {
-				case "y", "Y":
-					// User wants to save before quitting
-					m.showQuitConfirm = false
-					m.saved = false
-					m.saveMsg = "Saving..."
-					// Save and then return to browser
-					return m, tea.Batch(
-						m.saveJournal,
-						func() tea.Msg {
-							return BackToJournalBrowserMsg{}
-						},
-					)
-				case "n", "N":
-					// User wants to quit without saving
-					m.showQuitConfirm = false
-					return m, func() tea.Msg {
-						return BackToJournalBrowserMsg{}
-					}
-				case "esc":
-					// User cancelled, stay in editor
-					m.showQuitConfirm = false
-					return m, nil
+		// ctrl+c quits, but asks first if it would lose unsaved changes
+		if msg.String() == "ctrl+c" && !m.showQuitConfirm {
+			return m.quitFromCtrlC()
+		}
+
+		// A binary file was refused; only allow leaving so it can't be overwritten
+		if errors.Is(m.err, services.ErrBinaryContent) {
+			if msg.String() == "esc" || msg.String() == "q" {
+				return m, func() tea.Msg {
+					return BackToJournalBrowserMsg{}
 				}
-				return m, nil
 			}
+			return m, nil
+		}
+
+		// Any key other than a normal-mode vertical move forgets the goal column
+		if m.mode != ModeNormal || !isVerticalMoveKey(msg.String()) {
+			m.goalCol = -1
+		}
+
+		// Scroll or close the unsaved changes
+		if m.diff != nil {
+			if m.diff.update(msg.String(), m.textarea.Height()) {
+				m.diff = nil
+			}
+			return m, nil
+		}
 
+		// Handle quit confirmation dialog
+		if m.showQuitConfirm {
 			switch msg.String() {
+			case "d", "D":
+				// Review the changes before deciding
+				m.diff = newDiffOverlay(m.initialContent, m.textarea.Value())
+				return m, nil
+
+			case "y", "Y":
+				// User wants to save before quitting
+				m.showQuitConfirm = false
+				m.saved = false
+				m.saveMsg = "Saving..."
+				// Save, then leave (or open the other day) once the save succeeds
+				m.leaveAfterSave = true
+				return m, m.saveJournal
+			case "n", "N":
+				// User wants to quit without saving
+				m.showQuitConfirm = false
+				return m, m.afterConfirm()
+			case "esc":
+				// User cancelled, stay in editor
+				m.showQuitConfirm = false
+				m.quitToShell = false
+				m.navigateTo = time.Time{}
+				return m, nil
 			case "ctrl+c":
+				// A second ctrl+c quits without saving
 				return m, tea.Quit
+			}
+			return m, nil
+		}
 
-			case "q":
-				// Check if this is a newly created journal (in this session) that is still empty/unchanged
-				if m.wasJustCreated && m.isEmpty() {
-					// Delete the empty journal file
-					if m.filePath != "" {
-						_ = m.journalService.DeleteJournal(m.filePath)
-					}
-					return m, func() tea.Msg {
-						return BackToJournalBrowserMsg{}
-					}
-				}
+		// Select lines in visual mode
+		if m.visual != nil {
+			return m.updateVisual(msg)
+		}
 
-				// Check if there are unsaved changes
-				if m.hasUnsavedChanges() {
-					m.showQuitConfirm = true
-					return m, nil
-				}
-				// No unsaved changes, return to journal browser
-				return m, func() tea.Msg {
-					return BackToJournalBrowserMsg{}
-				}
+		// Shared actions like save and undo, bound by the active keymap
+		if model, cmd, ok := m.runAction(m.opts.EditorKeymap.action(m.mode, msg.String())); ok {
+			return model, cmd
+		}
 
+		// Handle mode-specific keys
+		if m.mode == ModeNormal {
+			switch msg.String() {
 			case "i":
 				// Enter insert mode
 				m.mode = ModeInsert
@@ -354,40 +436,23 @@ func (m JournalEditorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Use smart indentation
 				return m, m.insertNewLineWithIndent()
 
-			case "ctrl+s":
-				// Save journal (works in both modes)
-				m.saved = false
-				m.saveMsg = "Saving..."
-				return m, m.saveJournal
-
-			case "ctrl+z":
-				// Undo
-				m.undo()
-				return m, nil
-
-			case "ctrl+y":
-				// Redo
-				m.redo()
-				return m, nil
-
-			// Vim navigation in normal mode - convert to arrow keys
 			case "h":
 				m.textarea, cmd = m.textarea.Update(tea.KeyMsg{Type: tea.KeyLeft})
 				return m, cmd
 
-			case "j":
-				m.textarea, cmd = m.textarea.Update(tea.KeyMsg{Type: tea.KeyDown})
-				return m, cmd
+			case "j", "down":
+				m.moveVertical(1)
+				return m, nil
 
-			case "k":
-				m.textarea, cmd = m.textarea.Update(tea.KeyMsg{Type: tea.KeyUp})
-				return m, cmd
+			case "k", "up":
+				m.moveVertical(-1)
+				return m, nil
 
 			case "l":
 				m.textarea, cmd = m.textarea.Update(tea.KeyMsg{Type: tea.KeyRight})
 				return m, cmd
 
-			case "left", "right", "up", "down":
+			case "left", "right":
 				m.textarea, cmd = m.textarea.Update(msg)
 				return m, cmd
 
@@ -419,26 +484,20 @@ func (m JournalEditorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.textarea, cmd = m.textarea.Update(tea.KeyMsg{Type: tea.KeyCtrlEnd})
 				return m, cmd
 
-			case "d":
-				// Delete current line (like dd in vim)
-				m.deleteLine()
+			case "alt+k", "alt+up":
+				// Move the current task up within the Tasks section
+				m.moveTask(-1)
+				return m, nil
+
+			case "alt+j", "alt+down":
+				// Move the current task down within the Tasks section
+				m.moveTask(1)
 				return m, nil
 
 			case "x":
 				// Delete character under cursor (like x in vim)
 				m.deleteChar()
 				return m, nil
-
-			case "p":
-				// Preview markdown in browser
-				if m.filePath != "" {
-					content := m.textarea.Value()
-					go func() {
-						_ = m.previewService.PreviewMarkdown(m.filePath, content)
-					}()
-					m.saveMsg = "✓ Opening preview in browser..."
-				}
-				return m, nil
 			}
 			// Block all other keys in normal mode (don't pass to textarea)
 			return m, nil
@@ -450,47 +509,6 @@ func (m JournalEditorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.mode = ModeNormal
 				return m, nil
 
-			case "ctrl+s":
-				// Save journal
-				m.saved = false
-				m.saveMsg = "Saving..."
-				return m, m.saveJournal
-
-			case "ctrl+z":
-				// Undo
-				m.undo()
-				return m, nil
-
-			case "ctrl+y":
-				// Redo
-				m.redo()
-				return m, nil
-
-			case "alt+v":
-				// Paste image from clipboard
-				m.saveMsg = "Checking clipboard for image..."
-
-				if m.clipboardHandler == nil {
-					m.saveMsg = "⚠ Clipboard handler not initialized"
-					return m, nil
-				}
-
-				hasImage := m.clipboardHandler.HasImage()
-				if hasImage {
-					// Handle image paste
-					m.saveMsg = "Image detected, saving..."
-					if err := m.pasteImage(); err != nil {
-						m.saveMsg = fmt.Sprintf("❌ Error: %v", err)
-					} else {
-						m.saveMsg = "✓ Image inserted successfully!"
-					}
-					return m, nil
-				}
-
-				// No image in clipboard
-				m.saveMsg = "❌ No image in clipboard"
-				return m, nil
-
 			case "enter":
 				// Smart indentation for lists
 				return m, m.insertNewLineWithIndent()
@@ -505,6 +523,16 @@ func (m JournalEditorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.unindentCurrentLine()
 				return m, nil
 
+			case "alt+up":
+				// Move the current task up within the Tasks section
+				m.moveTask(-1)
+				return m, nil
+
+			case "alt+down":
+				// Move the current task down within the Tasks section
+				m.moveTask(1)
+				return m, nil
+
 			case "ctrl+left":
 				// Jump backwards by word
 				m.jumpWordBackward()
@@ -525,12 +553,10 @@ func (m JournalEditorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.deleteWordForward()
 				return m, nil
 
-			case "ctrl+c":
-				return m, tea.Quit
-
 			default:
 				// Pass all other keys to textarea in insert mode
 				m.textarea, cmd = m.textarea.Update(msg)
+				m.updateWordCount()
 				return m, cmd
 			}
 		}
@@ -545,6 +571,211 @@ func (m JournalEditorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// runAction performs a shared editor action bound by the active keymap (see
+// editorAction), reporting false if action isn't one the journal editor has
+func (m JournalEditorModel) runAction(action string) (tea.Model, tea.Cmd, bool) {
+	switch action {
+	case editorActionBack:
+		model, cmd := m.leave()
+		return model, cmd, true
+
+	case editorActionSave:
+		m.saved = false
+		m.saveMsg = "Saving..."
+		return m, m.saveJournal, true
+
+	case editorActionUndo:
+		m.undo()
+		return m, nil, true
+
+	case editorActionRedo:
+		m.redo()
+		return m, nil, true
+
+	case editorActionPreview:
+		// Preview markdown in browser
+		if m.filePath != "" {
+			content := m.textarea.Value()
+			go func() {
+				_ = m.previewService.PreviewMarkdown(m.filePath, content)
+			}()
+			m.saveMsg = "✓ Opening preview in browser..."
+		}
+		return m, nil, true
+
+	case editorActionPasteImage:
+		return m, m.pasteClipboardImage(), true
+
+	case editorActionFocus:
+		// Toggle distraction-free focus mode
+		m.toggleFocusMode()
+		return m, nil, true
+
+	case editorActionDiff:
+		// Review the unsaved changes as a diff
+		m.diff = newDiffOverlay(m.initialContent, m.textarea.Value())
+		return m, nil, true
+
+	case editorActionNextEntry:
+		// Open the next day's entry
+		model, cmd := m.navigateToDay(1)
+		return model, cmd, true
+
+	case editorActionPrevEntry:
+		// Open the previous day's entry
+		model, cmd := m.navigateToDay(-1)
+		return model, cmd, true
+
+	case editorActionDeleteLine:
+		// Delete current line (like dd in vim)
+		m.deleteLine()
+		return m, nil, true
+
+	case editorActionSelect:
+		// Select whole lines, starting with this one
+		m.visual = newVisualSelection(m.textarea.Line(), m.textarea.Height())
+		return m, nil, true
+
+	case editorActionPasteLines:
+		// Paste the lines last yanked or deleted in visual mode below this one
+		m.pasteLines()
+		return m, nil, true
+
+	case editorActionNextTask:
+		m.jumpToTask(true)
+		return m, nil, true
+
+	case editorActionPrevTask:
+		m.jumpToTask(false)
+		return m, nil, true
+	}
+
+	return m, nil, false
+}
+
+// leave goes back to the journal browser, asking first if there are unsaved changes
+func (m JournalEditorModel) leave() (tea.Model, tea.Cmd) {
+	// Check if this is a newly created journal (in this session) that is still empty/unchanged
+	if m.wasJustCreated && m.isEmpty() {
+		// Delete the empty journal file
+		if m.filePath != "" {
+			_ = m.journalService.DeleteJournal(m.filePath)
+		}
+		return m, func() tea.Msg {
+			return BackToJournalBrowserMsg{}
+		}
+	}
+
+	// Check if there are unsaved changes
+	if m.hasUnsavedChanges() {
+		m.showQuitConfirm = true
+		return m, nil
+	}
+	// No unsaved changes, return to journal browser
+	return m, func() tea.Msg {
+		return BackToJournalBrowserMsg{}
+	}
+}
+
+// pasteClipboardImage queues saving the image (or image file) on the clipboard as an
+// attachment, returning the command that saves it. The link is inserted at the cursor
+// once it has been saved (see imagePastedMsg).
+func (m *JournalEditorModel) pasteClipboardImage() tea.Cmd {
+	if m.filePath == "" {
+		m.saveMsg = "❌ Error: cannot determine journal location for image attachment"
+		return nil
+	}
+	if m.clipboardHandler == nil {
+		m.saveMsg = "⚠ Clipboard handler not initialized"
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.pastes, cmd = m.pastes.push(m.clipboardHandler, imagePaste{
+		// Use a centralized .attachments/imgs directory, naming the image after the current file
+		imgsDir:  filepath.Join(m.journalService.GetJournalDir(), ".attachments", "imgs"),
+		baseName: clipboardImageBaseName(m.filePath),
+	})
+	m.saveMsg = "Saving pasted image..."
+	return cmd
+}
+
+// updateVisual handles keys in line-wise visual mode: j/k extend the selection,
+// and d or y act on the selected lines
+func (m JournalEditorModel) updateVisual(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	from, to := m.visual.bounds(m.textarea.Line())
+
+	switch msg.String() {
+	case "esc", "v", "q":
+		m.visual = nil
+		return m, nil
+
+	case "j", "down":
+		m.moveVertical(1)
+
+	case "k", "up":
+		m.moveVertical(-1)
+
+	case "g":
+		m.moveToLine(0)
+
+	case "G":
+		m.moveToLine(m.textarea.LineCount() - 1)
+
+	case "y":
+		// Copy the lines to the register and go back to the top of the selection
+		lines := strings.Split(m.textarea.Value(), "\n")
+		lineRegister = append([]string(nil), lines[from:to+1]...)
+		m.visual = nil
+		m.moveToLine(from)
+		m.saveMsg = fmt.Sprintf("✓ Yanked %d line(s) • p: paste", to-from+1)
+		return m, nil
+
+	case "d", "x":
+		m.deleteLines(from, to)
+		m.visual = nil
+		m.saveMsg = fmt.Sprintf("Deleted %d line(s) • p: paste", to-from+1)
+		return m, nil
+	}
+
+	m.visual.scrollTo(m.textarea.Line(), m.textarea.Height())
+	return m, nil
+}
+
+// deleteLines deletes lines from through to as a single undo step, keeping them in
+// the line register
+func (m *JournalEditorModel) deleteLines(from, to int) {
+	lines := strings.Split(m.textarea.Value(), "\n")
+	if to >= len(lines) {
+		return
+	}
+	lineRegister = append([]string(nil), lines[from:to+1]...)
+
+	// Save current state before deletion
+	m.trackContentChange()
+
+	newContent, line := deleteLineRange(m.textarea.Value(), from, to)
+	m.textarea.SetValue(newContent)
+	m.moveToLine(line)
+
+	// Track the change after deletion
+	m.trackContentChange()
+}
+
+// pasteLines inserts the line register below the cursor line
+func (m *JournalEditorModel) pasteLines() {
+	if len(lineRegister) == 0 {
+		m.saveMsg = "Nothing to paste • select lines with v, then y or d"
+		return
+	}
+
+	m.trackContentChange()
+	newContent, line := pasteLinesAfter(m.textarea.Value(), m.textarea.Line(), lineRegister)
+	m.textarea.SetValue(newContent)
+	m.moveToLine(line)
+	m.trackContentChange()
+}
+
 // trackContentChange saves the current content to undo stack if it changed
 func (m *JournalEditorModel) trackContentChange() {
 	currentContent := m.textarea.Value()
@@ -564,6 +795,7 @@ func (m *JournalEditorModel) trackContentChange() {
 			m.undoStack = m.undoStack[1:]
 		}
 		m.lastContent = currentContent
+		m.updateWordCount()
 		// Clear redo stack on new change
 		m.redoStack = []undoState{}
 	}
@@ -593,6 +825,7 @@ func (m *JournalEditorModel) undo() {
 	// Restore content
 	m.textarea.SetValue(previousState.content)
 	m.lastContent = previousState.content
+	m.updateWordCount()
 
 	// Restore cursor position
 	// First, move to the correct line
@@ -634,6 +867,7 @@ func (m *JournalEditorModel) redo() {
 	// Restore content
 	m.textarea.SetValue(nextState.content)
 	m.lastContent = nextState.content
+	m.updateWordCount()
 
 	// Restore cursor position
 	// First, move to the correct line
@@ -658,38 +892,82 @@ func (m *JournalEditorModel) deleteLine() {
 		return
 	}
 
+	newContent, line, ok := deleteLineAt(content, m.textarea.Line())
+	if !ok {
+		return
+	}
+
 	// Save current state before deletion
 	m.trackContentChange()
 
-	lines := strings.Split(content, "\n")
-	currentLineNum := m.textarea.Line()
+	// Keep the cursor on the same line number, or the new last line if the last line was deleted
+	m.textarea.SetValue(newContent)
+	m.moveToLine(line)
 
-	// Check bounds
-	if currentLineNum >= len(lines) {
+	// Track the change after deletion
+	m.trackContentChange()
+}
+
+// resizeTextarea fits the textarea to the window, leaving room for the title and help
+func (m *JournalEditorModel) resizeTextarea() {
+	if m.height <= 0 {
 		return
 	}
+	m.textarea.SetHeight(editorTextareaHeight(m.height, 0, m.focusMode))
+}
 
-	currentLineText := lines[currentLineNum]
+// toggleFocusMode shows or hides the title, mode, and help around the textarea
+func (m *JournalEditorModel) toggleFocusMode() {
+	m.focusMode = !m.focusMode
+	m.resizeTextarea()
+}
 
-	// Move to start of line
-	m.textarea.CursorStart()
+// moveTask swaps the task on the cursor's line with the one above (delta -1) or
+// below (delta 1), keeping the cursor on the moved task
+func (m *JournalEditorModel) moveTask(delta int) {
+	newContent, line, ok := moveTaskLine(m.textarea.Value(), m.textarea.Line(), delta)
+	if !ok {
+		return
+	}
+	col := m.textarea.LineInfo().ColumnOffset
 
-	// Delete all characters on the line
-	for i := 0; i < len(currentLineText); i++ {
-		m.textarea, _ = m.textarea.Update(tea.KeyMsg{Type: tea.KeyDelete})
+	// Save current state before the move
+	m.trackContentChange()
+
+	m.textarea.SetValue(newContent)
+	m.moveToLine(line)
+	m.textarea.SetCursor(col)
+
+	// Track the change after the move
+	m.trackContentChange()
+}
+
+// moveToLine moves the cursor to the start of the given line
+func (m *JournalEditorModel) moveToLine(line int) {
+	m.textarea.CursorStart()
+	for m.textarea.Line() > 0 {
+		m.textarea.CursorUp()
+	}
+	for m.textarea.Line() < line && m.textarea.Line() < m.textarea.LineCount()-1 {
+		m.textarea.CursorDown()
 	}
+	m.textarea.CursorStart()
+}
 
-	// If this isn't the last line, delete the newline character too
-	if currentLineNum < len(lines)-1 {
-		m.textarea, _ = m.textarea.Update(tea.KeyMsg{Type: tea.KeyDelete})
-	} else if currentLineNum > 0 {
-		// If it's the last line but not the only line, delete the newline before it
-		m.textarea, _ = m.textarea.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+// jumpToTask moves the cursor to the start of the next or previous task line
+func (m *JournalEditorModel) jumpToTask(forward bool) {
+	target := adjacentTaskLine(m.textarea.Value(), m.textarea.Line(), forward)
+	if target < 0 {
+		return
 	}
 
-	// Cursor is now at the start of what was the next line (or end of previous line)
-	// Track the change after deletion
-	m.trackContentChange()
+	for m.textarea.Line() < target {
+		m.textarea.CursorDown()
+	}
+	for m.textarea.Line() > target {
+		m.textarea.CursorUp()
+	}
+	m.textarea.CursorStart()
 }
 
 // deleteChar deletes the character under the cursor (like x in vim)
@@ -727,6 +1005,11 @@ func (m *JournalEditorModel) deleteChar() {
 	m.trackContentChange()
 }
 
+// updateWordCount recounts the words shown in the title line
+func (m *JournalEditorModel) updateWordCount() {
+	m.wordCount = services.CountWords(m.textarea.Value())
+}
+
 // hasUnsavedChanges checks if the current content differs from the initial/saved content
 func (m *JournalEditorModel) hasUnsavedChanges() bool {
 	return m.textarea.Value() != m.initialContent
@@ -1091,28 +1374,8 @@ func (m *JournalEditorModel) unindentCurrentLine() {
 	}
 }
 
-// pasteImage handles pasting an image from the clipboard
-func (m *JournalEditorModel) pasteImage() error {
-	if m.clipboardHandler == nil {
-		return fmt.Errorf("clipboard handler not initialized")
-	}
-
-	if m.filePath == "" {
-		return fmt.Errorf("cannot determine journal location for image attachment")
-	}
-
-	// Get the journal directory
-	journalDir := m.journalService.GetJournalDir()
-
-	// Use a centralized .attachments/imgs directory
-	imgsDir := filepath.Join(journalDir, ".attachments", "imgs")
-
-	// Save the image and get the filename
-	filename, err := m.clipboardHandler.SaveClipboardImage(imgsDir, "image")
-	if err != nil {
-		return err
-	}
-
+// insertImageLink links the pasted image filename (in .attachments/imgs) at the cursor
+func (m *JournalEditorModel) insertImageLink(filename string) {
 	// Create the relative path for the markdown link
 	relativePath := fmt.Sprintf(".attachments/imgs/%s", filename)
 
@@ -1142,8 +1405,6 @@ func (m *JournalEditorModel) pasteImage() error {
 
 	// Track the change
 	m.trackContentChange()
-
-	return nil
 }
 
 func (m JournalEditorModel) View() string {
@@ -1153,17 +1414,39 @@ func (m JournalEditorModel) View() string {
 
 	var b strings.Builder
 
+	if m.focusMode {
+		// Just the text and one status line
+		if m.diff != nil {
+			b.WriteString(m.diff.view(m.textarea.Height()))
+		} else if m.visual != nil {
+			b.WriteString(m.visual.view(m.textarea.Value(), m.textarea.Line(), m.textarea.Width(), m.textarea.Height()))
+		} else {
+			b.WriteString(m.textarea.View())
+		}
+		b.WriteString("\n")
+		if m.showQuitConfirm {
+			b.WriteString(confirmTextStyle.Render("⚠ Unsaved changes. Save before leaving? (y/n, d: show changes, esc to cancel)"))
+		} else {
+			b.WriteString(editorHelpStyle.Render(focusIndicator(m.saveMsg, m.hasUnsavedChanges())))
+		}
+		return m.fillScreen(b.String())
+	}
+
 	// Title
 	title := fmt.Sprintf("📝 %s", m.date.Format("Monday, January 2, 2006"))
 	b.WriteString(editorTitleStyle.Render(title))
 	b.WriteString(" ")
 
 	// Mode indicator
-	if m.mode == ModeInsert {
-		b.WriteString(modeStyle.Render("-- INSERT --"))
+	if m.visual != nil {
+		b.WriteString(modeStyle.Render("-- VISUAL LINE --"))
+	} else if m.mode == ModeInsert {
+		b.WriteString(modeStyle.Render(m.opts.EditorKeymap.modeLabel(m.mode)))
 	} else {
-		b.WriteString(normalModeStyle.Render("-- NORMAL --"))
+		b.WriteString(normalModeStyle.Render(m.opts.EditorKeymap.modeLabel(m.mode)))
 	}
+	b.WriteString(" ")
+	b.WriteString(editorHelpStyle.Render(wordCountStatus(m.wordCount)))
 	b.WriteString("\n")
 
 	// Save status
@@ -1176,8 +1459,12 @@ func (m JournalEditorModel) View() string {
 		b.WriteString("\n")
 	}
 
-	// Textarea
-	b.WriteString(m.textarea.View())
+	// Textarea, or the unsaved changes in its place
+	if m.diff != nil {
+		b.WriteString(m.diff.view(m.textarea.Height()))
+	} else {
+		b.WriteString(m.textarea.View())
+	}
 	b.WriteString("\n\n")
 
 	// Show quit confirmation dialog if needed
@@ -1185,22 +1472,34 @@ func (m JournalEditorModel) View() string {
 		confirmStyle := lipgloss.NewStyle().
 			Foreground(lipgloss.Color("226")).
 			Bold(true)
-		b.WriteString(confirmStyle.Render("⚠ You have unsaved changes. Save before quitting? (y/n, esc to cancel)"))
+		prompt := "⚠ You have unsaved changes. Save before quitting? (y/n, d: show changes, esc to cancel)"
+		if !m.navigateTo.IsZero() {
+			prompt = fmt.Sprintf("⚠ You have unsaved changes. Save before opening %s? (y/n, d: show changes, esc to cancel)", m.navigateTo.Format("Monday, January 2"))
+		}
+		b.WriteString(confirmStyle.Render(prompt))
 		b.WriteString("\n\n")
 	}
 
 	// Help - different based on mode
 	var help string
-	if m.mode == ModeNormal {
-		help = "hjkl: move • i/a/o: insert • d: delete line • x: delete char • p: preview • 0/$: line start/end • g/G: top/bottom • ctrl+s: save • q: back"
+	if m.diff != nil {
+		help = "j/k: scroll • ctrl+d/u: page • esc: close"
+	} else if m.visual != nil {
+		help = "j/k: extend selection • g/G: to top/bottom • d: delete lines • y: yank lines • esc: cancel"
+	} else if !m.opts.EditorKeymap.modal() {
+		help = "alt+X: delete line • alt+V: select lines • alt+Y: paste lines • alt+{/}: prev/next task • alt+↑/↓: move task • alt+P/N: prev/next day • alt+D: diff • alt+F: focus mode • alt+p: preview • alt+v: paste image • ctrl+z/y: undo/redo • ctrl+s: save • esc: back"
+	} else if m.mode == ModeNormal {
+		help = "hjkl: move • i/a/o: insert • d: delete line • x: delete char • v: select lines • p: paste lines • {/}: prev/next task • alt+j/k: move task • [/]: prev/next day • F: focus mode • D: diff • P: preview • 0/$: line start/end • g/G: top/bottom • ctrl+s: save • q: back"
 	} else {
-		help = "esc: normal mode • ctrl+z/y: undo/redo • alt+v: paste image • ctrl+s: save • ctrl+c: quit"
+		help = "esc: normal mode • ctrl+z/y: undo/redo • alt+↑/↓: move task • alt+v: paste image • ctrl+s: save • ctrl+c: quit"
 	}
 	b.WriteString(editorHelpStyle.Render(help))
 
-	content := b.String()
+	return m.fillScreen(b.String())
+}
 
-	// Fill the screen
+// fillScreen sizes the view to the whole window
+func (m JournalEditorModel) fillScreen(content string) string {
 	if m.width > 0 && m.height > 0 {
 		style := lipgloss.NewStyle().
 			Width(m.width).
@@ -1223,6 +1522,91 @@ type JournalEditorErrorMsg struct {
 
 type JournalSavedMsg struct{}
 
+// JournalSaveFailedMsg reports a save that didn't write the entry
+type JournalSaveFailedMsg struct {
+	err error
+}
+
 type ClearSaveMsg struct{}
 
 type PositionCursorMsg struct{}
+
+// JumpToTimeSectionMsg moves the cursor to the given time section heading line
+type JumpToTimeSectionMsg struct {
+	line int
+}
+
+// moveVertical moves the cursor delta lines, keeping the goal column across short lines
+func (m *JournalEditorModel) moveVertical(delta int) {
+	info := m.textarea.LineInfo()
+	lines := strings.Split(m.textarea.Value(), "\n")
+	target, col, goal := verticalMove(lines, m.textarea.Line(), info.StartColumn+info.ColumnOffset, m.goalCol, delta)
+	m.goalCol = goal
+
+	for m.textarea.Line() < target {
+		m.textarea.CursorDown()
+	}
+	for m.textarea.Line() > target {
+		m.textarea.CursorUp()
+	}
+	m.textarea.SetCursor(col)
+}
+
+// afterConfirm closes the editor the way the pending confirmation asked: exiting
+// the program, opening another day, or going back to the browser
+func (m JournalEditorModel) afterConfirm() tea.Cmd {
+	if m.quitToShell {
+		return tea.Quit
+	}
+	if !m.navigateTo.IsZero() {
+		return openJournal(m.navigateTo)
+	}
+	return func() tea.Msg {
+		return BackToJournalBrowserMsg{}
+	}
+}
+
+// navigateToDay opens the entry delta days away, asking first if there are unsaved changes
+func (m JournalEditorModel) navigateToDay(delta int) (tea.Model, tea.Cmd) {
+	date := m.date.AddDate(0, 0, delta)
+
+	// Don't leave behind an untouched entry created just by flipping past it
+	if m.wasJustCreated && m.isEmpty() {
+		if m.filePath != "" {
+			_ = m.journalService.DeleteJournal(m.filePath)
+		}
+		return m, openJournal(date)
+	}
+
+	if m.hasUnsavedChanges() {
+		m.showQuitConfirm = true
+		m.navigateTo = date
+		return m, nil
+	}
+
+	return m, openJournal(date)
+}
+
+// openJournal returns a command that opens the journal editor for date
+func openJournal(date time.Time) tea.Cmd {
+	return func() tea.Msg {
+		return OpenJournalMsg{date: date}
+	}
+}
+
+// quitFromCtrlC exits the program on ctrl+c. With unsaved changes it shows the
+// save confirmation instead, unless the editor is configured to quit immediately.
+func (m JournalEditorModel) quitFromCtrlC() (tea.Model, tea.Cmd) {
+	if m.opts.CtrlC == CtrlCQuit || !m.hasUnsavedChanges() {
+		return m, tea.Quit
+	}
+
+	if m.opts.EditorKeymap.modal() {
+		m.mode = ModeNormal
+	}
+	m.diff = nil
+	m.visual = nil
+	m.showQuitConfirm = true
+	m.quitToShell = true
+	return m, nil
+}
@@ -0,0 +1,144 @@
+package tui
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/redjax/notetkr/internal/services"
+)
+
+func TestJournalEditorDayNavigation(t *testing.T) {
+	journalService := services.NewJournalService(t.TempDir(), time.Sunday)
+	date := time.Date(2024, time.May, 1, 0, 0, 0, 0, time.Local)
+
+	m := NewJournalEditor(journalService, DefaultOptions(), date)
+	updated, _ := m.Update(m.loadJournal())
+	m = updated.(JournalEditorModel)
+	// Treat the entry as existing so leaving doesn't delete it
+	m.wasJustCreated = false
+
+	key := func(r rune) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}} }
+
+	// Without changes, [ opens the previous day straight away
+	_, cmd := m.Update(key('['))
+	if cmd == nil {
+		t.Fatal("[ should open the previous day")
+	}
+	if msg, ok := cmd().(OpenJournalMsg); !ok || !msg.date.Equal(date.AddDate(0, 0, -1)) {
+		t.Errorf("[ opened %v, want April 30", msg.date)
+	}
+
+	// With unsaved changes, ] asks first
+	m.textarea.SetValue(m.textarea.Value() + "\nnew line")
+	updated, cmd = m.Update(key(']'))
+	m = updated.(JournalEditorModel)
+	if cmd != nil || !m.showQuitConfirm {
+		t.Fatal("] with unsaved changes should ask before leaving")
+	}
+	if !strings.Contains(m.View(), "Save before opening Thursday, May 2?") {
+		t.Error("confirmation should name the day being opened")
+	}
+
+	// esc stays, n discards and opens the next day
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(JournalEditorModel)
+	if m.showQuitConfirm || !m.navigateTo.IsZero() {
+		t.Error("esc should cancel the navigation")
+	}
+
+	updated, _ = m.Update(key(']'))
+	_, cmd = updated.Update(key('n'))
+	if cmd == nil {
+		t.Fatal("n should open the next day without saving")
+	}
+	if msg, ok := cmd().(OpenJournalMsg); !ok || !msg.date.Equal(date.AddDate(0, 0, 1)) {
+		t.Errorf("] opened %v, want May 2", msg.date)
+	}
+}
+
+func TestJournalEditorEmacsKeymapReachesNormalActions(t *testing.T) {
+	journalService := services.NewJournalService(t.TempDir(), time.Sunday)
+	date := time.Date(2024, time.May, 1, 0, 0, 0, 0, time.Local)
+
+	opts := DefaultOptions()
+	opts.EditorKeymap = KeymapEmacs
+	m := NewJournalEditor(journalService, opts, date)
+	updated, _ := m.Update(m.loadJournal())
+	m = updated.(JournalEditorModel)
+	m.wasJustCreated = false
+
+	alt := func(r rune) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}, Alt: true} }
+
+	// alt+P/alt+N open the previous and next day, like [ and ]
+	for _, step := range []struct {
+		key  rune
+		want time.Time
+	}{
+		{'P', date.AddDate(0, 0, -1)},
+		{'N', date.AddDate(0, 0, 1)},
+	} {
+		_, cmd := m.Update(alt(step.key))
+		if cmd == nil {
+			t.Fatalf("alt+%c should open another day", step.key)
+		}
+		if msg, ok := cmd().(OpenJournalMsg); !ok || !msg.date.Equal(step.want) {
+			t.Errorf("alt+%c opened %v, want %v", step.key, msg.date, step.want)
+		}
+	}
+
+	// alt+X deletes the line, like d
+	m.textarea.SetValue("first\nsecond")
+	updated, _ = m.Update(alt('X'))
+	m = updated.(JournalEditorModel)
+	if got := m.textarea.Value(); got != "first" {
+		t.Errorf("content after alt+X = %q, want %q", got, "first")
+	}
+}
+
+func TestJournalEditorNavigatesOnlyAfterSaving(t *testing.T) {
+	journalService := services.NewJournalService(t.TempDir(), time.Sunday)
+	date := time.Date(2024, time.May, 1, 0, 0, 0, 0, time.Local)
+
+	m := NewJournalEditor(journalService, DefaultOptions(), date)
+	updated, _ := m.Update(m.loadJournal())
+	m = updated.(JournalEditorModel)
+	m.wasJustCreated = false
+	m.textarea.SetValue(m.textarea.Value() + "\nnew line")
+
+	// Make the save fail by putting a directory where the entry was
+	path := journalService.GetJournalPathForDate(date)
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(path, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	key := func(r rune) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}} }
+	updated, _ = m.Update(key(']'))
+	updated, cmd := updated.Update(key('y'))
+	updated, cmd = updated.Update(cmd())
+	m = updated.(JournalEditorModel)
+	if cmd != nil {
+		t.Fatal("a failed save shouldn't open the next day")
+	}
+	if !strings.Contains(m.saveMsg, "Save failed") || !strings.Contains(m.textarea.Value(), "new line") {
+		t.Errorf("a failed save should keep the text and show the error, got %q", m.saveMsg)
+	}
+
+	// Once saving works, the next day opens after the save
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	updated, _ = m.Update(key(']'))
+	updated, cmd = updated.Update(key('y'))
+	if _, cmd = updated.Update(cmd()); cmd == nil {
+		t.Fatal("expected the next day to open after saving")
+	}
+	if msg, ok := cmd().(OpenJournalMsg); !ok || !msg.date.Equal(date.AddDate(0, 0, 1)) {
+		t.Errorf("after saving, opened %v, want May 2", msg.date)
+	}
+}
@@ -0,0 +1,120 @@
+package tui
+
+import "strings"
+
+// Actions that can be rebound through the KeyMap. Their values are not valid
+// key names, so Resolve can substitute them into a key switch without clashing.
+const (
+	ActionUp   = "<up>"   // Navigate up one directory level (or back to the dashboard)
+	ActionPrev = "<prev>" // Move the cursor to the previous item
+	ActionNext = "<next>" // Move the cursor to the next item
+	ActionOpen = "<open>" // Open the selected item
+	ActionQuit = "<quit>" // Quit the program
+)
+
+// KeyMap holds the configurable navigation key bindings
+type KeyMap struct {
+	Up   []string // Keys that navigate up one directory level (or back to the dashboard)
+	Prev []string // Keys that move to the previous item
+	Next []string // Keys that move to the next item
+	Open []string // Keys that open the selected item
+	Quit []string // Keys that quit (ctrl+c always quits)
+}
+
+// DefaultKeyMap returns the built-in navigation bindings
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Up:   []string{"esc", "h"},
+		Prev: []string{"up", "k"},
+		Next: []string{"down", "j"},
+		Open: []string{"enter", "l"},
+		Quit: []string{"q"},
+	}
+}
+
+// withDefaults fills empty bindings with the defaults
+func (k KeyMap) withDefaults() KeyMap {
+	defaults := DefaultKeyMap()
+	if len(k.Up) == 0 {
+		k.Up = defaults.Up
+	}
+	if len(k.Prev) == 0 {
+		k.Prev = defaults.Prev
+	}
+	if len(k.Next) == 0 {
+		k.Next = defaults.Next
+	}
+	if len(k.Open) == 0 {
+		k.Open = defaults.Open
+	}
+	if len(k.Quit) == 0 {
+		k.Quit = defaults.Quit
+	}
+	return k
+}
+
+// bindings returns the keys bound to an action
+func (k KeyMap) bindings(action string) []string {
+	switch action {
+	case ActionUp:
+		return k.Up
+	case ActionPrev:
+		return k.Prev
+	case ActionNext:
+		return k.Next
+	case ActionOpen:
+		return k.Open
+	case ActionQuit:
+		return k.Quit
+	}
+	return nil
+}
+
+// Resolve returns the action bound to key, or key itself if it isn't rebindable
+func (k KeyMap) Resolve(key string) string {
+	for _, action := range []string{ActionUp, ActionPrev, ActionNext, ActionOpen, ActionQuit} {
+		for _, binding := range k.bindings(action) {
+			if binding == key {
+				return action
+			}
+		}
+	}
+	return key
+}
+
+// IsUp reports whether key is bound to up-navigation
+func (k KeyMap) IsUp(key string) bool {
+	return k.Resolve(key) == ActionUp
+}
+
+// HelpKeys returns an action's keys formatted for help text, e.g. "↑/k"
+func (k KeyMap) HelpKeys(action string) string {
+	keys := k.bindings(action)
+	labels := make([]string, len(keys))
+	for i, key := range keys {
+		labels[i] = keyLabel(key)
+	}
+	return strings.Join(labels, "/")
+}
+
+// UpHelp returns the up-navigation keys formatted for help text, e.g. "esc/h"
+func (k KeyMap) UpHelp() string {
+	return k.HelpKeys(ActionUp)
+}
+
+// keyLabel returns the short label shown for a key in help text
+func keyLabel(key string) string {
+	switch key {
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case "left":
+		return "←"
+	case "right":
+		return "→"
+	case " ":
+		return "space"
+	}
+	return key
+}
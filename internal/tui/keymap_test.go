@@ -0,0 +1,55 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/redjax/notetkr/internal/services"
+)
+
+func TestKeyMapControlsUpNavigation(t *testing.T) {
+	notesDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(notesDir, "work", "projects"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// Remap up-navigation to esc/backspace so "h" no longer goes up
+	opts := DefaultOptions()
+	opts.Keys = KeyMap{Up: []string{"esc", "backspace"}}.withDefaults()
+
+	m := NewNotesBrowser(services.NewNotesService(notesDir), opts, 80, 24)
+	m.currentPath = filepath.Join("work", "projects")
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'h'}})
+	m = updated.(NotesBrowserModel)
+	if m.currentPath != filepath.Join("work", "projects") {
+		t.Errorf("'h' navigated up to %q with a keymap that doesn't bind it", m.currentPath)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	m = updated.(NotesBrowserModel)
+	if m.currentPath != "work" {
+		t.Errorf("backspace should navigate up to %q, got %q", "work", m.currentPath)
+	}
+}
+
+func TestKeyMapFallsBackToDefaults(t *testing.T) {
+	keys := KeyMap{}.withDefaults()
+	if !keys.IsUp("esc") || !keys.IsUp("h") {
+		t.Errorf("empty keymap should fall back to defaults, got %v", keys.Up)
+	}
+}
+
+func TestEveryVimNormalActionHasEmacsBinding(t *testing.T) {
+	emacsActions := make(map[string]bool)
+	for _, action := range emacsBindings {
+		emacsActions[action] = true
+	}
+	for key, action := range vimNormalBindings {
+		if !emacsActions[action] {
+			t.Errorf("vim NORMAL %q runs %s, which has no emacs binding", key, action)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/redjax/notetkr/internal/services"
+)
+
+// NoteViewerModel shows a note read-only, with its frontmatter hidden and its tags in
+// the header, for reading a note without the risk of editing it by accident
+type NoteViewerModel struct {
+	notesService *services.NotesService
+	opts         Options
+	note         services.Note
+	body         string // Note content without its frontmatter
+	sort         services.NoteSortMode
+	scrollOffset int
+	width        int
+	height       int
+	err          error
+}
+
+// ViewNoteMsg opens a note in the read-only viewer
+type ViewNoteMsg struct {
+	note services.Note
+	sort services.NoteSortMode // Order of the browser it was opened from, passed on to the editor
+}
+
+func NewNoteViewer(notesService *services.NotesService, opts Options, note services.Note, width, height int) NoteViewerModel {
+	m := NoteViewerModel{
+		notesService: notesService,
+		opts:         opts,
+		note:         note,
+		width:        width,
+		height:       height,
+	}
+	content, err := notesService.ReadNote(note.FilePath)
+	if err != nil {
+		m.err = err
+		return m
+	}
+	m.body = strings.Trim(services.StripFrontMatter(services.NormalizeLineEndings(content)), "\n")
+	return m
+}
+
+func (m NoteViewerModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m NoteViewerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.scrollOffset = min(m.scrollOffset, m.maxScroll())
+		return m, nil
+
+	case tea.KeyMsg:
+		switch m.opts.Keys.Resolve(msg.String()) {
+		case ActionQuit, "ctrl+c":
+			return m, tea.Quit
+
+		case ActionUp:
+			return m, func() tea.Msg {
+				return BackToNotesBrowserMsg{}
+			}
+
+		case ActionPrev:
+			if m.scrollOffset > 0 {
+				m.scrollOffset--
+			}
+
+		case ActionNext:
+			if m.scrollOffset < m.maxScroll() {
+				m.scrollOffset++
+			}
+
+		case "pgup", "ctrl+u":
+			m.scrollOffset = max(0, m.scrollOffset-m.visibleLines())
+
+		case "pgdown", "ctrl+d", " ":
+			m.scrollOffset = min(m.maxScroll(), m.scrollOffset+m.visibleLines())
+
+		case "g":
+			m.scrollOffset = 0
+
+		case "G":
+			m.scrollOffset = m.maxScroll()
+
+		case "e":
+			// Switch to the editor for changes
+			filePath, sort := m.note.FilePath, m.sort
+			return m, func() tea.Msg {
+				return OpenNoteMsg{filePath: filePath, sort: sort}
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// contentWidth is the width the note body is wrapped to
+func (m NoteViewerModel) contentWidth() int {
+	if m.width < 44 {
+		return 80 // Default if width not set
+	}
+	return m.width - 4 // Account for padding
+}
+
+// visibleLines is how many lines of the note fit between the header and the help
+func (m NoteViewerModel) visibleLines() int {
+	headerLines := 5 // Title + tags + blank lines
+	footerLines := 3 // Scroll indicator + help text
+	if visible := m.height - headerLines - footerLines; visible > 0 {
+		return visible
+	}
+	return 10 // Default if height not set
+}
+
+// lines returns the note body wrapped to the content width
+func (m NoteViewerModel) lines() []string {
+	wrapped := lipgloss.NewStyle().Width(m.contentWidth()).Render(m.body)
+	return strings.Split(wrapped, "\n")
+}
+
+// maxScroll is the furthest the view scrolls, with the last line at the bottom
+func (m NoteViewerModel) maxScroll() int {
+	return max(0, len(m.lines())-m.visibleLines())
+}
+
+func (m NoteViewerModel) View() string {
+	if m.err != nil {
+		return fmt.Sprintf("\n  Error: %v\n\n  Press 'esc' to go back\n", m.err)
+	}
+
+	s := notesBrowserTitleStyle.Render("📖 "+m.note.DisplayName()) + "\n"
+	if len(m.note.Tags) > 0 {
+		s += noteTagStyle.Render("  #"+strings.Join(m.note.Tags, " #")) + "\n"
+	} else {
+		s += noteTagStyle.Render("  No tags") + "\n"
+	}
+	s += "\n"
+
+	lines := m.lines()
+	start := min(m.scrollOffset, len(lines))
+	end := min(start+m.visibleLines(), len(lines))
+	s += lipgloss.NewStyle().Padding(0, 2).Render(strings.Join(lines[start:end], "\n")) + "\n"
+
+	// Scroll indicator
+	if len(lines) > m.visibleLines() {
+		scrollInfo := fmt.Sprintf("  [Lines %d-%d of %d]", start+1, end, len(lines))
+		s += lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render(scrollInfo) + "\n"
+	} else {
+		s += "\n"
+	}
+
+	s += helpStyle.Render(renderHelp(
+		helpEntry{m.opts.Keys.HelpKeys(ActionPrev) + " " + m.opts.Keys.HelpKeys(ActionNext), "scroll"},
+		helpEntry{"space/pgdn", "page"},
+		helpEntry{"g/G", "top/bottom"},
+		helpEntry{"e", "edit"},
+		helpEntry{m.opts.Keys.HelpKeys(ActionUp), "back"},
+		helpEntry{m.opts.Keys.HelpKeys(ActionQuit), "quit"},
+	))
+
+	// Fill the screen
+	if m.width > 0 && m.height > 0 {
+		style := lipgloss.NewStyle().
+			Width(m.width).
+			Height(m.height)
+		return style.Render(s)
+	}
+
+	return s
+}
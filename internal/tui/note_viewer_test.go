@@ -0,0 +1,84 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestNoteViewerShowsNoteReadOnly(t *testing.T) {
+	notesDir := t.TempDir()
+	var body strings.Builder
+	for i := 1; i <= 40; i++ {
+		fmt.Fprintf(&body, "line %d\n", i)
+	}
+	path := filepath.Join(notesDir, "reading.md")
+	content := "---\ntitle: Reading list\ntags: books, Later\n---\n" + body.String()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Space in the browser opens the selected note in the viewer
+	app := NewNotesBrowserApp(testConfig(t, t.TempDir(), notesDir))
+	result, _ := app.Update(tea.WindowSizeMsg{Width: 80, Height: 20})
+	result, cmd := result.Update(tea.KeyMsg{Type: tea.KeySpace})
+	if cmd == nil {
+		t.Fatal("space should open the viewer")
+	}
+	result, _ = result.Update(cmd())
+	m, ok := result.(AppModel).currentView.(NoteViewerModel)
+	if !ok {
+		t.Fatalf("space opened %T, want NoteViewerModel", result.(AppModel).currentView)
+	}
+
+	view := m.View()
+	// Tags come in no particular order
+	for _, want := range []string{"Reading list", "#books", "#later", "line 1", "[Lines 1-12 of 40]"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "title:") {
+		t.Error("view should hide the frontmatter")
+	}
+
+	press := func(m NoteViewerModel, key tea.KeyMsg) NoteViewerModel {
+		updated, _ := m.Update(key)
+		return updated.(NoteViewerModel)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	m = press(m, runes("G"))
+	if m.scrollOffset != 28 || !strings.Contains(m.View(), "line 40") {
+		t.Errorf("G scrolled to %d, want the last line at the bottom (28)", m.scrollOffset)
+	}
+	m = press(m, runes("j"))
+	if m.scrollOffset != 28 {
+		t.Errorf("j past the end scrolled to %d", m.scrollOffset)
+	}
+	m = press(m, runes("k"))
+	m = press(m, runes("g"))
+	if m.scrollOffset != 0 {
+		t.Errorf("g scrolled to %d, want 0", m.scrollOffset)
+	}
+
+	// The note is never changed, and e hands it to the editor
+	if _, cmd := m.Update(runes("x")); cmd != nil {
+		t.Error("other keys should do nothing")
+	}
+	if data, _ := os.ReadFile(path); string(data) != content {
+		t.Error("viewing should leave the note untouched")
+	}
+	_, cmd = m.Update(runes("e"))
+	if msg, ok := cmd().(OpenNoteMsg); !ok || msg.filePath != path {
+		t.Errorf("e emitted %#v, want OpenNoteMsg for the note", msg)
+	}
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if _, ok := cmd().(BackToNotesBrowserMsg); !ok {
+		t.Error("esc should go back to the notes browser")
+	}
+}
@@ -5,11 +5,13 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/redjax/notetkr/internal/services"
+	"github.com/redjax/notetkr/internal/utils"
 )
 
 type FilterMode int
@@ -30,6 +32,7 @@ type directoryNode struct {
 
 type NotesBrowserModel struct {
 	notesService       *services.NotesService
+	opts               Options
 	notes              []services.Note
 	filteredNotes      []services.Note
 	directories        []string // Directories in current path
@@ -45,10 +48,16 @@ type NotesBrowserModel struct {
 	showingTags        bool
 	showingTemplates   bool
 	tagCursor          int
+	renamingTag        bool            // Prompting for a new name for the highlighted tag
+	tagRenameInput     textinput.Model // New name for the tag being renamed
 	templateCursor     int
 	confirmDelete      bool
 	deleteTarget       string
 	deleteTargetIdx    int
+	deleteIsDir        bool   // Whether the pending delete targets a category directory
+	deleteDirPath      string // Category path relative to notes root, for directory deletes
+	deleteDirItems     int    // Number of entries in the category pending deletion
+	statusMsg          string // Non-fatal error or status shown above the help line
 	previewService     *services.PreviewService
 	showingNewMenu     bool
 	newMenuCursor      int
@@ -60,6 +69,25 @@ type NotesBrowserModel struct {
 	moveDirTree        []*directoryNode // Flattened view of directory tree for move UI
 	moveCursor         int
 	moveCreatingNewDir bool
+	pastingNote        bool            // Prompting for a name for a note created from the clipboard
+	pasteInput         textinput.Model // Name input for the clipboard note
+	pasteContent       string          // Clipboard text captured when the prompt opened
+	renamingNote       bool            // Prompting for a new name for a note
+	renameInput        textinput.Model // New name for the note being renamed
+	renameTargetIdx    int             // Index in filteredNotes of the note being renamed
+	showingArchive     bool            // Listing archived notes to restore
+	archivedNotes      []services.Note // Archived notes, loaded when the list opens
+	archiveCursor      int
+	undoPath           string                     // Quick-deleted note the undo toast offers to restore, "" when it's gone
+	undoID             int                        // Identifies the undo toast's timer
+	trash              *noteTrash                 // Quick-deleted notes, shared with the app
+	readClipboard      func() (string, error)     // Clipboard text source (replaceable in tests)
+	copyHTML           func(string) (bool, error) // Clipboard HTML sink (replaceable in tests)
+	flatView           bool                       // Listing every note in the vault instead of one category
+	dirSort            services.DirSortMode       // How directories are ordered
+	noteSort           services.NoteSortMode      // How notes are ordered
+	refreshID          int                        // Identifies this browser's auto-refresh timer
+	lastRefresh        time.Time                  // When the notes were last loaded, for debouncing auto-refresh
 }
 
 var (
@@ -90,7 +118,7 @@ var (
 			Padding(1, 2)
 )
 
-func NewNotesBrowser(notesService *services.NotesService, width, height int) NotesBrowserModel {
+func NewNotesBrowser(notesService *services.NotesService, opts Options, width, height int) NotesBrowserModel {
 	searchInput := textinput.New()
 	searchInput.Placeholder = "Search notes..."
 	searchInput.CharLimit = 100
@@ -105,18 +133,42 @@ func NewNotesBrowser(notesService *services.NotesService, width, height int) Not
 	moveInput.CharLimit = 200
 	moveInput.Width = 50
 
+	pasteInput := textinput.New()
+	pasteInput.Placeholder = "Enter note name..."
+	pasteInput.CharLimit = 100
+	pasteInput.Width = 50
+
+	renameInput := textinput.New()
+	renameInput.Placeholder = "Enter new name..."
+	renameInput.CharLimit = 100
+	renameInput.Width = 50
+
+	tagRenameInput := textinput.New()
+	tagRenameInput.Placeholder = "Enter new tag name..."
+	tagRenameInput.CharLimit = 100
+	tagRenameInput.Width = 50
+
 	m := NotesBrowserModel{
 		notesService:     notesService,
+		opts:             opts,
+		renameInput:      renameInput,
+		tagRenameInput:   tagRenameInput,
 		searchInput:      searchInput,
 		categoryInput:    categoryInput,
 		moveInput:        moveInput,
+		pasteInput:       pasteInput,
+		readClipboard:    utils.ReadTextFromClipboard,
+		copyHTML:         utils.CopyHTMLToClipboard,
 		filterMode:       FilterNone,
 		showingTags:      false,
 		showingTemplates: false,
 		width:            width,
 		height:           height,
-		previewService:   services.NewPreviewService(),
+		previewService:   services.NewPreviewService(opts.Preview),
+		trash:            newNoteTrash(),
 	}
+	lastNotesRefreshID++
+	m.refreshID = lastNotesRefreshID
 
 	// Initialize default templates
 	m.notesService.InitializeDefaultTemplates()
@@ -127,16 +179,26 @@ func NewNotesBrowser(notesService *services.NotesService, width, height int) Not
 }
 
 func (m *NotesBrowserModel) loadNotes() {
-	notes, directories, err := m.notesService.ListNotesInPath(m.currentPath)
+	var notes []services.Note
+	var directories []string
+	var err error
+	if m.flatView {
+		notes, err = m.notesService.ListNotes()
+	} else {
+		notes, directories, err = m.notesService.ListNotesInPath(m.currentPath)
+	}
 	if err != nil {
 		m.err = err
 		return
 	}
 
+	services.SortNotes(notes, m.noteSort)
 	m.notes = notes
+	m.notesService.SortDirectories(m.currentPath, directories, m.dirSort)
 	m.directories = directories
 	m.filteredNotes = notes
 	m.cursor = 0
+	m.lastRefresh = time.Now()
 
 	// Load all tags
 	tags, err := m.notesService.GetAllTags()
@@ -278,8 +340,78 @@ func (m *NotesBrowserModel) loadTemplates() {
 	m.templateCursor = 0
 }
 
+// applyTagFilter shows only the notes (from the whole vault) tagged with tag
+func (m *NotesBrowserModel) applyTagFilter(tag string) {
+	notes, err := m.notesService.FilterByTag(tag)
+	if err != nil {
+		return
+	}
+	m.filteredNotes = notes
+	m.cursor = 0
+	m.filterMode = FilterTag
+}
+
+// notesBrowserPosition is where the notes browser was left when opening a note,
+// so returning to it can restore the category, sort order and selection
+type notesBrowserPosition struct {
+	path     string
+	flat     bool // Whether the flat list of every note was showing
+	cursor   int
+	selected string // Selected directory name or note path, found again if the list changed
+	dirSort  services.DirSortMode
+	noteSort services.NoteSortMode
+}
+
+// position records the browser's current category, sort order and selection
+func (m NotesBrowserModel) position() notesBrowserPosition {
+	pos := notesBrowserPosition{
+		path:     m.currentPath,
+		flat:     m.flatView,
+		cursor:   m.cursor,
+		dirSort:  m.dirSort,
+		noteSort: m.noteSort,
+	}
+	if m.cursor < len(m.directories) {
+		pos.selected = m.directories[m.cursor]
+	} else if noteIdx := m.cursor - len(m.directories); noteIdx < len(m.filteredNotes) {
+		pos.selected = m.filteredNotes[noteIdx].FilePath
+	}
+	return pos
+}
+
+// restorePosition goes back to a recorded position. The selection follows the
+// recorded item if it is still listed, otherwise the cursor stays in range.
+func (m *NotesBrowserModel) restorePosition(pos notesBrowserPosition) {
+	m.currentPath = pos.path
+	m.flatView = pos.flat
+	m.dirSort = pos.dirSort
+	m.noteSort = pos.noteSort
+	m.loadNotes()
+	if m.err != nil {
+		// The category is gone; start from the root instead
+		m.err = nil
+		m.currentPath = ""
+		m.loadNotes()
+		return
+	}
+
+	for i, dir := range m.directories {
+		if dir == pos.selected {
+			m.cursor = i
+			return
+		}
+	}
+	for i, note := range m.filteredNotes {
+		if note.FilePath == pos.selected {
+			m.cursor = len(m.directories) + i
+			return
+		}
+	}
+	m.cursor = max(0, min(pos.cursor, len(m.directories)+len(m.filteredNotes)-1))
+}
+
 func (m NotesBrowserModel) Init() tea.Cmd {
-	return nil
+	return m.scheduleAutoRefresh()
 }
 
 func (m NotesBrowserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -291,13 +423,43 @@ func (m NotesBrowserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 		return m, nil
 
+	case tea.FocusMsg:
+		if m.opts.NotesRefreshOnFocus {
+			m.autoRefresh(time.Now())
+		}
+		return m, nil
+
+	case undoExpiredMsg:
+		m.trash.expire(msg.id)
+		if msg.id == m.undoID {
+			m.undoPath = ""
+		}
+		return m, nil
+
+	case notesRefreshTickMsg:
+		if msg.id != m.refreshID {
+			return m, nil
+		}
+		m.autoRefresh(time.Now())
+		return m, m.scheduleAutoRefresh()
+
 	case tea.KeyMsg:
+		// Clear any previous status message on the next keypress
+		m.statusMsg = ""
+
 		// Handle delete confirmation
 		if m.confirmDelete {
 			switch msg.String() {
 			case "y", "Y":
 				// Confirm delete
-				if m.deleteTargetIdx >= 0 && m.deleteTargetIdx < len(m.filteredNotes) {
+				if m.deleteIsDir {
+					// Deleting a category (already confirmed, so force non-empty deletes)
+					if err := m.notesService.DeleteCategory(m.deleteDirPath, true); err != nil {
+						m.statusMsg = fmt.Sprintf("❌ %v", err)
+					} else {
+						m.loadNotes()
+					}
+				} else if m.deleteTargetIdx >= 0 && m.deleteTargetIdx < len(m.filteredNotes) {
 					note := m.filteredNotes[m.deleteTargetIdx]
 					err := m.notesService.DeleteNote(note.FilePath)
 					if err != nil {
@@ -312,6 +474,8 @@ func (m NotesBrowserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.confirmDelete = false
 				m.deleteTarget = ""
 				m.deleteTargetIdx = -1
+				m.deleteIsDir = false
+				m.deleteDirPath = ""
 				return m, nil
 
 			case "n", "N", "esc":
@@ -319,11 +483,54 @@ func (m NotesBrowserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.confirmDelete = false
 				m.deleteTarget = ""
 				m.deleteTargetIdx = -1
+				m.deleteIsDir = false
+				m.deleteDirPath = ""
 				return m, nil
 			}
 			return m, nil
 		}
 
+		// Handle the new name input for a tag being renamed
+		if m.renamingTag {
+			switch msg.String() {
+			case "esc":
+				m.renamingTag = false
+				m.tagRenameInput.Blur()
+				m.tagRenameInput.SetValue("")
+				return m, nil
+
+			case "enter":
+				newTag := strings.TrimSpace(m.tagRenameInput.Value())
+				if newTag == "" || m.tagCursor >= len(m.allTags) {
+					return m, nil
+				}
+
+				oldTag := m.allTags[m.tagCursor]
+				m.renamingTag = false
+				m.tagRenameInput.Blur()
+				m.tagRenameInput.SetValue("")
+				changed, err := m.notesService.RenameTag(oldTag, newTag)
+				m.restorePosition(m.position())
+				m.tagCursor = max(0, min(m.tagCursor, len(m.allTags)-1))
+				if err != nil {
+					m.statusMsg = fmt.Sprintf("❌ %v", err)
+					return m, nil
+				}
+				// Keep the renamed tag highlighted
+				newTag = strings.ToLower(strings.TrimPrefix(newTag, "#"))
+				if i := sort.SearchStrings(m.allTags, newTag); i < len(m.allTags) && m.allTags[i] == newTag {
+					m.tagCursor = i
+				}
+				m.statusMsg = fmt.Sprintf("✓ Renamed #%s to #%s in %d note(s)", oldTag, newTag, changed)
+				return m, nil
+
+			default:
+				var cmd tea.Cmd
+				m.tagRenameInput, cmd = m.tagRenameInput.Update(msg)
+				return m, cmd
+			}
+		}
+
 		// Handle tag selection mode
 		if m.showingTags {
 			switch msg.String() {
@@ -348,16 +555,21 @@ func (m NotesBrowserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			case "enter", "l":
 				if len(m.allTags) > 0 && m.tagCursor < len(m.allTags) {
-					selectedTag := m.allTags[m.tagCursor]
-					notes, err := m.notesService.FilterByTag(selectedTag)
-					if err == nil {
-						m.filteredNotes = notes
-						m.cursor = 0
-						m.filterMode = FilterTag
-					}
+					m.applyTagFilter(m.allTags[m.tagCursor])
 					m.showingTags = false
 				}
 				return m, nil
+
+			case "R":
+				// Rename (or merge) the highlighted tag across all notes
+				if m.tagCursor < len(m.allTags) {
+					m.renamingTag = true
+					m.tagRenameInput.SetValue(m.allTags[m.tagCursor])
+					m.tagRenameInput.CursorEnd()
+					m.tagRenameInput.Focus()
+					return m, textinput.Blink
+				}
+				return m, nil
 			}
 			return m, nil
 		}
@@ -381,7 +593,7 @@ func (m NotesBrowserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 					// Create the category directory
 					if err := m.notesService.CreateCategory(fullCategoryPath); err != nil {
-						m.err = err
+						m.statusMsg = fmt.Sprintf("❌ %v", err)
 					} else {
 						m.loadNotes()
 					}
@@ -398,6 +610,79 @@ func (m NotesBrowserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		// Handle name input for a note created from the clipboard
+		if m.pastingNote {
+			switch msg.String() {
+			case "esc":
+				m.pastingNote = false
+				m.pasteInput.Blur()
+				m.pasteInput.SetValue("")
+				m.pasteContent = ""
+				return m, nil
+
+			case "enter":
+				noteName := strings.TrimSpace(m.pasteInput.Value())
+				if noteName == "" {
+					return m, nil
+				}
+
+				filePath, err := m.notesService.CreateNoteWithBody(noteName, m.currentPath, m.pasteContent)
+				m.pastingNote = false
+				m.pasteInput.Blur()
+				m.pasteInput.SetValue("")
+				m.pasteContent = ""
+				if err != nil {
+					m.statusMsg = fmt.Sprintf("❌ %v", err)
+					return m, nil
+				}
+
+				m.loadNotes()
+				return m, func() tea.Msg {
+					return OpenNoteMsg{filePath: filePath}
+				}
+
+			default:
+				var cmd tea.Cmd
+				m.pasteInput, cmd = m.pasteInput.Update(msg)
+				return m, cmd
+			}
+		}
+
+		// Handle the new name input for a note being renamed
+		if m.renamingNote {
+			switch msg.String() {
+			case "esc":
+				m.renamingNote = false
+				m.renameInput.Blur()
+				m.renameInput.SetValue("")
+				return m, nil
+
+			case "enter":
+				newName := strings.TrimSpace(m.renameInput.Value())
+				if newName == "" || m.renameTargetIdx >= len(m.filteredNotes) {
+					return m, nil
+				}
+
+				note := m.filteredNotes[m.renameTargetIdx]
+				m.renamingNote = false
+				m.renameInput.Blur()
+				m.renameInput.SetValue("")
+				newPath, err := m.notesService.RenameNote(note.FilePath, newName)
+				if err != nil {
+					m.statusMsg = fmt.Sprintf("❌ %v", err)
+					return m, nil
+				}
+				m.statusMsg = renamedStatus("Renamed", newName, newPath)
+				m.loadNotes()
+				return m, nil
+
+			default:
+				var cmd tea.Cmd
+				m.renameInput, cmd = m.renameInput.Update(msg)
+				return m, cmd
+			}
+		}
+
 		// Handle move note directory selection or new directory input
 		if m.movingNote {
 			// If creating new directory, handle text input
@@ -413,10 +698,13 @@ func (m NotesBrowserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					destPath := strings.TrimSpace(m.moveInput.Value())
 					if destPath != "" && m.moveTargetIdx < len(m.filteredNotes) {
 						note := m.filteredNotes[m.moveTargetIdx]
-						// Move the note
-						if err := m.notesService.MoveNote(note.FilePath, destPath); err != nil {
-							m.err = err
+						// Create the new directory first so we don't silently merge into an existing one
+						if err := m.notesService.CreateCategory(destPath); err != nil {
+							m.statusMsg = fmt.Sprintf("❌ %v", err)
+						} else if newPath, err := m.notesService.MoveNote(note.FilePath, destPath); err != nil {
+							m.statusMsg = fmt.Sprintf("❌ %v", err)
 						} else {
+							m.statusMsg = renamedStatus("Moved", filepath.Base(note.FilePath), newPath)
 							m.loadNotes()
 						}
 					}
@@ -484,9 +772,10 @@ func (m NotesBrowserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					node := m.moveDirTree[m.moveCursor]
 					note := m.filteredNotes[m.moveTargetIdx]
 					// Move the note
-					if err := m.notesService.MoveNote(note.FilePath, node.fullPath); err != nil {
-						m.err = err
+					if newPath, err := m.notesService.MoveNote(note.FilePath, node.fullPath); err != nil {
+						m.statusMsg = fmt.Sprintf("❌ %v", err)
 					} else {
+						m.statusMsg = renamedStatus("Moved", filepath.Base(note.FilePath), newPath)
 						m.loadNotes()
 					}
 				}
@@ -513,22 +802,66 @@ func (m NotesBrowserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 
 			case "down", "j":
-				if m.newMenuCursor < 1 { // 0: New Note, 1: New Category
+				if m.newMenuCursor < 2 { // 0: New Note, 1: New Category, 2: Archived notes
 					m.newMenuCursor++
 				}
 				return m, nil
 
 			case "enter", "l":
 				m.showingNewMenu = false
-				if m.newMenuCursor == 0 {
+				switch m.newMenuCursor {
+				case 0:
 					// Show template selection for new note
 					m.showingTemplates = true
 					m.templateCursor = 0
-				} else {
+				case 1:
 					// Show category input
 					m.creatingCategory = true
 					m.categoryInput.Focus()
 					return m, textinput.Blink
+				default:
+					// List archived notes to restore
+					m.openArchive()
+				}
+				return m, nil
+			}
+			return m, nil
+		}
+
+		// Handle archived notes list
+		if m.showingArchive {
+			switch msg.String() {
+			case "q", "ctrl+c":
+				return m, tea.Quit
+
+			case "esc":
+				m.showingArchive = false
+				return m, nil
+
+			case "up", "k":
+				if m.archiveCursor > 0 {
+					m.archiveCursor--
+				}
+				return m, nil
+
+			case "down", "j":
+				if m.archiveCursor < len(m.archivedNotes)-1 {
+					m.archiveCursor++
+				}
+				return m, nil
+
+			case "enter", "l":
+				if m.archiveCursor < len(m.archivedNotes) {
+					note := m.archivedNotes[m.archiveCursor]
+					newPath, err := m.notesService.RestoreNote(note.FilePath)
+					if err != nil {
+						m.statusMsg = fmt.Sprintf("❌ %v", err)
+						return m, nil
+					}
+					rel, _ := filepath.Rel(m.notesService.GetNotesDir(), newPath)
+					m.statusMsg = "✓ Restored " + rel
+					m.restorePosition(m.position())
+					m.openArchive()
 				}
 				return m, nil
 			}
@@ -601,12 +934,18 @@ func (m NotesBrowserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-		// Normal navigation mode
-		switch msg.String() {
-		case "q", "ctrl+c":
+		// Normal navigation mode (navigation keys are configurable, see KeyMap)
+		switch m.opts.Keys.Resolve(msg.String()) {
+		case ActionQuit, "ctrl+c":
 			return m, tea.Quit
 
-		case "esc", "h":
+		case ActionUp:
+			// Leave the flat list for the category it was opened from
+			if m.flatView {
+				m.flatView = false
+				m.loadNotes()
+				return m, nil
+			}
 			// If we're in a subdirectory, go up one level
 			if m.currentPath != "" {
 				m.currentPath = filepath.Dir(m.currentPath)
@@ -621,20 +960,20 @@ func (m NotesBrowserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return BackToDashboardMsg{}
 			}
 
-		case "up", "k":
+		case ActionPrev:
 			if m.cursor > 0 {
 				m.cursor--
 			}
 			return m, nil
 
-		case "down", "j":
+		case ActionNext:
 			totalItems := len(m.directories) + len(m.filteredNotes)
 			if m.cursor < totalItems-1 {
 				m.cursor++
 			}
 			return m, nil
 
-		case "enter", "l":
+		case ActionOpen:
 			// Check if selecting a directory
 			if m.cursor < len(m.directories) {
 				// Navigate into directory
@@ -652,17 +991,82 @@ func (m NotesBrowserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if noteIdx >= 0 && noteIdx < len(m.filteredNotes) {
 				note := m.filteredNotes[noteIdx]
 				return m, func() tea.Msg {
-					return OpenNoteMsg{filePath: note.FilePath}
+					return OpenNoteMsg{filePath: note.FilePath, sort: m.noteSort}
+				}
+			}
+			return m, nil
+
+		case " ":
+			// Read the selected note in the viewer, without opening the editor
+			noteIdx := m.cursor - len(m.directories)
+			if noteIdx >= 0 && noteIdx < len(m.filteredNotes) {
+				note := m.filteredNotes[noteIdx]
+				return m, func() tea.Msg {
+					return ViewNoteMsg{note: note, sort: m.noteSort}
 				}
 			}
 			return m, nil
 
+		case "pgup", "pgdown":
+			// Jump a page through long lists (e.g. the flat list or vault-wide search/tag results)
+			delta := 1
+			if msg.String() == "pgup" {
+				delta = -1
+			}
+			totalItems := len(m.directories) + len(m.filteredNotes)
+			m.cursor = pageMove(m.cursor, totalItems, m.pageSize(), delta)
+			return m, nil
+
+		case "f":
+			// Toggle the flat list of every note in the vault
+			m.flatView = !m.flatView
+			m.filterMode = FilterNone
+			m.searchInput.SetValue("")
+			m.loadNotes()
+			return m, nil
+
 		case "n":
 			// Show new item menu (note or category)
 			m.showingNewMenu = true
 			m.newMenuCursor = 0
 			return m, nil
 
+		case "s":
+			// Toggle directory sort between name and most recently modified
+			if m.dirSort == services.DirSortName {
+				m.dirSort = services.DirSortModified
+			} else {
+				m.dirSort = services.DirSortName
+			}
+			m.loadNotes()
+			return m, nil
+
+		case "w":
+			// Toggle note sort between most recently modified and frontmatter weight
+			if m.noteSort == services.NoteSortModified {
+				m.noteSort = services.NoteSortWeight
+			} else {
+				m.noteSort = services.NoteSortModified
+			}
+			m.loadNotes()
+			return m, nil
+
+		case "v":
+			// New note from the clipboard text
+			text, err := m.readClipboard()
+			if err != nil {
+				m.statusMsg = fmt.Sprintf("❌ %v", err)
+				return m, nil
+			}
+			if strings.TrimSpace(text) == "" {
+				m.statusMsg = "❌ clipboard does not contain any text"
+				return m, nil
+			}
+			m.pastingNote = true
+			m.pasteContent = text
+			m.pasteInput.Focus()
+			return m, textinput.Blink
+
 		case "m":
 			// Move note to different category
 			noteIdx := m.cursor - len(m.directories)
@@ -676,6 +1080,67 @@ func (m NotesBrowserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 
+		case "R":
+			// Rename the selected note
+			noteIdx := m.cursor - len(m.directories)
+			if noteIdx >= 0 && noteIdx < len(m.filteredNotes) {
+				m.renamingNote = true
+				m.renameTargetIdx = noteIdx
+				m.renameInput.SetValue(strings.TrimSuffix(filepath.Base(m.filteredNotes[noteIdx].FilePath), ".md"))
+				m.renameInput.CursorEnd()
+				m.renameInput.Focus()
+				return m, textinput.Blink
+			}
+			return m, nil
+
+		case "D":
+			// Duplicate the selected note next to itself
+			noteIdx := m.cursor - len(m.directories)
+			if noteIdx >= 0 && noteIdx < len(m.filteredNotes) {
+				newPath, err := m.notesService.DuplicateNote(m.filteredNotes[noteIdx].FilePath)
+				if err != nil {
+					m.statusMsg = fmt.Sprintf("❌ %v", err)
+					return m, nil
+				}
+				m.statusMsg = "✓ Duplicated as " + filepath.Base(newPath)
+				m.loadNotes()
+			}
+			return m, nil
+
+		case "P":
+			// Pin the selected note to the top of its directory, or unpin it
+			noteIdx := m.cursor - len(m.directories)
+			if noteIdx >= 0 && noteIdx < len(m.filteredNotes) {
+				note := m.filteredNotes[noteIdx]
+				if err := m.notesService.SetPinned(note.FilePath, !note.Pinned); err != nil {
+					m.statusMsg = fmt.Sprintf("❌ %v", err)
+					return m, nil
+				}
+				if note.Pinned {
+					m.statusMsg = "✓ Unpinned " + note.DisplayName()
+				} else {
+					m.statusMsg = "✓ Pinned " + note.DisplayName()
+				}
+				// Reload with the cursor following the note to its new place
+				m.restorePosition(m.position())
+			}
+			return m, nil
+
+		case "a":
+			// Archive the selected note, hiding it without deleting it
+			noteIdx := m.cursor - len(m.directories)
+			if noteIdx >= 0 && noteIdx < len(m.filteredNotes) {
+				note := m.filteredNotes[noteIdx]
+				if err := m.notesService.ArchiveNote(note.FilePath); err != nil {
+					m.statusMsg = fmt.Sprintf("❌ %v", err)
+					return m, nil
+				}
+				m.statusMsg = "✓ Archived " + note.DisplayName() + " (n → Archived notes to restore it)"
+				m.loadNotes()
+				m.cursor = min(len(m.directories)+noteIdx, max(len(m.directories)+len(m.filteredNotes)-1, 0))
+			}
+			return m, nil
+
 		case "/":
 			// Start search
 			m.filterMode = FilterSearch
@@ -688,6 +1153,30 @@ func (m NotesBrowserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.tagCursor = 0
 			return m, nil
 
+		case "T":
+			// Open the tag cloud overview
+			return m, func() tea.Msg {
+				return OpenTagCloudMsg{}
+			}
+
+		case "Y":
+			// Copy the selected note's tags to the clipboard
+			noteIdx := m.cursor - len(m.directories)
+			if noteIdx < 0 || noteIdx >= len(m.filteredNotes) {
+				return m, nil
+			}
+			note := m.filteredNotes[noteIdx]
+			if len(note.Tags) == 0 {
+				m.statusMsg = "❌ " + note.Name + " has no tags"
+				return m, nil
+			}
+			if err := utils.CopyToClipboard(strings.Join(note.Tags, ", ")); err != nil {
+				m.statusMsg = fmt.Sprintf("❌ %v", err)
+				return m, nil
+			}
+			m.statusMsg = "✓ Copied tags: " + strings.Join(note.Tags, ", ")
+			return m, nil
+
 		case "c":
 			// Clear filter
 			m.filterMode = FilterNone
@@ -702,16 +1191,90 @@ func (m NotesBrowserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 
 		case "d":
-			// Delete note (only if a note is selected, not a directory)
+			// Delete a category directory (with item count in the confirmation)
+			if m.cursor < len(m.directories) {
+				dirName := m.directories[m.cursor]
+				dirPath := filepath.Join(m.currentPath, dirName)
+				count, err := m.notesService.CountCategoryItems(dirPath)
+				if err != nil {
+					m.statusMsg = fmt.Sprintf("❌ %v", err)
+					return m, nil
+				}
+				m.confirmDelete = true
+				m.deleteIsDir = true
+				m.deleteDirPath = dirPath
+				m.deleteDirItems = count
+				m.deleteTarget = dirName + "/"
+				m.deleteTargetIdx = -1
+				return m, nil
+			}
+
+			// Delete note
 			noteIdx := m.cursor - len(m.directories)
 			if noteIdx >= 0 && noteIdx < len(m.filteredNotes) {
 				note := m.filteredNotes[noteIdx]
+				if m.opts.QuickDelete {
+					// Delete straight away, with an undo; notes that can't be kept for
+					// undoing (e.g. binary files) are still confirmed
+					pos := m.position()
+					if id, err := m.trash.add(m.notesService, note.FilePath); err == nil {
+						m.restorePosition(pos)
+						return m, m.offerUndo(note.FilePath, id)
+					}
+				}
 				m.confirmDelete = true
+				m.deleteIsDir = false
 				m.deleteTarget = note.Name
 				m.deleteTargetIdx = noteIdx
 			}
 			return m, nil
 
+		case "u":
+			// Undo the last quick delete while its toast is up
+			if m.undoPath == "" {
+				return m, nil
+			}
+			path := m.undoPath
+			m.undoPath = ""
+			if err := m.trash.restore(m.notesService, path); err != nil {
+				m.statusMsg = fmt.Sprintf("❌ %v", err)
+				return m, nil
+			}
+			pos := m.position()
+			pos.selected = path
+			m.restorePosition(pos)
+			m.statusMsg = fmt.Sprintf("✓ Restored %s", filepath.Base(path))
+			return m, nil
+
+		case "e":
+			// Copy the selected note to the clipboard as rendered HTML
+			noteIdx := m.cursor - len(m.directories)
+			if noteIdx < 0 || noteIdx >= len(m.filteredNotes) {
+				return m, nil
+			}
+			note := m.filteredNotes[noteIdx]
+			content, err := m.notesService.ReadNote(note.FilePath)
+			if err != nil {
+				m.statusMsg = fmt.Sprintf("❌ %v", err)
+				return m, nil
+			}
+			content = m.notesService.ResolveWikiLinks(content, note.FilePath)
+			htmlContent, err := m.previewService.RenderHTML(note.FilePath, content)
+			if err != nil {
+				m.statusMsg = fmt.Sprintf("❌ %v", err)
+				return m, nil
+			}
+			asHTML, err := m.copyHTML(htmlContent)
+			switch {
+			case err != nil:
+				m.statusMsg = fmt.Sprintf("❌ %v", err)
+			case asHTML:
+				m.statusMsg = "✓ Copied " + note.Name + " as HTML"
+			default:
+				m.statusMsg = "✓ Copied " + note.Name + " HTML as text (no HTML clipboard available)"
+			}
+			return m, nil
+
 		case "p":
 			// Preview note in browser (only if a note is selected, not a directory)
 			noteIdx := m.cursor - len(m.directories)
@@ -720,6 +1283,7 @@ func (m NotesBrowserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Read the note content
 				content, err := m.notesService.ReadNote(note.FilePath)
 				if err == nil {
+					content = m.notesService.ResolveWikiLinks(content, note.FilePath)
 					go func() {
 						_ = m.previewService.PreviewMarkdown(note.FilePath, content)
 					}()
@@ -750,6 +1314,9 @@ func (m NotesBrowserModel) View() string {
 	// Show confirmation dialog if delete is pending
 	if m.confirmDelete {
 		dialogText := confirmTextStyle.Render(fmt.Sprintf("Delete '%s'?", m.deleteTarget)) + "\n\n"
+		if m.deleteIsDir && m.deleteDirItems > 0 {
+			dialogText += fmt.Sprintf("⚠ This category is not empty: %d item(s) will be deleted with it.\n\n", m.deleteDirItems)
+		}
 		dialogText += "  y: yes   n: no   esc: cancel"
 		dialog := confirmDialogStyle.Render(dialogText)
 		s += dialog + "\n\n"
@@ -765,6 +1332,25 @@ func (m NotesBrowserModel) View() string {
 		s += dialog + "\n\n"
 	}
 
+	// Show name input for a note created from the clipboard
+	if m.pastingNote {
+		dialogText := confirmTextStyle.Render("New Note from Clipboard") + "\n\n"
+		dialogText += fmt.Sprintf("Clipboard: %d line(s), %d character(s)\n", strings.Count(strings.TrimRight(m.pasteContent, "\n"), "\n")+1, len([]rune(m.pasteContent)))
+		dialogText += m.pasteInput.View() + "\n\n"
+		dialogText += "  enter: create   esc: cancel"
+		dialog := confirmDialogStyle.Render(dialogText)
+		s += dialog + "\n\n"
+	}
+
+	// Show the new name input for a note being renamed
+	if m.renamingNote {
+		dialogText := confirmTextStyle.Render("Rename Note") + "\n\n"
+		dialogText += m.renameInput.View() + "\n\n"
+		dialogText += "  enter: rename   esc: cancel"
+		dialog := confirmDialogStyle.Render(dialogText)
+		s += dialog + "\n\n"
+	}
+
 	// Show move note directory selection or new dir input
 	if m.movingNote {
 		noteName := ""
@@ -826,7 +1412,7 @@ func (m NotesBrowserModel) View() string {
 	// Show new item menu
 	if m.showingNewMenu {
 		menuText := confirmTextStyle.Render("Create New...") + "\n\n"
-		options := []string{"Note", "Category"}
+		options := []string{"Note", "Category", "Archived notes"}
 		for i, option := range options {
 			if i == m.newMenuCursor {
 				menuText += noteSelectedStyle.Render("▶ "+option) + "\n"
@@ -840,14 +1426,25 @@ func (m NotesBrowserModel) View() string {
 	}
 
 	// Show tag selection overlay
-	if m.showingTags {
+	if m.renamingTag {
+		dialogText := confirmTextStyle.Render(fmt.Sprintf("Rename Tag #%s", m.allTags[m.tagCursor])) + "\n\n"
+		dialogText += m.tagRenameInput.View() + "\n\n"
+		dialogText += "  Renaming onto an existing tag merges the two.\n"
+		dialogText += "  enter: rename   esc: cancel"
+		dialog := confirmDialogStyle.Render(dialogText)
+		s += dialog + "\n\n"
+	} else if m.showingTags {
 		s += tagListStyle.Render(m.renderTagList()) + "\n\n"
 	} else if m.showingTemplates {
 		// Show template selection overlay
 		s += tagListStyle.Render(m.renderTemplateList()) + "\n\n"
+	} else if m.showingArchive {
+		s += tagListStyle.Render(m.renderArchiveList()) + "\n\n"
 	} else {
-		// Show current path breadcrumb
-		if m.currentPath != "" {
+		// Show current path breadcrumb, or that the flat list is showing
+		if m.flatView {
+			s += noteTagStyle.Render(fmt.Sprintf("📚 All notes (%d)", len(m.notes))) + "\n\n"
+		} else if m.currentPath != "" {
 			s += noteTagStyle.Render("📁 "+m.currentPath) + "\n\n"
 		}
 
@@ -857,8 +1454,15 @@ func (m NotesBrowserModel) View() string {
 			s += "  No notes or folders found.\n\n"
 			s += noteItemStyle.Render("Press 'n' to create a new note or category") + "\n"
 		} else {
+			// Only render the page containing the cursor
+			pageSize := m.pageSize()
+			start, end := pageWindow(m.cursor, totalItems, pageSize)
+
 			// Render directories first
 			for i, dir := range m.directories {
+				if i < start || i >= end {
+					continue
+				}
 				var line string
 				if i == m.cursor {
 					line = "▶ 📁 " + dir + "/"
@@ -872,39 +1476,70 @@ func (m NotesBrowserModel) View() string {
 			// Then render notes
 			for i, note := range m.filteredNotes {
 				itemIdx := len(m.directories) + i
+				if itemIdx < start || itemIdx >= end {
+					continue
+				}
+				name := note.DisplayName()
+				if dir := filepath.Dir(note.Name); m.flatView && dir != "." {
+					// The flat list spans categories, so show which one each note is in
+					name = filepath.ToSlash(dir) + "/" + name
+				}
+				if note.Pinned {
+					name = "★ " + name
+				}
 				var line string
 				if itemIdx == m.cursor {
-					line = "▶ " + note.Name
+					line = "▶ " + name
 					if len(note.Tags) > 0 {
 						line += " " + noteTagStyle.Render("["+strings.Join(note.Tags, ", ")+"]")
 					}
 					s += noteSelectedStyle.Render(line) + "\n"
 				} else {
-					line = "  " + note.Name
+					line = "  " + name
 					if len(note.Tags) > 0 {
 						line += " " + noteTagStyle.Render("["+strings.Join(note.Tags, ", ")+"]")
 					}
 					s += noteItemStyle.Render(line) + "\n"
 				}
 			}
+
+			if pages := pageCount(totalItems, pageSize); pages > 1 {
+				s += "\n" + statusStyle.Render(fmt.Sprintf("  page %d/%d (%d items) • pgup/pgdn: page", start/pageSize+1, pages, totalItems)) + "\n"
+			}
 		}
 	}
 
 	s += "\n"
 
+	// Undo toast for a quick delete
+	if m.undoPath != "" {
+		s += successStyle.Render(fmt.Sprintf("🗑 Deleted %s • Undo (u)", filepath.Base(m.undoPath))) + "\n"
+	}
+
+	// Status message (non-fatal errors from create/move/delete, or a confirmation)
+	if strings.HasPrefix(m.statusMsg, "✓") {
+		s += successStyle.Render(m.statusMsg) + "\n"
+	} else if m.statusMsg != "" {
+		s += errorStyle.Render(m.statusMsg) + "\n"
+	}
+
 	// Help text
-	if m.showingTags {
-		s += helpStyle.Render("↑/k: up • ↓/j: down • enter/l: select tag • esc: back")
+	if m.renamingTag {
+		s += helpStyle.Render("enter: confirm • esc: cancel")
+	} else if m.showingTags {
+		s += helpStyle.Render("↑/k: up • ↓/j: down • enter/l: select tag • R: rename tag • esc: back")
 	} else if m.showingTemplates {
 		s += helpStyle.Render("↑/k: up • ↓/j: down • enter/l: select template • esc: back")
+	} else if m.showingArchive {
+		s += helpStyle.Render("↑/k: up • ↓/j: down • enter/l: restore • esc: back")
 	} else if m.showingNewMenu {
 		s += helpStyle.Render("↑/k: up • ↓/j: down • enter/l: select • esc: back")
-	} else if m.creatingCategory || m.movingNote {
+	} else if m.creatingCategory || m.movingNote || m.pastingNote || m.renamingNote {
 		s += helpStyle.Render("enter: confirm • esc: cancel")
 	} else if m.filterMode == FilterSearch && m.searchInput.Focused() {
 		s += helpStyle.Render("enter: search • esc: cancel")
 	} else {
-		s += helpStyle.Render("↑/k: up • ↓/j: down • enter/l: open • p: preview • n: new • m: move • /: search • t: tags • c: clear filter • r: refresh • d: delete • esc/h: back • q: quit")
+		s += helpStyle.Render(notesBrowserHelp(m.opts.Keys, m.dirSort.String(), m.noteSort.String()))
 	}
 
 	// Fill the screen
@@ -956,8 +1591,55 @@ func (m NotesBrowserModel) renderTemplateList() string {
 	return s
 }
 
+// openArchive lists the archived notes, keeping the cursor in range
+func (m *NotesBrowserModel) openArchive() {
+	notes, err := m.notesService.ListArchivedNotes()
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("❌ %v", err)
+		return
+	}
+	m.archivedNotes = notes
+	m.archiveCursor = min(m.archiveCursor, max(len(notes)-1, 0))
+	m.showingArchive = true
+}
+
+func (m NotesBrowserModel) renderArchiveList() string {
+	var s string
+	s += "🗄 Archived Notes\n\n"
+
+	if len(m.archivedNotes) == 0 {
+		s += "  No archived notes\n"
+	} else {
+		for i, note := range m.archivedNotes {
+			line := note.Name + " " + noteTagStyle.Render(note.ModTime.Format("2006-01-02"))
+			if i == m.archiveCursor {
+				s += noteSelectedStyle.Render("▶ "+line) + "\n"
+			} else {
+				s += "  " + line + "\n"
+			}
+		}
+	}
+
+	return s
+}
+
+// renamedStatus is the status line after a note is moved or renamed, pointing out
+// when the name it should have had was taken and it got a numeric suffix instead
+func renamedStatus(verb, wantName, newPath string) string {
+	if !strings.HasSuffix(wantName, ".md") {
+		wantName += ".md"
+	}
+	if filepath.Base(newPath) == wantName {
+		return ""
+	}
+	return fmt.Sprintf("✓ %s as %s, since %s was taken", verb, filepath.Base(newPath), wantName)
+}
+
 type OpenNoteMsg struct {
-	filePath string
+	filePath   string
+	query      string                 // Search that found the note, found again in the editor
+	searchOpts services.SearchOptions // How query matched
+	sort       services.NoteSortMode  // Order of the browser it was opened from, kept by ]/[
 }
 
 type CreateNoteMsg struct{}
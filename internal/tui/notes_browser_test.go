@@ -0,0 +1,318 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/redjax/notetkr/internal/services"
+)
+
+func TestNewNoteFromClipboard(t *testing.T) {
+	notesDir := t.TempDir()
+	clipboardText := "Copied from somewhere\n\n- point one\n- point two\n"
+
+	m := NewNotesBrowser(services.NewNotesService(notesDir), DefaultOptions(), 80, 24)
+	m.readClipboard = func() (string, error) { return clipboardText, nil }
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	m = updated.(NotesBrowserModel)
+	if !m.pastingNote {
+		t.Fatal("'v' should prompt for a note name")
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("captured")})
+	m = updated.(NotesBrowserModel)
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("creating the note should open it in the editor")
+	}
+	if _, ok := cmd().(OpenNoteMsg); !ok {
+		t.Errorf("expected OpenNoteMsg after creating the note")
+	}
+
+	content, err := os.ReadFile(filepath.Join(notesDir, "captured.md"))
+	if err != nil {
+		t.Fatalf("note was not created: %v", err)
+	}
+
+	want := "---\ntags:\nkeywords:\n---\n\n" + clipboardText
+	if string(content) != want {
+		t.Errorf("note content = %q, want %q", content, want)
+	}
+}
+
+func TestCopyNoteAsHTML(t *testing.T) {
+	notesDir := t.TempDir()
+	content := "---\ntags: work\n---\n# Status Update\n\n- **shipped** the release\n"
+	if err := os.WriteFile(filepath.Join(notesDir, "update.md"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewNotesBrowser(services.NewNotesService(notesDir), DefaultOptions(), 80, 24)
+	var copied string
+	m.copyHTML = func(html string) (bool, error) {
+		copied = html
+		return false, nil
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	m = updated.(NotesBrowserModel)
+
+	for _, want := range []string{`<h1 id="status-update">Status Update</h1>`, "<strong>shipped</strong>"} {
+		if !strings.Contains(copied, want) {
+			t.Errorf("clipboard HTML missing %q", want)
+		}
+	}
+	if strings.Contains(copied, "tags: work") {
+		t.Error("frontmatter should not be rendered into the clipboard HTML")
+	}
+	if !strings.Contains(m.statusMsg, "HTML as text") {
+		t.Errorf("status should mention the plain-text fallback, got %q", m.statusMsg)
+	}
+}
+
+func TestNotesBrowserAutoRefresh(t *testing.T) {
+	notesDir := t.TempDir()
+	writeNote := func(name string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(notesDir, name), []byte("# "+name+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeNote("a.md")
+	writeNote("b.md")
+
+	opts := DefaultOptions()
+	opts.NotesRefreshOnFocus = true
+	opts.NotesRefreshInterval = time.Second
+	m := NewNotesBrowser(services.NewNotesService(notesDir), opts, 80, 24)
+	m.cursor = 1
+	selected := m.filteredNotes[1].FilePath
+	if m.Init() == nil {
+		t.Fatal("Init should start the refresh timer")
+	}
+
+	// A note added outside the app shows up when the window regains focus
+	writeNote("c.md")
+	m.lastRefresh = time.Time{}
+	updated, _ := m.Update(tea.FocusMsg{})
+	m = updated.(NotesBrowserModel)
+	if len(m.filteredNotes) != 3 {
+		t.Fatalf("focus should reload the notes, got %d notes", len(m.filteredNotes))
+	}
+	if got := m.filteredNotes[m.cursor].FilePath; got != selected {
+		t.Errorf("refresh moved the selection from %s to %s", selected, got)
+	}
+
+	// Triggers right after a refresh are debounced
+	writeNote("d.md")
+	updated, _ = m.Update(tea.FocusMsg{})
+	m = updated.(NotesBrowserModel)
+	if len(m.filteredNotes) != 3 {
+		t.Errorf("focus right after a refresh should be ignored, got %d notes", len(m.filteredNotes))
+	}
+
+	// The timer reloads too, and keeps itself going
+	m.lastRefresh = time.Time{}
+	updated, cmd := m.Update(notesRefreshTickMsg{id: m.refreshID})
+	m = updated.(NotesBrowserModel)
+	if len(m.filteredNotes) != 4 {
+		t.Errorf("tick should reload the notes, got %d notes", len(m.filteredNotes))
+	}
+	if cmd == nil {
+		t.Error("tick should schedule the next refresh")
+	}
+
+	// A timer left over from an earlier browser stops
+	m.lastRefresh = time.Time{}
+	_, cmd = m.Update(notesRefreshTickMsg{id: m.refreshID - 1})
+	if cmd != nil {
+		t.Error("a stale timer should not be rescheduled")
+	}
+}
+
+func TestNotesBrowserPinsNotes(t *testing.T) {
+	notesDir := t.TempDir()
+	now := time.Now()
+	for i, name := range []string{"newest.md", "middle.md", "oldest.md"} {
+		path := filepath.Join(notesDir, name)
+		if err := os.WriteFile(path, []byte("# "+name+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		modTime := now.Add(-time.Duration(i) * time.Hour)
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(notesDir, "work"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	names := func(m NotesBrowserModel) []string {
+		var names []string
+		for _, note := range m.filteredNotes {
+			names = append(names, filepath.Base(note.FilePath))
+		}
+		return names
+	}
+	pin := func(m NotesBrowserModel, name string) NotesBrowserModel {
+		t.Helper()
+		for i, note := range m.filteredNotes {
+			if filepath.Base(note.FilePath) == name {
+				m.cursor = len(m.directories) + i
+			}
+		}
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("P")})
+		m = updated.(NotesBrowserModel)
+		if got := filepath.Base(m.filteredNotes[m.cursor-len(m.directories)].FilePath); got != name {
+			t.Errorf("after toggling the pin on %s the cursor is on %s", name, got)
+		}
+		return m
+	}
+
+	m := NewNotesBrowser(services.NewNotesService(notesDir), DefaultOptions(), 80, 24)
+
+	// Pinned notes come first, newest first among themselves, with the rest after
+	m = pin(m, "oldest.md")
+	m = pin(m, "middle.md")
+	if got, want := names(m), []string{"middle.md", "oldest.md", "newest.md"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("notes after pinning = %v, want %v", got, want)
+	}
+	if !strings.Contains(m.View(), "★ middle.md") {
+		t.Error("pinned notes should be shown with a star")
+	}
+	if m.directories[0] != "work" {
+		t.Errorf("directories should stay above pinned notes, got %v", m.directories)
+	}
+
+	// Unpinning puts the note back in date order
+	m = pin(m, "middle.md")
+	if got, want := names(m), []string{"oldest.md", "newest.md", "middle.md"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("notes after unpinning = %v, want %v", got, want)
+	}
+}
+
+func TestNotesBrowserRenamesTag(t *testing.T) {
+	notesDir := t.TempDir()
+	for name, content := range map[string]string{
+		"a.md": "---\ntags: meetng, work\n---\n",
+		"b.md": "Notes from the #meetng\n",
+	} {
+		if err := os.WriteFile(filepath.Join(notesDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	press := func(m NotesBrowserModel, key tea.KeyMsg) NotesBrowserModel {
+		updated, _ := m.Update(key)
+		return updated.(NotesBrowserModel)
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	m := NewNotesBrowser(services.NewNotesService(notesDir), DefaultOptions(), 80, 24)
+	m = press(m, runes("t"))
+	m = press(m, runes("R"))
+	if !m.renamingTag || m.tagRenameInput.Value() != "meetng" {
+		t.Fatalf("R should prompt to rename #meetng, got renamingTag=%v value=%q", m.renamingTag, m.tagRenameInput.Value())
+	}
+	m.tagRenameInput.SetValue("meeting")
+	m = press(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+	if m.renamingTag || !m.showingTags {
+		t.Error("the tag list should stay open after renaming")
+	}
+	if want := []string{"meeting", "work"}; !reflect.DeepEqual(m.allTags, want) {
+		t.Errorf("tags after renaming = %v, want %v", m.allTags, want)
+	}
+	if m.allTags[m.tagCursor] != "meeting" {
+		t.Errorf("cursor on #%s, want the renamed #meeting", m.allTags[m.tagCursor])
+	}
+	if want := "✓ Renamed #meetng to #meeting in 2 note(s)"; m.statusMsg != want {
+		t.Errorf("status = %q, want %q", m.statusMsg, want)
+	}
+}
+
+func TestNotesBrowserQuickDeleteUndo(t *testing.T) {
+	notesDir := t.TempDir()
+	modTime := time.Now().Add(-48 * time.Hour).Truncate(time.Second)
+	for _, name := range []string{"keep.md", "oops.md", "old.md"} {
+		path := filepath.Join(notesDir, name)
+		if err := os.WriteFile(path, []byte("# "+name+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	press := func(m NotesBrowserModel, key string) (NotesBrowserModel, tea.Cmd) {
+		updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		return updated.(NotesBrowserModel), cmd
+	}
+	selectNote := func(m NotesBrowserModel, name string) NotesBrowserModel {
+		for i, note := range m.filteredNotes {
+			if note.Name == name {
+				m.cursor = len(m.directories) + i
+			}
+		}
+		return m
+	}
+
+	undoFile := services.UndoHistoryPath(filepath.Join(notesDir, "oops.md"))
+	if err := os.WriteFile(undoFile, []byte(`{"saved":"# oops.md\n","entries":[]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// d deletes without asking and offers an undo
+	opts := DefaultOptions()
+	opts.QuickDelete = true
+	m := selectNote(NewNotesBrowser(services.NewNotesService(notesDir), opts, 80, 24), "oops.md")
+	m, cmd := press(m, "d")
+	oops := filepath.Join(notesDir, "oops.md")
+	if m.confirmDelete || cmd == nil {
+		t.Fatal("quick delete should delete straight away and start the undo timer")
+	}
+	if _, err := os.Stat(oops); !os.IsNotExist(err) {
+		t.Fatal("oops.md should be deleted")
+	}
+	if !strings.Contains(m.View(), "Deleted oops.md • Undo (u)") {
+		t.Error("the undo toast should be shown")
+	}
+
+	// u puts it back as it was, selected
+	m, _ = press(m, "u")
+	info, err := os.Stat(oops)
+	if err != nil {
+		t.Fatalf("u should restore oops.md: %v", err)
+	}
+	if data, _ := os.ReadFile(oops); string(data) != "# oops.md\n" || !info.ModTime().Equal(modTime) {
+		t.Errorf("restored note = %q modified %v, want the original content and time", data, info.ModTime())
+	}
+	if data, err := os.ReadFile(undoFile); err != nil || !strings.Contains(string(data), `"saved"`) {
+		t.Errorf("undo should restore the note's undo history too: %q, %v", data, err)
+	}
+	if got := m.filteredNotes[m.cursor-len(m.directories)].Name; got != "oops.md" {
+		t.Errorf("cursor on %s after undo, want oops.md", got)
+	}
+	if strings.Contains(m.View(), "Undo (u)") {
+		t.Error("the toast should be gone after undoing")
+	}
+
+	// Once the toast times out the delete is final
+	m = selectNote(m, "old.md")
+	m, _ = press(m, "d")
+	updated, _ := m.Update(undoExpiredMsg{id: m.undoID})
+	m = updated.(NotesBrowserModel)
+	if m.undoPath != "" || len(m.trash.notes) != 0 {
+		t.Error("the expired toast should empty the trash")
+	}
+	m, _ = press(m, "u")
+	if _, err := os.Stat(filepath.Join(notesDir, "old.md")); !os.IsNotExist(err) {
+		t.Error("u after the toast expired should not restore the note")
+	}
+}
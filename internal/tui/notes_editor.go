@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 	"regexp"
@@ -8,6 +9,7 @@ import (
 	"time"
 
 	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/redjax/notetkr/internal/services"
@@ -22,6 +24,7 @@ type undoState struct {
 
 type NotesEditorModel struct {
 	notesService     *services.NotesService
+	opts             Options
 	filePath         string
 	templatePath     string
 	targetPath       string // Target directory path for new notes
@@ -39,9 +42,34 @@ type NotesEditorModel struct {
 	redoStack        []undoState
 	lastContent      string
 	clipboardHandler *utils.ClipboardImageHandler
+	pastes           pasteQueue // Clipboard image pastes waiting to be linked, the first one saving
 	showQuitConfirm  bool
 	initialContent   string
 	previewService   *services.PreviewService
+	goalCol          int    // Column to restore on vertical moves, -1 when unset
+	quitToShell      bool   // The pending quit confirmation came from ctrl+c
+	foldedHeader     string // Frontmatter hidden from the textarea while folded, "" when unfolded
+	confirmDiscard   bool   // Asking whether to discard the typed new-note name
+	lineEnding       string // Line ending of the loaded file, restored on save
+	finding          bool   // Typing a query to find in the note
+	findInput        textinput.Model
+	findResults      []services.LineMatch   // Lines matching the last find, nil when not showing them
+	findQuery        string                 // Last find, repeated by n/N
+	findOpts         services.SearchOptions // How findQuery matches, e.g. carried over from search
+	findCursor       int
+	replacing        bool // Typing the replacement for the last find
+	replaceInput     textinput.Model
+	focusMode        bool            // Chrome hidden, textarea filling the window
+	backlinks        []services.Note // Notes linking here with [[name]], nil when not showing them
+	backlinkCursor   int
+	wordCount        int              // Words in the content, excluding frontmatter
+	diff             *diffOverlay     // Unsaved changes being reviewed, nil when not showing them
+	visual           *visualSelection // Line-wise visual mode selection, nil outside visual mode
+	scratch          bool             // Opened as a scratchpad, kept in memory until saved as a note
+	savingAs         bool             // Typing the name to save a scratchpad as
+	saveAsInput      textinput.Model
+	quitAfterSave    bool                  // Leave the editor once the pending save (or save-as of the scratchpad) succeeds
+	noteSort         services.NoteSortMode // Order ]/[ step through the note's directory in
 }
 
 var (
@@ -66,38 +94,49 @@ var (
 )
 
 // NewNotesEditor creates a new notes editor for an existing note
-func NewNotesEditor(notesService *services.NotesService, filePath string) NotesEditorModel {
+func NewNotesEditor(notesService *services.NotesService, opts Options, filePath string) NotesEditorModel {
 	ta := textarea.New()
-	ta.Placeholder = "Press 'i' to enter insert mode and start writing..."
+	ta.Placeholder = opts.EditorKeymap.placeholder()
 	ta.Focus()
 	ta.CharLimit = 0
 	ta.ShowLineNumbers = false
 	ta.SetWidth(80)
 	ta.SetHeight(20)
 
-	clipboardHandler := utils.NewClipboardImageHandler()
+	clipboardHandler := utils.NewClipboardImageHandler(opts.Images)
 	// Try to initialize clipboard, but don't fail if it doesn't work
 	_ = clipboardHandler.Initialize()
 
 	m := NotesEditorModel{
 		notesService:     notesService,
+		opts:             opts,
 		filePath:         filePath,
 		textarea:         ta,
-		mode:             ModeNormal,
+		mode:             opts.EditorKeymap.initialMode(),
 		saved:            false,
 		isNewNote:        false,
 		undoStack:        []undoState{},
+		goalCol:          -1,
 		redoStack:        []undoState{},
 		lastContent:      "",
 		clipboardHandler: clipboardHandler,
-		previewService:   services.NewPreviewService(),
+		previewService:   services.NewPreviewService(opts.Preview),
 	}
 
 	return m
 }
 
+// NewNotesEditorWithQuery creates a notes editor that opens at the first line matching
+// query, so n/N step through the occurrences a search found
+func NewNotesEditorWithQuery(notesService *services.NotesService, opts Options, filePath, query string, searchOpts services.SearchOptions) NotesEditorModel {
+	m := NewNotesEditor(notesService, opts, filePath)
+	m.findQuery = query
+	m.findOpts = searchOpts
+	return m
+}
+
 // NewNotesEditorForNew creates a new notes editor for a new note
-func NewNotesEditorForNew(notesService *services.NotesService) NotesEditorModel {
+func NewNotesEditorForNew(notesService *services.NotesService, opts Options) NotesEditorModel {
 	ta := textarea.New()
 	ta.Placeholder = "Enter note name..."
 	ta.Focus()
@@ -106,27 +145,29 @@ func NewNotesEditorForNew(notesService *services.NotesService) NotesEditorModel
 	ta.SetWidth(80)
 	ta.SetHeight(1)
 
-	clipboardHandler := utils.NewClipboardImageHandler()
+	clipboardHandler := utils.NewClipboardImageHandler(opts.Images)
 	_ = clipboardHandler.Initialize()
 
 	m := NotesEditorModel{
 		notesService:     notesService,
+		opts:             opts,
 		textarea:         ta,
 		mode:             ModeInsert, // Start in insert mode for name
 		saved:            false,
 		isNewNote:        true,
 		undoStack:        []undoState{},
+		goalCol:          -1,
 		redoStack:        []undoState{},
 		lastContent:      "",
 		clipboardHandler: clipboardHandler,
-		previewService:   services.NewPreviewService(),
+		previewService:   services.NewPreviewService(opts.Preview),
 	}
 
 	return m
 }
 
 // NewNotesEditorForNewWithTemplate creates a new notes editor for a new note from a template
-func NewNotesEditorForNewWithTemplate(notesService *services.NotesService, templatePath string, targetPath string) NotesEditorModel {
+func NewNotesEditorForNewWithTemplate(notesService *services.NotesService, opts Options, templatePath string, targetPath string) NotesEditorModel {
 	ta := textarea.New()
 	ta.Placeholder = "Enter note name..."
 	ta.Focus()
@@ -135,11 +176,12 @@ func NewNotesEditorForNewWithTemplate(notesService *services.NotesService, templ
 	ta.SetWidth(80)
 	ta.SetHeight(1)
 
-	clipboardHandler := utils.NewClipboardImageHandler()
+	clipboardHandler := utils.NewClipboardImageHandler(opts.Images)
 	_ = clipboardHandler.Initialize()
 
 	m := NotesEditorModel{
 		notesService:     notesService,
+		opts:             opts,
 		templatePath:     templatePath,
 		targetPath:       targetPath,
 		textarea:         ta,
@@ -147,17 +189,18 @@ func NewNotesEditorForNewWithTemplate(notesService *services.NotesService, templ
 		saved:            false,
 		isNewNote:        true,
 		undoStack:        []undoState{},
+		goalCol:          -1,
 		redoStack:        []undoState{},
 		lastContent:      "",
 		clipboardHandler: clipboardHandler,
-		previewService:   services.NewPreviewService(),
+		previewService:   services.NewPreviewService(opts.Preview),
 	}
 
 	return m
 }
 
 func (m NotesEditorModel) Init() tea.Cmd {
-	if m.isNewNote {
+	if m.isNewNote || m.unsavedScratch() {
 		return textarea.Blink
 	}
 	return tea.Batch(
@@ -172,17 +215,27 @@ func (m NotesEditorModel) loadNote() tea.Msg {
 		return NotesEditorErrorMsg{err: err}
 	}
 
-	return NotesEditorLoadedMsg{
+	msg := NotesEditorLoadedMsg{
 		filePath: m.filePath,
 		content:  content,
 	}
+	if m.opts.PersistUndo {
+		// A missing or unreadable history just starts a fresh one
+		msg.undo, _ = m.notesService.LoadUndoHistory(m.filePath, services.NormalizeLineEndings(content))
+	}
+	return msg
 }
 
 func (m NotesEditorModel) saveNote() tea.Msg {
-	content := m.textarea.Value()
+	content := services.RestoreLineEndings(m.content(), m.lineEnding)
 	err := m.notesService.WriteNote(m.filePath, content)
 	if err != nil {
-		return NotesEditorErrorMsg{err: err}
+		return NotesSaveFailedMsg{err: err}
+	}
+
+	if m.opts.PersistUndo {
+		// Losing the undo history isn't worth failing the save over
+		_ = m.notesService.SaveUndoHistory(m.filePath, m.content(), m.undoHistory())
 	}
 
 	return NotesSavedMsg{}
@@ -200,36 +253,61 @@ func (m NotesEditorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.textarea.SetHeight(1)
 		} else {
 			m.textarea.SetWidth(msg.Width - 4)
-			m.textarea.SetHeight(msg.Height - 7)
+			m.resizeTextarea()
 		}
 		return m, nil
 
 	case NotesEditorLoadedMsg:
 		m.filePath = msg.filePath
+		// Edit with "\n" endings, restoring the file's own endings on save
+		m.lineEnding = services.DetectLineEnding(msg.content)
+		msg.content = services.NormalizeLineEndings(msg.content)
 		m.textarea.SetValue(msg.content)
 		// Reset cursor to start of document
 		m.textarea.CursorStart()
-		// Initialize undo stack with the loaded content
+		// Initialize undo stack with the loaded content, and any saved history
 		m.lastContent = msg.content
 		m.initialContent = msg.content
+		for _, entry := range msg.undo {
+			m.undoStack = append(m.undoStack, undoState{content: entry.Content, line: entry.Line, column: entry.Column})
+		}
+		m.updateWordCount()
+		if m.findQuery != "" {
+			m.findFrom(-1, 1)
+		}
 		return m, nil
 
 	case NotesEditorErrorMsg:
 		m.err = msg.err
 		return m, nil
 
+	case NotesSaveFailedMsg:
+		// Stay in the editor so the unsaved text isn't lost
+		m.saved = false
+		m.quitAfterSave = false
+		m.quitToShell = false
+		m.saveMsg = fmt.Sprintf("❌ Save failed: %v", msg.err)
+		return m, nil
+
 	case NotesSavedMsg:
 		m.saved = true
 		m.saveMsg = "✓ Saved"
 
 		// Only clear wasJustCreated if user actually made changes from the template
-		currentContent := strings.TrimSpace(m.textarea.Value())
+		currentContent := strings.TrimSpace(m.content())
 		initialTemplate := strings.TrimSpace(m.initialContent)
 		if currentContent != initialTemplate {
 			m.wasJustCreated = false // User made real changes
 		}
 
-		m.initialContent = m.textarea.Value()
+		m.initialContent = m.content()
+		if m.quitAfterSave {
+			m.quitAfterSave = false
+			if m.quitToShell {
+				return m, tea.Quit
+			}
+			return m, m.back()
+		}
 		return m, tea.Tick(2*time.Second, func(t time.Time) tea.Msg {
 			return ClearSaveMsg{}
 		})
@@ -238,14 +316,53 @@ func (m NotesEditorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.saveMsg = ""
 		return m, nil
 
+	case imagePastedMsg:
+		if msg.err != nil {
+			m.saveMsg = fmt.Sprintf("❌ Error: %v", msg.err)
+		} else {
+			m.insertImageLink(msg.filename)
+			m.saveMsg = "✓ Image inserted successfully!"
+		}
+		m.pastes, cmd = m.pastes.pop(m.clipboardHandler)
+		return m, cmd
+
 	case tea.KeyMsg:
+		// ctrl+c quits, but asks first if it would lose unsaved changes
+		if msg.String() == "ctrl+c" && !m.showQuitConfirm {
+			return m.quitFromCtrlC()
+		}
+
+		// A binary file was refused; only allow leaving so it can't be overwritten
+		if errors.Is(m.err, services.ErrBinaryContent) {
+			if msg.String() == "esc" || msg.String() == "q" {
+				return m, func() tea.Msg {
+					return BackToNotesBrowserMsg{}
+				}
+			}
+			return m, nil
+		}
+
 		// Handle new note name entry
 		if m.isNewNote {
+			// Confirm throwing away a typed name
+			if m.confirmDiscard {
+				switch msg.String() {
+				case "y", "Y":
+					m.confirmDiscard = false
+					return m, cancelNewNote(m.opts.NewNoteCancel)
+				case "n", "N", "esc":
+					m.confirmDiscard = false
+				}
+				return m, nil
+			}
+
 			switch msg.String() {
 			case "esc":
-				return m, func() tea.Msg {
-					return BackToDashboardMsg{}
+				if strings.TrimSpace(m.textarea.Value()) != "" {
+					m.confirmDiscard = true
+					return m, nil
 				}
+				return m, cancelNewNote(m.opts.NewNoteCancel)
 
 			case "enter":
 				// Create the note
@@ -274,15 +391,15 @@ func (m NotesEditorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.wasJustCreated = true // Mark that this note was just created
 				m.filePath = filePath
 				m.noteName = noteName
-				m.mode = ModeNormal
+				m.mode = m.opts.EditorKeymap.initialMode()
 				// Use actual window height if available, otherwise default
 				if m.height > 0 {
-					m.textarea.SetHeight(m.height - 7)
+					m.resizeTextarea()
 				} else {
 					m.textarea.SetHeight(20)
 				}
 				m.textarea.CharLimit = 0
-				m.textarea.Placeholder = "Press 'i' to enter insert mode and start writing..."
+				m.textarea.Placeholder = m.opts.EditorKeymap.placeholder()
 
 				return m, m.loadNote
 
@@ -292,73 +409,89 @@ func (m NotesEditorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-		// Normal editor mode
-		if m.mode == ModeNormal {
-			// Handle quit confirmation dialog
-			if m.showQuitConfirm {
-				switch msg.String() {
-				case "y", "Y":
-					// User wants to save before quitting
-					m.showQuitConfirm = false
-					m.saved = false
-					m.saveMsg = "Saving..."
-					// Save and then return to browser
-					return m, tea.Batch(
-						m.saveNote,
-						func() tea.Msg {
-							return BackToNotesBrowserMsg{}
-						},
-					)
-				case "n", "N":
-					// User wants to quit without saving
-					m.showQuitConfirm = false
-					return m, func() tea.Msg {
-						return BackToNotesBrowserMsg{}
-					}
-				case "esc":
-					// User cancelled, stay in editor
-					m.showQuitConfirm = false
-					return m, nil
-				}
-				return m, nil
+		// Any key other than a normal-mode vertical move forgets the goal column
+		if m.mode != ModeNormal || !isVerticalMoveKey(msg.String()) {
+			m.goalCol = -1
+		}
+
+		// Scroll or close the unsaved changes
+		if m.diff != nil {
+			if m.diff.update(msg.String(), m.textarea.Height()) {
+				m.diff = nil
 			}
+			return m, nil
+		}
 
+		// Handle quit confirmation dialog
+		if m.showQuitConfirm {
 			switch msg.String() {
-			case "q":
-				// Check if this is a newly created note (in this session) that is still empty/unchanged
-				if m.wasJustCreated && m.isEmpty() {
-					// Delete the empty note file
-					if m.filePath != "" {
-						_ = m.notesService.DeleteNote(m.filePath)
-					}
-					return m, func() tea.Msg {
-						return BackToNotesBrowserMsg{}
-					}
-				}
-
-				// Check if there are unsaved changes
-				if m.hasUnsavedChanges() {
-					m.showQuitConfirm = true
-					return m, nil
+			case "d", "D":
+				// Review the changes before deciding
+				m.diff = newDiffOverlay(m.initialContent, m.content())
+				return m, nil
+			case "y", "Y":
+				// User wants to save before quitting
+				m.showQuitConfirm = false
+				if m.unsavedScratch() {
+					// Ask for a name first, then leave once it's saved
+					m.quitAfterSave = true
+					m.startSaveAs()
+					return m, textinput.Blink
 				}
-				// No unsaved changes, go back to notes browser
-				return m, func() tea.Msg {
-					return BackToNotesBrowserMsg{}
+				m.saved = false
+				m.saveMsg = "Saving..."
+				// Save, then leave once the save succeeds
+				m.quitAfterSave = true
+				return m, m.saveNote
+			case "n", "N":
+				// User wants to quit without saving
+				m.showQuitConfirm = false
+				if m.quitToShell {
+					return m, tea.Quit
 				}
+				return m, m.back()
+			case "esc":
+				// User cancelled, stay in editor
+				m.showQuitConfirm = false
+				m.quitToShell = false
+				return m, nil
+			case "ctrl+c":
+				// A second ctrl+c quits without saving
+				return m, tea.Quit
+			}
+			return m, nil
+		}
 
-			case "ctrl+s":
-				return m, m.saveNote
+		if m.savingAs {
+			return m.updateSaveAs(msg)
+		}
 
-			case "ctrl+z":
-				// Undo
-				m.undo()
-				return m, nil
+		// Handle the find prompt and its results
+		if m.finding || m.findResults != nil {
+			return m.updateFind(msg)
+		}
 
-			case "ctrl+y":
-				// Redo
-				m.redo()
-				return m, nil
+		if m.replacing {
+			return m.updateReplace(msg)
+		}
+
+		if m.backlinks != nil {
+			return m.updateBacklinks(msg)
+		}
+
+		// Select lines in visual mode
+		if m.visual != nil {
+			return m.updateVisual(msg)
+		}
+
+		// Shared actions like save and undo, bound by the active keymap
+		if model, cmd, ok := m.runAction(m.opts.EditorKeymap.action(m.mode, msg.String())); ok {
+			return model, cmd
+		}
 
+		// Normal editor mode
+		if m.mode == ModeNormal {
+			switch msg.String() {
 			case "i":
 				m.mode = ModeInsert
 				m.textarea.Focus()
@@ -382,19 +515,19 @@ func (m NotesEditorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.textarea, cmd = m.textarea.Update(tea.KeyMsg{Type: tea.KeyLeft})
 				return m, cmd
 
-			case "j":
-				m.textarea, cmd = m.textarea.Update(tea.KeyMsg{Type: tea.KeyDown})
-				return m, cmd
+			case "j", "down":
+				m.moveVertical(1)
+				return m, nil
 
-			case "k":
-				m.textarea, cmd = m.textarea.Update(tea.KeyMsg{Type: tea.KeyUp})
-				return m, cmd
+			case "k", "up":
+				m.moveVertical(-1)
+				return m, nil
 
 			case "l":
 				m.textarea, cmd = m.textarea.Update(tea.KeyMsg{Type: tea.KeyRight})
 				return m, cmd
 
-			case "left", "right", "up", "down":
+			case "left", "right":
 				m.textarea, cmd = m.textarea.Update(msg)
 				return m, cmd
 
@@ -422,26 +555,10 @@ func (m NotesEditorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.textarea, cmd = m.textarea.Update(tea.KeyMsg{Type: tea.KeyCtrlEnd})
 				return m, cmd
 
-			case "d":
-				// Delete current line (like dd in vim)
-				m.deleteLine()
-				return m, nil
-
 			case "x":
 				// Delete character under cursor (like x in vim)
 				m.deleteChar()
 				return m, nil
-
-			case "p":
-				// Preview markdown in browser
-				if m.filePath != "" {
-					content := m.textarea.Value()
-					go func() {
-						_ = m.previewService.PreviewMarkdown(m.filePath, content)
-					}()
-					m.saveMsg = "✓ Opening preview in browser..."
-				}
-				return m, nil
 			}
 			return m, nil
 		} else {
@@ -451,44 +568,6 @@ func (m NotesEditorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.mode = ModeNormal
 				return m, nil
 
-			case "ctrl+s":
-				return m, m.saveNote
-
-			case "ctrl+z":
-				// Undo
-				m.undo()
-				return m, nil
-
-			case "ctrl+y":
-				// Redo
-				m.redo()
-				return m, nil
-
-			case "alt+v":
-				// Paste image from clipboard
-				m.saveMsg = "Checking clipboard for image..."
-
-				if m.clipboardHandler == nil {
-					m.saveMsg = "⚠ Clipboard handler not initialized"
-					return m, nil
-				}
-
-				hasImage := m.clipboardHandler.HasImage()
-				if hasImage {
-					// Handle image paste
-					m.saveMsg = "Image detected, saving..."
-					if err := m.pasteImage(); err != nil {
-						m.saveMsg = fmt.Sprintf("❌ Error: %v", err)
-					} else {
-						m.saveMsg = "✓ Image inserted successfully!"
-					}
-					return m, nil
-				}
-
-				// No image in clipboard
-				m.saveMsg = "❌ No image in clipboard"
-				return m, nil
-
 			case "enter":
 				// Smart indentation for lists
 				return m, m.insertNewLineWithIndent()
@@ -525,6 +604,7 @@ func (m NotesEditorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			default:
 				m.textarea, cmd = m.textarea.Update(msg)
+				m.updateWordCount()
 				return m, cmd
 			}
 		}
@@ -538,9 +618,286 @@ func (m NotesEditorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// runAction performs a shared editor action bound by the active keymap (see
+// editorAction), reporting false if action isn't one
+func (m NotesEditorModel) runAction(action string) (tea.Model, tea.Cmd, bool) {
+	switch action {
+	case editorActionBack:
+		model, cmd := m.leave()
+		return model, cmd, true
+
+	case editorActionSave:
+		if m.unsavedScratch() {
+			// A scratchpad needs a name before it can be written
+			m.startSaveAs()
+			return m, textinput.Blink, true
+		}
+		return m, m.saveNote, true
+
+	case editorActionUndo:
+		m.undo()
+		return m, nil, true
+
+	case editorActionRedo:
+		m.redo()
+		return m, nil, true
+
+	case editorActionPreview:
+		// Preview markdown in browser
+		if m.filePath != "" {
+			content := m.notesService.ResolveWikiLinks(m.content(), m.filePath)
+			go func() {
+				_ = m.previewService.PreviewMarkdown(m.filePath, content)
+			}()
+			m.saveMsg = "✓ Opening preview in browser..."
+		}
+		return m, nil, true
+
+	case editorActionPasteImage:
+		return m, m.pasteClipboardImage(), true
+
+	case editorActionBold:
+		m.formatWord(boldFormat)
+		return m, textarea.Blink, true
+
+	case editorActionItalic:
+		m.formatWord(italicFormat)
+		return m, textarea.Blink, true
+
+	case editorActionLink:
+		// Link the word under the cursor, or insert a [](url) skeleton
+		m.formatWord(linkFormat)
+		return m, textarea.Blink, true
+
+	case editorActionFocus:
+		// Toggle distraction-free focus mode
+		m.toggleFocusMode()
+		return m, nil, true
+
+	case editorActionDiff:
+		// Review the unsaved changes as a diff
+		m.diff = newDiffOverlay(m.initialContent, m.content())
+		return m, nil, true
+
+	case editorActionFind:
+		// Find lines in the note
+		m.startFind()
+		return m, textinput.Blink, true
+
+	case editorActionFindNext:
+		// Next line matching the last find
+		m.findNext(1)
+		return m, nil, true
+
+	case editorActionFindPrev:
+		// Previous line matching the last find
+		m.findNext(-1)
+		return m, nil, true
+
+	case editorActionReplace:
+		// Replace the last find's matches
+		m.startReplace()
+		return m, textinput.Blink, true
+
+	case editorActionNextEntry:
+		model, cmd := m.openNeighbor(1)
+		return model, cmd, true
+
+	case editorActionPrevEntry:
+		model, cmd := m.openNeighbor(-1)
+		return model, cmd, true
+
+	case editorActionDeleteLine:
+		// Delete current line (like dd in vim)
+		m.deleteLine()
+		return m, nil, true
+
+	case editorActionSelect:
+		// Select whole lines, starting with this one
+		m.visual = newVisualSelection(m.textarea.Line(), m.textarea.Height())
+		return m, nil, true
+
+	case editorActionPasteLines:
+		// Paste the lines last yanked or deleted in visual mode below this one
+		m.pasteLines()
+		return m, nil, true
+
+	case editorActionFold:
+		// Fold/unfold the frontmatter block
+		if m.foldedHeader != "" {
+			m.unfoldFrontMatter()
+		} else if !m.foldFrontMatter() {
+			m.saveMsg = "No frontmatter to fold"
+		}
+		return m, nil, true
+
+	case editorActionBacklinks:
+		// List the notes that link here with [[name]]
+		m.showBacklinks()
+		return m, nil, true
+	}
+
+	return m, nil, false
+}
+
+// openNeighbor opens the note step places away in this note's directory, for reading
+// through a folder without going back to the browser
+func (m NotesEditorModel) openNeighbor(step int) (tea.Model, tea.Cmd) {
+	if m.filePath == "" {
+		return m, nil
+	}
+	if m.hasUnsavedChanges() {
+		m.saveMsg = "Save this note (ctrl+s) before opening another"
+		return m, nil
+	}
+
+	neighbor, err := m.notesService.NeighborNote(m.filePath, m.noteSort, step)
+	if err != nil {
+		m.saveMsg = fmt.Sprintf("❌ %v", err)
+		return m, nil
+	}
+	if neighbor == m.filePath {
+		m.saveMsg = "No other notes in this directory"
+		return m, nil
+	}
+	sort := m.noteSort
+	return m, func() tea.Msg {
+		return OpenNoteMsg{filePath: neighbor, sort: sort}
+	}
+}
+
+// leave goes back to the notes browser, asking first if there are unsaved changes
+func (m NotesEditorModel) leave() (tea.Model, tea.Cmd) {
+	// Check if this is a newly created note (in this session) that is still empty/unchanged
+	if m.wasJustCreated && m.isEmpty() {
+		// Delete the empty note file
+		if m.filePath != "" {
+			_ = m.notesService.DeleteNote(m.filePath)
+		}
+		return m, m.back()
+	}
+
+	// Check if there are unsaved changes
+	if m.hasUnsavedChanges() {
+		m.showQuitConfirm = true
+		return m, nil
+	}
+	// No unsaved changes, go back
+	return m, m.back()
+}
+
+// pasteClipboardImage queues saving the image (or image file) on the clipboard as an
+// attachment, returning the command that saves it. The link is inserted at the cursor
+// once it has been saved (see imagePastedMsg).
+func (m *NotesEditorModel) pasteClipboardImage() tea.Cmd {
+	if m.unsavedScratch() {
+		// The image would be written before the text it belongs to
+		m.saveMsg = "⚠ Save the scratchpad as a note before pasting images"
+		return nil
+	}
+	if m.clipboardHandler == nil {
+		m.saveMsg = "⚠ Clipboard handler not initialized"
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.pastes, cmd = m.pastes.push(m.clipboardHandler, imagePaste{
+		// Use a centralized .attachments/imgs directory, naming the image after the current file
+		imgsDir:  filepath.Join(m.notesService.GetNotesDir(), ".attachments", "imgs"),
+		baseName: clipboardImageBaseName(m.filePath),
+	})
+	m.saveMsg = "Saving pasted image..."
+	return cmd
+}
+
+// updateVisual handles keys in line-wise visual mode: j/k extend the selection,
+// and d or y act on the selected lines
+func (m NotesEditorModel) updateVisual(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	from, to := m.visual.bounds(m.textarea.Line())
+
+	switch msg.String() {
+	case "esc", "v", "q":
+		m.visual = nil
+		return m, nil
+
+	case "j", "down":
+		m.moveVertical(1)
+
+	case "k", "up":
+		m.moveVertical(-1)
+
+	case "g":
+		m.moveToLine(0)
+
+	case "G":
+		m.moveToLine(m.textarea.LineCount() - 1)
+
+	case "y":
+		// Copy the lines to the register and go back to the top of the selection
+		lines := strings.Split(m.textarea.Value(), "\n")
+		lineRegister = append([]string(nil), lines[from:to+1]...)
+		m.visual = nil
+		m.moveToLine(from)
+		m.saveMsg = fmt.Sprintf("✓ Yanked %d line(s) • p: paste", to-from+1)
+		return m, nil
+
+	case "d", "x":
+		m.deleteLines(from, to)
+		m.visual = nil
+		m.saveMsg = fmt.Sprintf("Deleted %d line(s) • p: paste", to-from+1)
+		return m, nil
+	}
+
+	m.visual.scrollTo(m.textarea.Line(), m.textarea.Height())
+	return m, nil
+}
+
+// deleteLines deletes lines from through to as a single undo step, keeping them in
+// the line register
+func (m *NotesEditorModel) deleteLines(from, to int) {
+	lines := strings.Split(m.textarea.Value(), "\n")
+	if to >= len(lines) {
+		return
+	}
+	lineRegister = append([]string(nil), lines[from:to+1]...)
+
+	// Save current state before deletion
+	m.trackContentChange()
+
+	newContent, line := deleteLineRange(m.textarea.Value(), from, to)
+	m.textarea.SetValue(newContent)
+	m.moveToLine(line)
+
+	// Track the change after deletion
+	m.trackContentChange()
+}
+
+// pasteLines inserts the line register below the cursor line
+func (m *NotesEditorModel) pasteLines() {
+	if len(lineRegister) == 0 {
+		m.saveMsg = "Nothing to paste • select lines with v, then y or d"
+		return
+	}
+
+	m.trackContentChange()
+	newContent, line := pasteLinesAfter(m.textarea.Value(), m.textarea.Line(), lineRegister)
+	m.textarea.SetValue(newContent)
+	m.moveToLine(line)
+	m.trackContentChange()
+}
+
+// undoHistory is the undo stack, as saved next to the note when PersistUndo is on
+func (m NotesEditorModel) undoHistory() []services.UndoEntry {
+	entries := make([]services.UndoEntry, 0, len(m.undoStack))
+	for _, state := range m.undoStack {
+		entries = append(entries, services.UndoEntry{Content: state.content, Line: state.line, Column: state.column})
+	}
+	return entries
+}
+
 // trackContentChange saves the current content to undo stack if it changed
 func (m *NotesEditorModel) trackContentChange() {
-	currentContent := m.textarea.Value()
+	currentContent := m.content()
 	if currentContent != m.lastContent {
 		// Get current cursor position
 		lineInfo := m.textarea.LineInfo()
@@ -557,6 +914,7 @@ func (m *NotesEditorModel) trackContentChange() {
 			m.undoStack = m.undoStack[1:]
 		}
 		m.lastContent = currentContent
+		m.updateWordCount()
 		// Clear redo stack on new change
 		m.redoStack = []undoState{}
 	}
@@ -584,8 +942,9 @@ func (m *NotesEditorModel) undo() {
 	m.undoStack = m.undoStack[:len(m.undoStack)-1]
 
 	// Restore content
-	m.textarea.SetValue(previousState.content)
+	m.setContent(previousState.content)
 	m.lastContent = previousState.content
+	m.updateWordCount()
 
 	// Restore cursor position
 	// First, move to the correct line
@@ -625,8 +984,9 @@ func (m *NotesEditorModel) redo() {
 	m.redoStack = m.redoStack[:len(m.redoStack)-1]
 
 	// Restore content
-	m.textarea.SetValue(nextState.content)
+	m.setContent(nextState.content)
 	m.lastContent = nextState.content
+	m.updateWordCount()
 
 	// Restore cursor position
 	// First, move to the correct line
@@ -644,55 +1004,435 @@ func (m *NotesEditorModel) redo() {
 	m.textarea.SetCursor(nextState.column)
 }
 
+// updateWordCount recounts the words shown in the title line
+func (m *NotesEditorModel) updateWordCount() {
+	m.wordCount = services.CountWords(m.content())
+}
+
 // hasUnsavedChanges checks if the current content differs from the initial/saved content
 func (m *NotesEditorModel) hasUnsavedChanges() bool {
-	return m.textarea.Value() != m.initialContent
+	return m.content() != m.initialContent
 }
 
-// deleteLine deletes the current line where the cursor is positioned
-func (m *NotesEditorModel) deleteLine() {
-	content := m.textarea.Value()
-	if content == "" {
+// content returns the full note content, including any folded frontmatter
+func (m *NotesEditorModel) content() string {
+	return m.foldedHeader + m.textarea.Value()
+}
+
+// setContent replaces the note content, keeping the frontmatter folded if it was
+func (m *NotesEditorModel) setContent(content string) {
+	if m.foldedHeader != "" {
+		if header, body, ok := splitFrontMatter(content); ok {
+			m.foldedHeader = header
+			m.textarea.SetValue(body)
+			return
+		}
+		m.foldedHeader = ""
+		m.resizeTextarea()
+	}
+	m.textarea.SetValue(content)
+}
+
+// foldFrontMatter hides the frontmatter block from the textarea, showing a summary
+// line instead. The saved content is unchanged. Returns false if there is none.
+func (m *NotesEditorModel) foldFrontMatter() bool {
+	header, body, ok := splitFrontMatter(m.textarea.Value())
+	if !ok {
+		return false
+	}
+
+	line := foldedLine(m.textarea.Line(), strings.Count(header, "\n"))
+	m.foldedHeader = header
+	m.textarea.SetValue(body)
+	m.resizeTextarea()
+	m.moveToLine(line)
+	return true
+}
+
+// unfoldFrontMatter puts the folded frontmatter back into the textarea
+func (m *NotesEditorModel) unfoldFrontMatter() {
+	line := unfoldedLine(m.textarea.Line(), strings.Count(m.foldedHeader, "\n"))
+	m.textarea.SetValue(m.content())
+	m.foldedHeader = ""
+	m.resizeTextarea()
+	m.moveToLine(line)
+}
+
+// startFind opens the prompt for finding lines in the note
+func (m *NotesEditorModel) startFind() {
+	ti := textinput.New()
+	ti.Prompt = "/"
+	ti.Placeholder = "find in note..."
+	ti.CharLimit = 256
+	ti.Width = 60
+	ti.SetValue(m.findQuery)
+	ti.Focus()
+
+	m.findInput = ti
+	m.finding = true
+	m.findResults = nil
+	m.findCursor = 0
+}
+
+// updateFind handles keys while the find prompt or its results are showing
+func (m NotesEditorModel) updateFind(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.finding {
+		switch msg.String() {
+		case "esc":
+			m.finding = false
+			return m, nil
+
+		case "enter":
+			m.finding = false
+			m.findQuery = m.findInput.Value()
+			m.findOpts = services.SearchOptions{}
+			matches, err := services.SearchLines(m.content(), m.findQuery, m.findOpts)
+			if err != nil {
+				m.saveMsg = err.Error()
+				return m, nil
+			}
+			if len(matches) == 0 {
+				m.saveMsg = fmt.Sprintf("No lines match %q", m.findInput.Value())
+				return m, nil
+			}
+			m.findResults = matches
+			m.findCursor = 0
+			return m, nil
+		}
+
+		var cmd tea.Cmd
+		m.findInput, cmd = m.findInput.Update(msg)
+		return m, cmd
+	}
+
+	switch msg.String() {
+	case "esc", "q":
+		m.findResults = nil
+
+	case "up", "k":
+		if m.findCursor > 0 {
+			m.findCursor--
+		}
+
+	case "down", "j":
+		if m.findCursor < len(m.findResults)-1 {
+			m.findCursor++
+		}
+
+	case "enter":
+		// Matches are numbered against the full content, so show the frontmatter first
+		line := m.findResults[m.findCursor].Line - 1
+		m.findResults = nil
+		if m.foldedHeader != "" {
+			m.unfoldFrontMatter()
+		}
+		m.moveToLine(line)
+	}
+
+	return m, nil
+}
+
+// findNext moves to the next (dir 1) or previous (dir -1) line matching the last find
+func (m *NotesEditorModel) findNext(dir int) {
+	m.findFrom(unfoldedLine(m.textarea.Line(), strings.Count(m.foldedHeader, "\n")), dir)
+}
+
+// findFrom moves to the nearest line matching the last find after (dir 1) or before
+// (dir -1) line, a zero-based line of the full content, wrapping around the note
+func (m *NotesEditorModel) findFrom(line, dir int) {
+	if m.findQuery == "" {
+		m.saveMsg = "Nothing to find yet, press / to find"
 		return
 	}
 
-	// Save current state before deletion
+	matches, err := services.SearchLines(m.content(), m.findQuery, m.findOpts)
+	if err != nil {
+		m.saveMsg = err.Error()
+		return
+	}
+	if len(matches) == 0 {
+		m.saveMsg = fmt.Sprintf("No lines match %q", m.findQuery)
+		return
+	}
+
+	idx := 0
+	if dir > 0 {
+		for idx < len(matches) && matches[idx].Line-1 <= line {
+			idx++
+		}
+		if idx == len(matches) {
+			idx = 0
+		}
+	} else {
+		idx = len(matches) - 1
+		for idx >= 0 && matches[idx].Line-1 >= line {
+			idx--
+		}
+		if idx < 0 {
+			idx = len(matches) - 1
+		}
+	}
+
+	// Matches are numbered against the full content; unfold if one is in the frontmatter
+	target := matches[idx].Line - 1
+	headerLines := strings.Count(m.foldedHeader, "\n")
+	if m.foldedHeader != "" && target < headerLines {
+		m.unfoldFrontMatter()
+		headerLines = 0
+	}
+	m.moveToLine(target - headerLines)
+	m.saveMsg = fmt.Sprintf("Match %d of %d for %q • n/N: next/previous", idx+1, len(matches), m.findQuery)
+}
+
+// startReplace opens the prompt for replacing the last find, or explains why it can't
+func (m *NotesEditorModel) startReplace() {
+	if m.findQuery == "" {
+		m.saveMsg = "Nothing to replace yet, press / to find first"
+		return
+	}
+
+	ti := textinput.New()
+	ti.Prompt = fmt.Sprintf("Replace %q with: ", m.findQuery)
+	ti.CharLimit = 256
+	ti.Width = 60
+	ti.Focus()
+
+	m.replaceInput = ti
+	m.replacing = true
+}
+
+// updateReplace handles keys while the replace prompt is showing: enter replaces the
+// current match and moves to the next one, ctrl+r replaces every match
+func (m NotesEditorModel) updateReplace(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.replacing = false
+		return m, nil
+
+	case "enter":
+		m.replacing = false
+		m.replaceCurrent(m.replaceInput.Value())
+		return m, nil
+
+	case "ctrl+r":
+		m.replacing = false
+		m.replaceAll(m.replaceInput.Value())
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.replaceInput, cmd = m.replaceInput.Update(msg)
+	return m, cmd
+}
+
+// replaceCurrent replaces the first match on the cursor's line, or the next match after
+// it, then moves to the following match
+func (m *NotesEditorModel) replaceCurrent(replacement string) {
+	content := m.content()
+	matches, err := services.SearchLines(content, m.findQuery, m.findOpts)
+	if err != nil {
+		m.saveMsg = err.Error()
+		return
+	}
+	if len(matches) == 0 {
+		m.saveMsg = fmt.Sprintf("No lines match %q", m.findQuery)
+		return
+	}
+
+	cursorLine := unfoldedLine(m.textarea.Line(), strings.Count(m.foldedHeader, "\n"))
+	line := matches[0].Line - 1
+	for _, match := range matches {
+		if match.Line-1 >= cursorLine {
+			line = match.Line - 1
+			break
+		}
+	}
+
+	updated, _, err := services.ReplaceMatches(content, m.findQuery, replacement, m.findOpts, line)
+	if err != nil {
+		m.saveMsg = err.Error()
+		return
+	}
+	m.setContent(updated)
 	m.trackContentChange()
 
-	lines := strings.Split(content, "\n")
-	lineInfo := m.textarea.Line()
-	currentLine := lineInfo
+	m.findFrom(line, 1)
+	if strings.HasPrefix(m.saveMsg, "No lines match") {
+		m.saveMsg = fmt.Sprintf("Replaced the last match of %q", m.findQuery)
+		return
+	}
+	m.saveMsg = "Replaced 1 match • " + m.saveMsg
+}
 
-	// Check bounds
-	if currentLine >= len(lines) {
+// replaceAll replaces every match of the last find
+func (m *NotesEditorModel) replaceAll(replacement string) {
+	updated, count, err := services.ReplaceMatches(m.content(), m.findQuery, replacement, m.findOpts, -1)
+	if err != nil {
+		m.saveMsg = err.Error()
+		return
+	}
+	if count == 0 {
+		m.saveMsg = fmt.Sprintf("No lines match %q", m.findQuery)
 		return
 	}
 
-	// Remove the current line
-	newLines := append(lines[:currentLine], lines[currentLine+1:]...)
-	newContent := strings.Join(newLines, "\n")
+	line := m.textarea.Line()
+	m.setContent(updated)
+	m.trackContentChange()
+	m.moveToLine(line)
+	m.saveMsg = fmt.Sprintf("Replaced %d match(es) of %q", count, m.findQuery)
+}
 
-	// If we deleted the last line and there's content remaining, ensure proper ending
-	if currentLine >= len(newLines) && len(newLines) > 0 {
-		currentLine = len(newLines) - 1
+// showBacklinks opens the list of notes linking to this one, or explains why it can't
+func (m *NotesEditorModel) showBacklinks() {
+	if m.filePath == "" {
+		return
 	}
 
-	// Update content
-	m.textarea.SetValue(newContent)
+	backlinks, err := m.notesService.FindBacklinks(m.filePath)
+	if err != nil {
+		m.saveMsg = fmt.Sprintf("❌ %v", err)
+		return
+	}
+	if len(backlinks) == 0 {
+		m.saveMsg = fmt.Sprintf("No notes link to [[%s]]", strings.TrimSuffix(filepath.Base(m.filePath), ".md"))
+		return
+	}
 
-	// Position cursor at the beginning of the same line number (or last line if we deleted the last line)
-	// We need to count newlines to position correctly
-	if currentLine > 0 {
-		targetPos := 0
-		for i := 0; i < currentLine; i++ {
-			if i < len(newLines) {
-				targetPos += len(newLines[i]) + 1 // +1 for newline
-			}
+	m.backlinks = backlinks
+	m.backlinkCursor = 0
+}
+
+// updateBacklinks handles keys while the backlinks list is showing
+func (m NotesEditorModel) updateBacklinks(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		m.backlinks = nil
+
+	case "up", "k":
+		if m.backlinkCursor > 0 {
+			m.backlinkCursor--
 		}
-		m.textarea.SetCursor(targetPos)
-	} else {
-		m.textarea.SetCursor(0)
+
+	case "down", "j":
+		if m.backlinkCursor < len(m.backlinks)-1 {
+			m.backlinkCursor++
+		}
+
+	case "enter":
+		if m.hasUnsavedChanges() {
+			m.saveMsg = "Save this note (ctrl+s) before opening another"
+			return m, nil
+		}
+		filePath := m.backlinks[m.backlinkCursor].FilePath
+		return m, func() tea.Msg {
+			return OpenNoteMsg{filePath: filePath}
+		}
+	}
+
+	return m, nil
+}
+
+// backlinksView lists the notes linking to this one, windowed around the cursor
+func (m NotesEditorModel) backlinksView() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("%d note(s) link here:\n\n", len(m.backlinks)))
+
+	height := m.textarea.Height() - 2
+	if height < 1 {
+		height = 1
 	}
+	start, end := pageWindow(m.backlinkCursor, len(m.backlinks), height)
+
+	for i := start; i < end; i++ {
+		note := m.backlinks[i]
+		line := note.DisplayName()
+		if line != note.Name {
+			line += " (" + note.Name + ")"
+		}
+		if i == m.backlinkCursor {
+			b.WriteString(noteSelectedStyle.Render("▶ " + line))
+		} else {
+			b.WriteString("  " + line)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// findResultsView lists the lines matched by the last find, windowed around the cursor
+func (m NotesEditorModel) findResultsView() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("%d matching line(s):\n\n", len(m.findResults)))
+
+	height := m.textarea.Height() - 2
+	if height < 1 {
+		height = 1
+	}
+	start, end := pageWindow(m.findCursor, len(m.findResults), height)
+
+	width := len(fmt.Sprint(m.findResults[len(m.findResults)-1].Line))
+	for i := start; i < end; i++ {
+		match := m.findResults[i]
+		line := fmt.Sprintf("%*d: %s", width, match.Line, strings.TrimSpace(match.Text))
+		if i == m.findCursor {
+			b.WriteString(noteSelectedStyle.Render("▶ " + line))
+		} else {
+			b.WriteString("  " + line)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// moveToLine moves the cursor to the start of the given line
+func (m *NotesEditorModel) moveToLine(line int) {
+	m.textarea.CursorStart()
+	for m.textarea.Line() > 0 {
+		m.textarea.CursorUp()
+	}
+	for m.textarea.Line() < line && m.textarea.Line() < m.textarea.LineCount()-1 {
+		m.textarea.CursorDown()
+	}
+	m.textarea.CursorStart()
+}
+
+// resizeTextarea fits the textarea to the window, leaving room for the folded frontmatter summary
+func (m *NotesEditorModel) resizeTextarea() {
+	if m.width <= 0 || m.height <= 0 || m.isNewNote {
+		return
+	}
+	extra := 0
+	if m.foldedHeader != "" {
+		extra = 1
+	}
+	m.textarea.SetHeight(editorTextareaHeight(m.height, extra, m.focusMode))
+}
+
+// toggleFocusMode shows or hides the title, mode, and help around the textarea
+func (m *NotesEditorModel) toggleFocusMode() {
+	m.focusMode = !m.focusMode
+	m.resizeTextarea()
+}
+
+// deleteLine deletes the current line where the cursor is positioned
+func (m *NotesEditorModel) deleteLine() {
+	content := m.textarea.Value()
+	if content == "" {
+		return
+	}
+
+	newContent, line, ok := deleteLineAt(content, m.textarea.Line())
+	if !ok {
+		return
+	}
+
+	// Save current state before deletion
+	m.trackContentChange()
+
+	// Keep the cursor on the same line number, or the new last line if the last line was deleted
+	m.textarea.SetValue(newContent)
+	m.moveToLine(line)
 
 	// Track the change after deletion
 	m.trackContentChange()
@@ -751,24 +1491,8 @@ func (m *NotesEditorModel) deleteChar() {
 	m.trackContentChange()
 }
 
-// pasteImage handles pasting an image from the clipboard
-func (m *NotesEditorModel) pasteImage() error {
-	if m.clipboardHandler == nil {
-		return fmt.Errorf("clipboard handler not initialized")
-	}
-
-	// Get the notes directory
-	notesDir := m.notesService.GetNotesDir()
-
-	// Use a centralized .attachments/imgs directory
-	imgsDir := filepath.Join(notesDir, ".attachments", "imgs")
-
-	// Save the image and get the filename
-	filename, err := m.clipboardHandler.SaveClipboardImage(imgsDir, "image")
-	if err != nil {
-		return err
-	}
-
+// insertImageLink links the pasted image filename (in .attachments/imgs) at the cursor
+func (m *NotesEditorModel) insertImageLink(filename string) {
 	// Create the relative path for the markdown link
 	relativePath := fmt.Sprintf(".attachments/imgs/%s", filename)
 
@@ -798,13 +1522,11 @@ func (m *NotesEditorModel) pasteImage() error {
 
 	// Track the change
 	m.trackContentChange()
-
-	return nil
 }
 
 // isEmpty checks if the note content is effectively empty (only whitespace or unchanged from initial)
 func (m *NotesEditorModel) isEmpty() bool {
-	content := strings.TrimSpace(m.textarea.Value())
+	content := strings.TrimSpace(m.content())
 	initialContent := strings.TrimSpace(m.initialContent)
 
 	// Empty if no content or content matches initial template
@@ -1090,6 +1812,28 @@ func (m *NotesEditorModel) insertNewLineWithIndent() tea.Cmd {
 	return cmd
 }
 
+// formatWord applies a markdown format to the word under the cursor, since the
+// textarea has no selection, or inserts the format's skeleton when not on a word
+func (m *NotesEditorModel) formatWord(format markdownFormat) {
+	// Save the text typed so far as its own undo step
+	m.trackContentChange()
+
+	lines := strings.Split(m.textarea.Value(), "\n")
+	row := m.textarea.Line()
+	if row >= len(lines) {
+		return
+	}
+
+	info := m.textarea.LineInfo()
+	line, col := formatWordAt(lines[row], info.StartColumn+info.ColumnOffset, format)
+	lines[row] = line
+
+	m.textarea.SetValue(strings.Join(lines, "\n"))
+	m.moveToLine(row)
+	m.textarea.SetCursor(col)
+	m.trackContentChange()
+}
+
 // indentCurrentLine adds indentation to the current line (for Tab key)
 func (m *NotesEditorModel) indentCurrentLine() {
 	lineInfo := m.textarea.LineInfo()
@@ -1184,7 +1928,38 @@ func (m NotesEditorModel) View() string {
 		b.WriteString("Enter note name:\n")
 		b.WriteString(m.textarea.View())
 		b.WriteString("\n\n")
+		if m.confirmDiscard {
+			b.WriteString(confirmTextStyle.Render("Discard this new note? (y/n)"))
+			b.WriteString("\n\n")
+		}
 		b.WriteString(notesHelpStyle.Render("enter: create • esc: cancel"))
+	} else if m.focusMode {
+		// Just the text and one status line
+		if m.diff != nil {
+			b.WriteString(m.diff.view(m.textarea.Height()))
+		} else if m.visual != nil {
+			b.WriteString(m.visual.view(m.textarea.Value(), m.textarea.Line(), m.textarea.Width(), m.textarea.Height()))
+		} else if m.findResults != nil {
+			b.WriteString(m.findResultsView())
+		} else if m.backlinks != nil {
+			b.WriteString(m.backlinksView())
+		} else {
+			b.WriteString(m.textarea.View())
+		}
+		b.WriteString("\n")
+
+		switch {
+		case m.showQuitConfirm:
+			b.WriteString(confirmTextStyle.Render("⚠ Unsaved changes. Save before quitting? (y/n, d: show changes, esc to cancel)"))
+		case m.savingAs:
+			b.WriteString(m.saveAsInput.View())
+		case m.finding:
+			b.WriteString(m.findInput.View())
+		case m.replacing:
+			b.WriteString(m.replaceInput.View())
+		default:
+			b.WriteString(notesHelpStyle.Render(focusIndicator(m.saveMsg, m.hasUnsavedChanges())))
+		}
 	} else {
 		// Normal editor
 		title := fmt.Sprintf("📝 %s", m.filePath)
@@ -1194,11 +1969,15 @@ func (m NotesEditorModel) View() string {
 		b.WriteString(notesEditorTitleStyle.Render(title))
 		b.WriteString(" ")
 
-		if m.mode == ModeInsert {
-			b.WriteString(notesModeStyle.Render("-- INSERT --"))
+		if m.visual != nil {
+			b.WriteString(notesModeStyle.Render("-- VISUAL LINE --"))
+		} else if m.mode == ModeInsert {
+			b.WriteString(notesModeStyle.Render(m.opts.EditorKeymap.modeLabel(m.mode)))
 		} else {
-			b.WriteString(notesNormalModeStyle.Render("-- NORMAL --"))
+			b.WriteString(notesNormalModeStyle.Render(m.opts.EditorKeymap.modeLabel(m.mode)))
 		}
+		b.WriteString(" ")
+		b.WriteString(notesHelpStyle.Render(wordCountStatus(m.wordCount)))
 		b.WriteString("\n")
 
 		if m.saveMsg != "" {
@@ -1210,23 +1989,65 @@ func (m NotesEditorModel) View() string {
 			b.WriteString("\n")
 		}
 
-		b.WriteString(m.textarea.View())
+		if m.foldedHeader != "" {
+			b.WriteString(notesHelpStyle.Render("▸ --- " + frontMatterSummary(m.foldedHeader) + " ---"))
+			b.WriteString("\n")
+		}
+
+		if m.diff != nil {
+			b.WriteString(m.diff.view(m.textarea.Height()))
+		} else if m.visual != nil {
+			b.WriteString(m.visual.view(m.textarea.Value(), m.textarea.Line(), m.textarea.Width(), m.textarea.Height()))
+		} else if m.findResults != nil {
+			b.WriteString(m.findResultsView())
+		} else if m.backlinks != nil {
+			b.WriteString(m.backlinksView())
+		} else {
+			b.WriteString(m.textarea.View())
+		}
 		b.WriteString("\n\n")
 
+		if m.savingAs {
+			b.WriteString(m.saveAsInput.View())
+			b.WriteString("\n\n")
+		} else if m.finding {
+			b.WriteString(m.findInput.View())
+			b.WriteString("\n\n")
+		} else if m.replacing {
+			b.WriteString(m.replaceInput.View())
+			b.WriteString("\n\n")
+		}
+
 		// Show quit confirmation dialog if needed
 		if m.showQuitConfirm {
 			confirmStyle := lipgloss.NewStyle().
 				Foreground(lipgloss.Color("226")).
 				Bold(true)
-			b.WriteString(confirmStyle.Render("⚠ You have unsaved changes. Save before quitting? (y/n, esc to cancel)"))
+			b.WriteString(confirmStyle.Render("⚠ You have unsaved changes. Save before quitting? (y/n, d: show changes, esc to cancel)"))
 			b.WriteString("\n\n")
 		}
 
 		var help string
-		if m.mode == ModeNormal {
-			help = "hjkl: move • i/a/o: insert • d: delete line • x: delete char • p: preview • 0/$: line start/end • g/G: top/bottom • ctrl+s: save • q: back"
+		if m.diff != nil {
+			help = "j/k: scroll • ctrl+d/u: page • esc: close"
+		} else if m.visual != nil {
+			help = "j/k: extend selection • g/G: to top/bottom • d: delete lines • y: yank lines • esc: cancel"
+		} else if m.savingAs {
+			help = "enter: save as a note • esc: cancel"
+		} else if m.finding {
+			help = "enter: find • esc: cancel"
+		} else if m.replacing {
+			help = "enter: replace this match • ctrl+r: replace all • esc: cancel"
+		} else if m.findResults != nil {
+			help = "j/k: select • enter: go to line • esc: close"
+		} else if m.backlinks != nil {
+			help = "j/k: select • enter: open note • esc: close"
+		} else if !m.opts.EditorKeymap.modal() {
+			help = "alt+B/I/K: bold/italic/link • alt+X: delete line • alt+V: select lines • alt+Y: paste lines • alt+z: fold frontmatter • alt+L: backlinks • alt+s: find • alt+g/G: next/prev match • alt+%: replace • alt+N/P: next/prev note • alt+D: diff • alt+F: focus mode • alt+p: preview • alt+v: paste image • ctrl+z/y: undo/redo • ctrl+s: save • esc: back"
+		} else if m.mode == ModeNormal {
+			help = "hjkl: move • i/a/o: insert • d: delete line • x: delete char • v: select lines • p: paste lines • z: fold frontmatter • F: focus mode • B: backlinks • D: diff • /: find • n/N: next/prev match • ]/[: next/prev note • R: replace • P: preview • 0/$: line start/end • g/G: top/bottom • ctrl+s: save • q: back"
 		} else {
-			help = "esc: normal mode • ctrl+z/y: undo/redo • alt+v: paste image • ctrl+s: save • ctrl+c: quit"
+			help = "esc: normal mode • ctrl+z/y: undo/redo • ctrl+b/alt+i/ctrl+k: bold/italic/link • alt+v: paste image • ctrl+s: save • ctrl+c: quit"
 		}
 		b.WriteString(notesHelpStyle.Render(help))
 	}
@@ -1246,6 +2067,7 @@ func (m NotesEditorModel) View() string {
 type NotesEditorLoadedMsg struct {
 	filePath string
 	content  string
+	undo     []services.UndoEntry // Saved undo history, oldest first, when PersistUndo is on
 }
 
 type NotesEditorErrorMsg struct {
@@ -1254,4 +2076,56 @@ type NotesEditorErrorMsg struct {
 
 type NotesSavedMsg struct{}
 
+// NotesSaveFailedMsg reports a save that didn't write the note
+type NotesSaveFailedMsg struct {
+	err error
+}
+
 type BackToNotesBrowserMsg struct{}
+
+// cancelNewNote leaves the new-note name prompt for the configured destination
+func cancelNewNote(dest NewNoteCancelDestination) tea.Cmd {
+	if dest == CancelToDashboard {
+		return func() tea.Msg {
+			return BackToDashboardMsg{}
+		}
+	}
+	return func() tea.Msg {
+		return BackToNotesBrowserMsg{}
+	}
+}
+
+// moveVertical moves the cursor delta lines, keeping the goal column across short lines
+func (m *NotesEditorModel) moveVertical(delta int) {
+	info := m.textarea.LineInfo()
+	lines := strings.Split(m.textarea.Value(), "\n")
+	target, col, goal := verticalMove(lines, m.textarea.Line(), info.StartColumn+info.ColumnOffset, m.goalCol, delta)
+	m.goalCol = goal
+
+	for m.textarea.Line() < target {
+		m.textarea.CursorDown()
+	}
+	for m.textarea.Line() > target {
+		m.textarea.CursorUp()
+	}
+	m.textarea.SetCursor(col)
+}
+
+// quitFromCtrlC exits the program on ctrl+c. With unsaved changes it shows the
+// save confirmation instead, unless the editor is configured to quit immediately.
+func (m NotesEditorModel) quitFromCtrlC() (tea.Model, tea.Cmd) {
+	// Nothing to lose while still naming a new note
+	if m.isNewNote || m.opts.CtrlC == CtrlCQuit || !m.hasUnsavedChanges() {
+		return m, tea.Quit
+	}
+
+	if m.opts.EditorKeymap.modal() {
+		m.mode = ModeNormal
+	}
+	m.diff = nil
+	m.visual = nil
+	m.savingAs = false
+	m.showQuitConfirm = true
+	m.quitToShell = true
+	return m, nil
+}
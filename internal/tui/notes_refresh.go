@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// MinAutoRefreshGap is the shortest time between automatic notes browser refreshes,
+// so focus changes and timers don't keep re-reading a large notes directory
+const MinAutoRefreshGap = 2 * time.Second
+
+// notesRefreshTickMsg is sent by the auto-refresh timer of the browser with refreshID id
+type notesRefreshTickMsg struct {
+	id int
+}
+
+// lastNotesRefreshID numbers notes browsers, so a timer started by a browser that has
+// since been replaced stops instead of running alongside the new one's
+var lastNotesRefreshID int
+
+// scheduleAutoRefresh starts the wait for the next timed refresh, if there is a timer
+func (m NotesBrowserModel) scheduleAutoRefresh() tea.Cmd {
+	if m.opts.NotesRefreshInterval <= 0 {
+		return nil
+	}
+	id := m.refreshID
+	return tea.Tick(m.opts.NotesRefreshInterval, func(time.Time) tea.Msg {
+		return notesRefreshTickMsg{id: id}
+	})
+}
+
+// autoRefresh reloads the notes in place, keeping the selection. It does nothing
+// within MinAutoRefreshGap of the last load, or while a prompt, menu or filter is
+// open, since reloading would reset what's on screen.
+func (m *NotesBrowserModel) autoRefresh(now time.Time) {
+	if now.Sub(m.lastRefresh) < MinAutoRefreshGap {
+		return
+	}
+	if m.confirmDelete || m.showingNewMenu || m.creatingCategory || m.movingNote ||
+		m.pastingNote || m.renamingNote || m.showingTags || m.showingTemplates || m.showingArchive ||
+		m.filterMode != FilterNone || m.searchInput.Focused() {
+		return
+	}
+	m.restorePosition(m.position())
+}
@@ -0,0 +1,109 @@
+package tui
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/redjax/notetkr/internal/config"
+	"github.com/redjax/notetkr/internal/services"
+	"github.com/redjax/notetkr/internal/utils"
+)
+
+// Options are the settings the app's views and services are built with
+type Options struct {
+	Keys         KeyMap        // Navigation keys of the browsers
+	EditorKeymap EditorKeymap  // Key binding style of the notes and journal editors
+	CtrlC        CtrlCBehavior // What ctrl+c does in the editors
+
+	// PersistUndo saves a note's undo history (in a .undo file next to it) on save,
+	// so undo still works after the note is closed and reopened
+	PersistUndo bool
+
+	// JumpToLatestTimeSection opens today's journal at its last time section (e.g. "## 14:30")
+	JumpToLatestTimeSection bool
+
+	// NewNoteCancel is where esc at the new-note name prompt returns to
+	NewNoteCancel NewNoteCancelDestination
+
+	// IdleLock is how long the app can sit without input before it blanks the
+	// screen, so notes aren't left showing on a shared terminal. 0 turns it off.
+	IdleLock time.Duration
+
+	// NotesRefreshOnFocus reloads the notes browser when the terminal window regains focus
+	NotesRefreshOnFocus bool
+
+	// NotesRefreshInterval reloads the notes browser on a timer; 0 turns the timer off
+	NotesRefreshInterval time.Duration
+
+	// QuickDelete makes d in the notes browser delete a note straight away, keeping it
+	// in a session trash for QuickDeleteUndoFor so u can restore it, instead of asking
+	// for confirmation. Categories are always confirmed.
+	QuickDelete bool
+
+	// SearchHistoryFile is where past search queries are saved, "" to keep them in memory only
+	SearchHistoryFile string
+
+	// SearchSummaries adds saved weekly and monthly summaries to journal search results
+	SearchSummaries bool
+
+	Notes   services.NotesOptions   // How notes are created and moved
+	Journal services.JournalOptions // How journal entries are named and started
+	Preview services.PreviewOptions // How previews are rendered
+	Images  utils.ImageOptions      // How pasted images are saved
+}
+
+// DefaultOptions returns the options used without a config file
+func DefaultOptions() Options {
+	return Options{Notes: services.DefaultNotesOptions()}.withDefaults()
+}
+
+// OptionsFromConfig returns the options cfg sets
+func OptionsFromConfig(cfg *config.Config) Options {
+	return Options{
+		Keys: KeyMap{
+			Up:   cfg.UpKeys,
+			Prev: cfg.PrevKeys,
+			Next: cfg.NextKeys,
+			Open: cfg.OpenKeys,
+			Quit: cfg.QuitKeys,
+		},
+		EditorKeymap:            EditorKeymap(cfg.EditorKeymap),
+		CtrlC:                   CtrlCBehavior(cfg.CtrlC),
+		PersistUndo:             cfg.PersistUndo,
+		JumpToLatestTimeSection: cfg.JournalJumpToLatest,
+		NewNoteCancel:           NewNoteCancelDestination(cfg.NewNoteCancel),
+		IdleLock:                time.Duration(cfg.IdleLockMinutes) * time.Minute,
+		NotesRefreshOnFocus:     cfg.NotesRefreshOnFocus,
+		NotesRefreshInterval:    time.Duration(cfg.NotesRefreshInterval) * time.Second,
+		QuickDelete:             cfg.NotesQuickDelete,
+		SearchHistoryFile:       filepath.Join(cfg.DataDir, "search_history"),
+		SearchSummaries:         cfg.SearchSummaries,
+		Notes:                   services.NotesOptionsFromConfig(cfg),
+		Journal:                 services.JournalOptionsFromConfig(cfg),
+		Preview:                 services.PreviewOptionsFromConfig(cfg),
+		Images: utils.ImageOptions{
+			JPEGQuality: cfg.ImageJPEGQuality,
+			MaxWidth:    cfg.MaxImageWidth,
+		},
+	}.withDefaults()
+}
+
+// withDefaults replaces empty and unknown settings with their defaults
+func (o Options) withDefaults() Options {
+	o.Keys = o.Keys.withDefaults()
+	if o.EditorKeymap != KeymapEmacs {
+		o.EditorKeymap = KeymapVim
+	}
+	if o.CtrlC != CtrlCQuit {
+		o.CtrlC = CtrlCConfirm
+	}
+	if o.NewNoteCancel != CancelToDashboard {
+		o.NewNoteCancel = CancelToBrowser
+	}
+	o.IdleLock = max(o.IdleLock, 0)
+	if o.NotesRefreshInterval > 0 && o.NotesRefreshInterval < MinAutoRefreshGap {
+		o.NotesRefreshInterval = MinAutoRefreshGap
+	}
+	o.NotesRefreshInterval = max(o.NotesRefreshInterval, 0)
+	return o
+}
@@ -0,0 +1,85 @@
+package tui
+
+import "github.com/charmbracelet/lipgloss"
+
+// DefaultNotesPageSize is how many entries the notes list shows per page before
+// the window size is known
+const DefaultNotesPageSize = 50
+
+// notesListChrome is how many lines the notes browser draws around its list: the
+// title, the path line, the page line and the gap above the status and help
+const notesListChrome = 9
+
+// pageSize returns how many notes list entries fit the window around the title,
+// filter bar, page line, status and help
+func (m NotesBrowserModel) pageSize() int {
+	if m.height <= 0 {
+		return DefaultNotesPageSize
+	}
+
+	used := notesListChrome
+	switch m.filterMode {
+	case FilterSearch:
+		used += 4 // Bordered search bar and the gap after it
+	case FilterTag:
+		used += 2
+	}
+	if m.undoPath != "" {
+		used++
+	}
+	if m.statusMsg != "" {
+		used++
+	}
+
+	// The padded help line wraps to the window width
+	style := helpStyle
+	if m.width > 0 {
+		style = style.Width(m.width)
+	}
+	used += lipgloss.Height(style.Render(notesBrowserHelp(m.opts.Keys, m.dirSort.String(), m.noteSort.String())))
+
+	return max(m.height-used, 1)
+}
+
+// pageWindow returns the [start, end) range of the page of total items that
+// contains cursor, so only one page of a long list is rendered
+func pageWindow(cursor, total, pageSize int) (int, int) {
+	if total <= 0 || pageSize < 1 {
+		return 0, total
+	}
+
+	start := (cursor / pageSize) * pageSize
+	if start >= total {
+		start = ((total - 1) / pageSize) * pageSize
+	}
+	end := start + pageSize
+	if end > total {
+		end = total
+	}
+	return start, end
+}
+
+// pageMove returns the cursor after moving delta pages (negative for up),
+// clamped to the list
+func pageMove(cursor, total, pageSize, delta int) int {
+	if total <= 0 {
+		return 0
+	}
+
+	cursor += delta * pageSize
+	if cursor < 0 {
+		cursor = 0
+	}
+	if cursor > total-1 {
+		cursor = total - 1
+	}
+	return cursor
+}
+
+// pageCount returns how many pages total items span
+func pageCount(total, pageSize int) int {
+	if total <= 0 || pageSize < 1 {
+		return 1
+	}
+	return (total + pageSize - 1) / pageSize
+}
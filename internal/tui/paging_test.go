@@ -0,0 +1,108 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/redjax/notetkr/internal/services"
+)
+
+func TestPageNavigationMath(t *testing.T) {
+	const size, total = 10, 25
+
+	tests := []struct {
+		cursor, start, end int
+	}{
+		{0, 0, 10},
+		{9, 0, 10},
+		{10, 10, 20},
+		{24, 20, 25},
+	}
+	for _, tt := range tests {
+		start, end := pageWindow(tt.cursor, total, size)
+		if start != tt.start || end != tt.end {
+			t.Errorf("pageWindow(%d) = [%d, %d), want [%d, %d)", tt.cursor, start, end, tt.start, tt.end)
+		}
+	}
+
+	if got := pageMove(3, total, size, 1); got != 13 {
+		t.Errorf("page down from 3 = %d, want 13", got)
+	}
+	if got := pageMove(20, total, size, 1); got != 24 {
+		t.Errorf("page down past the end = %d, want last item 24", got)
+	}
+	if got := pageMove(5, total, size, -1); got != 0 {
+		t.Errorf("page up past the start = %d, want 0", got)
+	}
+	if got := pageCount(total, size); got != 3 {
+		t.Errorf("pageCount = %d, want 3", got)
+	}
+}
+
+func TestFlatNotesListPagesToWindowHeight(t *testing.T) {
+	notesDir := t.TempDir()
+	for i := 0; i < 40; i++ {
+		dir := filepath.Join(notesDir, "work")
+		if i%2 == 1 {
+			dir = filepath.Join(notesDir, "home", "todo")
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		name := filepath.Join(dir, fmt.Sprintf("note-%02d.md", i))
+		if err := os.WriteFile(name, []byte("no heading\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// f lists the notes of every category together
+	m := NewNotesBrowser(services.NewNotesService(notesDir), DefaultOptions(), 80, 24)
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	m = updated.(NotesBrowserModel)
+	if len(m.directories) != 0 || len(m.filteredNotes) != 40 {
+		t.Fatalf("flat list has %d directories and %d notes, want 0 and 40", len(m.directories), len(m.filteredNotes))
+	}
+
+	// A page is as many notes as fit the window, and the view fits it too
+	size := m.pageSize()
+	if size < 1 || size >= 24 {
+		t.Fatalf("page size = %d for a 24-line window", size)
+	}
+	view := m.View()
+	if got := strings.Count(view, "note-"); got != size {
+		t.Errorf("view rendered %d notes, want one page of %d", got, size)
+	}
+	if got := lipgloss.Height(view); got != 24 {
+		t.Errorf("view is %d lines tall, want the window's 24", got)
+	}
+	if !strings.Contains(view, "home/todo/note-") {
+		t.Error("flat list should show each note's category")
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+	m = updated.(NotesBrowserModel)
+	if m.cursor != size {
+		t.Errorf("cursor after pgdown = %d, want %d", m.cursor, size)
+	}
+	if want := fmt.Sprintf("page 2/%d", pageCount(40, size)); !strings.Contains(m.View(), want) {
+		t.Errorf("view should show %s", want)
+	}
+
+	// A taller window fits more per page
+	updated, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	m = updated.(NotesBrowserModel)
+	if got := m.pageSize(); got != size+16 {
+		t.Errorf("page size at 40 lines = %d, want %d", got, size+16)
+	}
+
+	// Going back leaves the flat list
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m = updated.(NotesBrowserModel); m.flatView || len(m.directories) != 2 {
+		t.Errorf("esc should return to the category list, got flat=%v dirs=%v", m.flatView, m.directories)
+	}
+}
@@ -0,0 +1,107 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/redjax/notetkr/internal/services"
+)
+
+// QuickDeleteUndoFor is how long the undo toast stays up after a quick delete
+const QuickDeleteUndoFor = 5 * time.Second
+
+// trashedNote is a quick-deleted note, kept in memory until its undo toast expires
+type trashedNote struct {
+	content string
+	undo    []byte // The note's saved undo history, nil if it had none
+	modTime time.Time
+	id      int // The undo toast offering it back
+}
+
+// noteTrash holds the quick-deleted notes that can still be restored, by path. The
+// app owns it and shares it with every notes browser it opens, so a toast that
+// expires while another view is showing still empties it.
+type noteTrash struct {
+	notes  map[string]trashedNote
+	lastID int // Numbers undo toasts, so an expiring toast doesn't hide a newer one
+}
+
+// newNoteTrash creates an empty trash
+func newNoteTrash() *noteTrash {
+	return &noteTrash{notes: make(map[string]trashedNote)}
+}
+
+// undoExpiredMsg is sent when the undo toast with the given id times out
+type undoExpiredMsg struct {
+	id int
+}
+
+// add deletes a note, keeping its content, undo history and modification time in
+// the trash. Returns the id of the undo toast that can restore it.
+func (t *noteTrash) add(notesService *services.NotesService, path string) (int, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+	content, err := notesService.ReadNote(path)
+	if err != nil {
+		return 0, err
+	}
+	undo, err := os.ReadFile(services.UndoHistoryPath(path))
+	if err != nil && !os.IsNotExist(err) {
+		return 0, err
+	}
+	if err := notesService.DeleteNote(path); err != nil {
+		return 0, err
+	}
+	t.lastID++
+	t.notes[path] = trashedNote{content: content, undo: undo, modTime: info.ModTime(), id: t.lastID}
+	return t.lastID, nil
+}
+
+// restore writes a trashed note (and its undo history) back where it was, with its
+// old modification time, and takes it out of the trash
+func (t *noteTrash) restore(notesService *services.NotesService, path string) error {
+	note, ok := t.notes[path]
+	if !ok {
+		return fmt.Errorf("nothing to undo")
+	}
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("can't restore, a note now exists at %s", path)
+	}
+	if err := notesService.WriteNote(path, note.content); err != nil {
+		return err
+	}
+	if note.undo != nil {
+		if err := os.WriteFile(services.UndoHistoryPath(path), note.undo, 0644); err != nil {
+			return err
+		}
+	}
+	delete(t.notes, path)
+	return os.Chtimes(path, note.modTime, note.modTime)
+}
+
+// expire drops the note the undo toast id offered, making its delete final. A note
+// deleted again since then belongs to a newer toast and is kept.
+func (t *noteTrash) expire(id int) {
+	for path, note := range t.notes {
+		if note.id == id {
+			delete(t.notes, path)
+		}
+	}
+}
+
+// offerUndo shows the undo toast id for a trashed note and starts its timer. The
+// note the toast offered before can no longer be restored.
+func (m *NotesBrowserModel) offerUndo(path string, id int) tea.Cmd {
+	if m.undoPath != "" {
+		m.trash.expire(m.undoID)
+	}
+	m.undoPath = path
+	m.undoID = id
+	return tea.Tick(QuickDeleteUndoFor, func(time.Time) tea.Msg {
+		return undoExpiredMsg{id: id}
+	})
+}
@@ -0,0 +1,115 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/redjax/notetkr/internal/services"
+)
+
+// recentNotesLimit is how many notes the dashboard's Recent Notes list shows
+const recentNotesLimit = 10
+
+// RecentNotesModel lists the most recently modified notes from every category, for
+// jumping straight back into one from the dashboard
+type RecentNotesModel struct {
+	notesService *services.NotesService
+	opts         Options
+	notes        []services.Note
+	cursor       int
+	width        int
+	height       int
+	err          error
+}
+
+func NewRecentNotes(notesService *services.NotesService, opts Options, width, height int) RecentNotesModel {
+	m := RecentNotesModel{
+		notesService: notesService,
+		opts:         opts,
+		width:        width,
+		height:       height,
+	}
+	m.notes, m.err = notesService.ListRecentNotes(recentNotesLimit)
+	return m
+}
+
+func (m RecentNotesModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m RecentNotesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		switch m.opts.Keys.Resolve(msg.String()) {
+		case ActionQuit, "ctrl+c":
+			return m, tea.Quit
+
+		case ActionUp:
+			return m, func() tea.Msg {
+				return BackToDashboardMsg{}
+			}
+
+		case ActionPrev:
+			if m.cursor > 0 {
+				m.cursor--
+			}
+
+		case ActionNext:
+			if m.cursor < len(m.notes)-1 {
+				m.cursor++
+			}
+
+		case ActionOpen:
+			if len(m.notes) == 0 {
+				return m, nil
+			}
+			filePath := m.notes[m.cursor].FilePath
+			return m, func() tea.Msg {
+				return OpenNoteMsg{filePath: filePath}
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func (m RecentNotesModel) View() string {
+	if m.err != nil {
+		return fmt.Sprintf("\n  Error: %v\n\n  Press 'esc' to go back\n", m.err)
+	}
+
+	s := notesBrowserTitleStyle.Render("🕘 Recent Notes") + "\n\n"
+
+	if len(m.notes) == 0 {
+		s += "  No notes yet.\n\n"
+	} else {
+		for i, note := range m.notes {
+			line := note.DisplayName() + " " + noteTagStyle.Render(note.ModTime.Format("2006-01-02 15:04"))
+			if category := filepath.Dir(note.Name); category != "." {
+				line += " " + statusStyle.Render("📁 "+category)
+			}
+			if i == m.cursor {
+				s += noteSelectedStyle.Render("▶ "+line) + "\n"
+			} else {
+				s += "  " + line + "\n"
+			}
+		}
+		s += "\n"
+	}
+
+	s += helpStyle.Render(renderHelp(
+		helpEntry{m.opts.Keys.HelpKeys(ActionPrev), "up"},
+		helpEntry{m.opts.Keys.HelpKeys(ActionNext), "down"},
+		helpEntry{m.opts.Keys.HelpKeys(ActionOpen), "open"},
+		helpEntry{m.opts.Keys.HelpKeys(ActionUp), "back"},
+		helpEntry{m.opts.Keys.HelpKeys(ActionQuit), "quit"},
+	))
+
+	return s
+}
@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRecentNotesOpensSelectedNote(t *testing.T) {
+	notesDir := t.TempDir()
+	now := time.Now()
+	for i, rel := range []string{"work/today.md", "yesterday.md"} {
+		path := filepath.Join(notesDir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("# "+rel+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		modTime := now.Add(-time.Duration(i) * 24 * time.Hour)
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The dashboard entry opens the list
+	app := NewNotesBrowserApp(testConfig(t, t.TempDir(), notesDir))
+	result, _ := app.Update(MenuSelectionMsg{Selection: "recent-notes"})
+	m, ok := result.(AppModel).currentView.(RecentNotesModel)
+	if !ok {
+		t.Fatalf("recent-notes selection opened %T, want RecentNotesModel", result.(AppModel).currentView)
+	}
+	if len(m.notes) != 2 || filepath.Base(m.notes[0].FilePath) != "today.md" {
+		t.Fatalf("recent notes = %v, want today.md first", m.notes)
+	}
+
+	// Selecting a note opens it in the editor
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd := updated.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("selecting a note should open it")
+	}
+	msg, ok := cmd().(OpenNoteMsg)
+	if !ok || msg.filePath != filepath.Join(notesDir, "yesterday.md") {
+		t.Errorf("selection emitted %#v, want OpenNoteMsg for yesterday.md", msg)
+	}
+
+	// Going back returns to the dashboard
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd == nil {
+		t.Fatal("esc should go back")
+	}
+	if _, ok := cmd().(BackToDashboardMsg); !ok {
+		t.Error("esc should return to the dashboard")
+	}
+}
@@ -3,6 +3,7 @@ package tui
 import (
 	"fmt"
 	"os"
+	"sort"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -13,6 +14,8 @@ type SavedSummariesBrowserModel struct {
 	journalService *services.JournalService
 	summaries      []services.WeeklySummaryInfo
 	cursor         int
+	oldestFirst    bool // List the oldest summaries first instead of the newest
+	groupByYear    bool // Show a heading before each year's summaries
 	width          int
 	height         int
 	err            error
@@ -31,12 +34,17 @@ var (
 					Foreground(lipgloss.Color("170")).
 					Bold(true).
 					Underline(true)
+
+	savedSummariesYearStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(lipgloss.Color("205"))
 )
 
 func NewSavedSummariesBrowser(journalService *services.JournalService) SavedSummariesBrowserModel {
 	m := SavedSummariesBrowserModel{
 		journalService: journalService,
 		cursor:         0,
+		groupByYear:    true,
 	}
 	m.loadSummaries()
 	return m
@@ -56,6 +64,54 @@ func (m *SavedSummariesBrowserModel) loadSummaries() {
 		return
 	}
 	m.summaries = summaries
+	m.sortSummaries()
+}
+
+// sortSummaries orders the summaries by week, newest or oldest first
+func (m *SavedSummariesBrowserModel) sortSummaries() {
+	sort.SliceStable(m.summaries, func(a, b int) bool {
+		if m.oldestFirst {
+			return m.summaries[a].WeekStart.Before(m.summaries[b].WeekStart)
+		}
+		return m.summaries[a].WeekStart.After(m.summaries[b].WeekStart)
+	})
+}
+
+// toggleOrder flips between newest and oldest first, keeping the same summary selected
+func (m *SavedSummariesBrowserModel) toggleOrder() {
+	var selected string
+	if m.cursor < len(m.summaries) {
+		selected = m.summaries[m.cursor].FilePath
+	}
+
+	m.oldestFirst = !m.oldestFirst
+	m.sortSummaries()
+
+	for i, summary := range m.summaries {
+		if summary.FilePath == selected {
+			m.cursor = i
+			break
+		}
+	}
+}
+
+// summaryRow is a line of the summaries list: a year heading, or the summary at index
+type summaryRow struct {
+	year  int
+	index int // -1 for a year heading
+}
+
+// summaryRows lays out the list, with a heading before each year when grouping
+func (m SavedSummariesBrowserModel) summaryRows() []summaryRow {
+	var rows []summaryRow
+	for i, summary := range m.summaries {
+		year := summary.WeekStart.Year()
+		if m.groupByYear && (i == 0 || m.summaries[i-1].WeekStart.Year() != year) {
+			rows = append(rows, summaryRow{year: year, index: -1})
+		}
+		rows = append(rows, summaryRow{year: year, index: i})
+	}
+	return rows
 }
 
 func (m SavedSummariesBrowserModel) Init() tea.Cmd {
@@ -95,6 +151,14 @@ func (m SavedSummariesBrowserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.cursor++
 			}
 
+		case "o":
+			// Toggle newest/oldest first
+			m.toggleOrder()
+
+		case "g":
+			// Toggle the year headings
+			m.groupByYear = !m.groupByYear
+
 		case "enter", "l", "right", " ":
 			if len(m.summaries) == 0 {
 				return m, nil
@@ -179,13 +243,22 @@ func (m SavedSummariesBrowserModel) View() string {
 	}
 
 	// Display summaries
-	for i, summary := range m.summaries {
+	for i, row := range m.summaryRows() {
+		if row.index < 0 {
+			if i > 0 {
+				s += "\n"
+			}
+			s += savedSummariesYearStyle.Render(fmt.Sprint(row.year)) + "\n"
+			continue
+		}
+
+		summary := m.summaries[row.index]
 		label := fmt.Sprintf("%s - %s",
 			summary.WeekStart.Format("Jan 2, 2006"),
 			summary.WeekEnd.Format("Jan 2, 2006"))
 
 		cursor := "  "
-		if m.cursor == i {
+		if m.cursor == row.index {
 			cursor = "→ "
 			s += cursor + savedSummariesSelectedStyle.Render(label) + "\n"
 		} else {
@@ -193,7 +266,11 @@ func (m SavedSummariesBrowserModel) View() string {
 		}
 	}
 
-	s += "\n" + helpStyle.Render("↑/k ↓/j: navigate • enter/l: view • d: delete • esc/h: back • q: quit")
+	order := "oldest first"
+	if m.oldestFirst {
+		order = "newest first"
+	}
+	s += "\n" + helpStyle.Render(fmt.Sprintf("↑/k ↓/j: navigate • enter/l: view • d: delete • o: %s • g: group by year • esc/h: back • q: quit", order))
 
 	// Center the content and fill the screen
 	if m.width > 0 && m.height > 0 {
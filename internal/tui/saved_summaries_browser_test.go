@@ -0,0 +1,63 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/redjax/notetkr/internal/services"
+)
+
+func TestSavedSummariesGroupedByYear(t *testing.T) {
+	journalService := services.NewJournalService(t.TempDir(), time.Sunday)
+	weeks := []time.Time{
+		time.Date(2024, time.December, 29, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, time.March, 5, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, time.January, 5, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, time.June, 2, 0, 0, 0, 0, time.UTC),
+	}
+	for _, week := range weeks {
+		if err := journalService.SaveWeeklySummary(week, "# Week\n"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := NewSavedSummariesBrowser(journalService)
+
+	// Newest first, with a heading before each year; a heading row has index -1
+	want := []summaryRow{
+		{2025, -1}, {2025, 0},
+		{2024, -1}, {2024, 1}, {2024, 2},
+		{2023, -1}, {2023, 3},
+	}
+	if got := m.summaryRows(); !reflect.DeepEqual(got, want) {
+		t.Errorf("rows = %v, want %v", got, want)
+	}
+	if got := m.summaries[1].WeekStart; !got.Equal(weeks[0]) {
+		t.Errorf("second summary starts %s, want %s", got, weeks[0])
+	}
+
+	// Oldest first keeps the selection on the same summary
+	m.cursor = 1
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	m = updated.(SavedSummariesBrowserModel)
+	want = []summaryRow{
+		{2023, -1}, {2023, 0},
+		{2024, -1}, {2024, 1}, {2024, 2},
+		{2025, -1}, {2025, 3},
+	}
+	if got := m.summaryRows(); !reflect.DeepEqual(got, want) {
+		t.Errorf("oldest first rows = %v, want %v", got, want)
+	}
+	if got := m.summaries[m.cursor].WeekStart; !got.Equal(weeks[0]) {
+		t.Errorf("selected summary starts %s after reordering, want %s", got, weeks[0])
+	}
+
+	// Without grouping there are no headings
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	m = updated.(SavedSummariesBrowserModel)
+	if got := m.summaryRows(); len(got) != len(weeks) || got[0].index != 0 {
+		t.Errorf("ungrouped rows = %v, want one row per summary", got)
+	}
+}
@@ -0,0 +1,95 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/redjax/notetkr/internal/services"
+)
+
+// NewScratchEditor creates a notes editor for a scratchpad: its text only lives in
+// memory until it's saved as a note with a name, so it can be thrown away freely
+func NewScratchEditor(notesService *services.NotesService, opts Options) NotesEditorModel {
+	m := NewNotesEditor(notesService, opts, "")
+	m.scratch = true
+	m.noteName = "Scratchpad (not saved)"
+	return m
+}
+
+// unsavedScratch reports whether this is a scratchpad that hasn't been saved as a
+// note yet, so it has no file to load, save or attach images next to
+func (m *NotesEditorModel) unsavedScratch() bool {
+	return m.scratch && m.filePath == ""
+}
+
+// startSaveAs asks for the name to save a scratchpad's text under
+func (m *NotesEditorModel) startSaveAs() {
+	ti := textinput.New()
+	ti.Prompt = "Save as: "
+	ti.Placeholder = "note name (e.g. ideas or work/ideas)"
+	ti.CharLimit = 200
+	ti.Width = 60
+	ti.Focus()
+
+	m.saveAsInput = ti
+	m.savingAs = true
+}
+
+// updateSaveAs handles keys while the save-as prompt is showing. Saving turns the
+// scratchpad into a regular note, or leaves the editor if it was closing.
+func (m NotesEditorModel) updateSaveAs(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.savingAs = false
+		m.quitAfterSave = false
+		m.quitToShell = false
+		return m, nil
+
+	case "enter":
+		name := strings.TrimSpace(m.saveAsInput.Value())
+		if name == "" {
+			return m, nil
+		}
+
+		// A name like "work/ideas" saves into that category
+		dir, base := filepath.Split(filepath.FromSlash(name))
+		filePath, err := m.notesService.CreateNoteWithBody(base, dir, m.content())
+		if err != nil {
+			m.saveMsg = fmt.Sprintf("❌ %v", err)
+			return m, nil
+		}
+
+		m.savingAs = false
+		m.filePath = filePath
+		m.noteName = name
+		if m.quitAfterSave {
+			if m.quitToShell {
+				return m, tea.Quit
+			}
+			return m, m.back()
+		}
+		m.saveMsg = "✓ Saved as " + name
+		// Load the note back with the frontmatter it was given
+		return m, m.loadNote
+	}
+
+	var cmd tea.Cmd
+	m.saveAsInput, cmd = m.saveAsInput.Update(msg)
+	return m, cmd
+}
+
+// back leaves the editor for the view it was opened from: the dashboard for a
+// scratchpad, the notes browser otherwise
+func (m NotesEditorModel) back() tea.Cmd {
+	if m.scratch {
+		return func() tea.Msg {
+			return BackToDashboardMsg{}
+		}
+	}
+	return func() tea.Msg {
+		return BackToNotesBrowserMsg{}
+	}
+}
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"fmt"
+	"os"
 	"sort"
 	"strings"
 	"time"
@@ -43,16 +44,18 @@ func (f SearchFilterType) String() string {
 }
 
 type SearchResult struct {
-	Type     string // "note" or "journal"
+	Type     string // "note", "journal", or "summary"
 	Name     string
 	FilePath string
-	Date     string // For journals
-	Preview  string
+	Date     string           // For journals and summaries
+	Preview  string           // Matched line, or a "[matched in ...]" label
+	Match    services.Snippet // Matched line with the match's position, if the content matched
 }
 
 type SearchBrowserModel struct {
 	journalService *services.JournalService
 	notesService   *services.NotesService
+	opts           Options
 	searchInput    textinput.Model
 	results        []SearchResult
 	cursor         int
@@ -64,6 +67,20 @@ type SearchBrowserModel struct {
 	filterType     SearchFilterType
 	showingFilters bool
 	filterCursor   int
+	fullFileSearch bool // Match note content including frontmatter
+	regexSearch    bool // Treat the query as a regular expression
+	caseSensitive  bool // Match the query's case exactly
+	searchErr      error
+	history        *services.SearchHistory
+	historyIndex   int    // Position while browsing history with up/down, -1 when not browsing
+	historyDraft   string // What was typed before browsing history
+}
+
+// loadSearchHistory reads the queries saved in path. History is a convenience, so
+// a file that can't be read just starts an empty history.
+func loadSearchHistory(path string) *services.SearchHistory {
+	history, _ := services.LoadSearchHistory(path, services.DefaultSearchHistorySize)
+	return history
 }
 
 var (
@@ -92,15 +109,37 @@ var (
 				Foreground(lipgloss.Color("212")).
 				Bold(true)
 
+	searchTypeSummaryStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("214")).
+				Bold(true)
+
 	searchPreviewStyle = lipgloss.NewStyle().
 				Foreground(lipgloss.Color("241")).
 				Italic(true)
 
 	searchHelpStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("241"))
+
+	searchMatchStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("226")).
+				Bold(true)
 )
 
-func NewSearchBrowser(journalService *services.JournalService, notesService *services.NotesService, width, height int) SearchBrowserModel {
+// renderSearchPreview renders a result's matched line with the match highlighted,
+// or its plain preview label
+func renderSearchPreview(result SearchResult) string {
+	if result.Match.Text != "" {
+		return searchPreviewStyle.Render(result.Match.Before()) +
+			searchMatchStyle.Render(result.Match.Match()) +
+			searchPreviewStyle.Render(result.Match.After())
+	}
+	if result.Preview != "" {
+		return searchPreviewStyle.Render(result.Preview)
+	}
+	return ""
+}
+
+func NewSearchBrowser(journalService *services.JournalService, notesService *services.NotesService, opts Options, width, height int) SearchBrowserModel {
 	searchInput := textinput.New()
 	searchInput.Placeholder = "Search notes and journals..."
 	searchInput.CharLimit = 100
@@ -109,6 +148,7 @@ func NewSearchBrowser(journalService *services.JournalService, notesService *ser
 	return SearchBrowserModel{
 		journalService: journalService,
 		notesService:   notesService,
+		opts:           opts,
 		searchInput:    searchInput,
 		width:          width,
 		height:         height,
@@ -117,11 +157,13 @@ func NewSearchBrowser(journalService *services.JournalService, notesService *ser
 		filterType:     FilterAll,
 		showingFilters: false,
 		filterCursor:   0,
+		history:        loadSearchHistory(opts.SearchHistoryFile),
+		historyIndex:   -1,
 	}
 }
 
 // NewSearchBrowserWithQuery creates a new search browser with an initial query
-func NewSearchBrowserWithQuery(journalService *services.JournalService, notesService *services.NotesService, width, height int, query string) SearchBrowserModel {
+func NewSearchBrowserWithQuery(journalService *services.JournalService, notesService *services.NotesService, opts Options, width, height int, query string) SearchBrowserModel {
 	searchInput := textinput.New()
 	searchInput.Placeholder = "Search notes and journals..."
 	searchInput.CharLimit = 100
@@ -137,6 +179,7 @@ func NewSearchBrowserWithQuery(journalService *services.JournalService, notesSer
 	m := SearchBrowserModel{
 		journalService: journalService,
 		notesService:   notesService,
+		opts:           opts,
 		searchInput:    searchInput,
 		width:          width,
 		height:         height,
@@ -145,11 +188,45 @@ func NewSearchBrowserWithQuery(journalService *services.JournalService, notesSer
 		filterType:     FilterAll,
 		showingFilters: false,
 		filterCursor:   0,
+		history:        loadSearchHistory(opts.SearchHistoryFile),
+		historyIndex:   -1,
+	}
+	if query != "" {
+		_ = m.history.Add(query)
 	}
 
 	return m
 }
 
+// browseHistory moves through past queries: up (-1) goes back in time, wrapping
+// from the oldest to the newest, and down (1) goes forward, ending at the typed draft.
+// It reports whether the key was used.
+func (m *SearchBrowserModel) browseHistory(direction int) bool {
+	queries := m.history.Queries()
+	if len(queries) == 0 || (direction > 0 && m.historyIndex < 0) {
+		return false
+	}
+
+	switch {
+	case m.historyIndex < 0:
+		m.historyDraft = m.searchInput.Value()
+		m.historyIndex = len(queries) - 1
+	case direction < 0 && m.historyIndex == 0:
+		m.historyIndex = len(queries) - 1
+	default:
+		m.historyIndex += direction
+	}
+
+	if m.historyIndex >= len(queries) {
+		m.historyIndex = -1
+		m.searchInput.SetValue(m.historyDraft)
+	} else {
+		m.searchInput.SetValue(queries[m.historyIndex])
+	}
+	m.searchInput.CursorEnd()
+	return true
+}
+
 func (m SearchBrowserModel) Init() tea.Cmd {
 	// If we have a query, perform search immediately
 	if m.searchInput.Value() != "" && m.searching {
@@ -166,9 +243,19 @@ func (m *SearchBrowserModel) performSearch() tea.Msg {
 
 	var results []SearchResult
 
+	opts := services.SearchOptions{
+		FullFile:      m.fullFileSearch,
+		CaseSensitive: m.caseSensitive,
+		Regex:         m.regexSearch,
+	}
+	match, err := services.NewSearchMatcher(query, opts)
+	if err != nil {
+		return SearchCompletedMsg{results: []SearchResult{}, err: err}
+	}
+
 	// Search notes based on filter type
 	if m.filterType == FilterAll || m.filterType == FilterNotes || m.filterType == FilterTags || m.filterType == FilterKeywords || m.filterType == FilterContent {
-		notes, err := m.notesService.SearchNotes(query)
+		notes, err := m.notesService.SearchNotesWithOpts(query, opts)
 		if err == nil {
 			for _, note := range notes {
 				// Apply filter
@@ -179,7 +266,7 @@ func (m *SearchBrowserModel) performSearch() tea.Msg {
 				case FilterTags:
 					// Only include if query matches tags
 					for _, tag := range note.Tags {
-						if strings.Contains(strings.ToLower(tag), strings.ToLower(query)) {
+						if match(tag) {
 							shouldInclude = true
 							break
 						}
@@ -187,7 +274,7 @@ func (m *SearchBrowserModel) performSearch() tea.Msg {
 				case FilterKeywords:
 					// Only include if query matches keywords
 					for _, keyword := range note.Keywords {
-						if strings.Contains(strings.ToLower(keyword), strings.ToLower(query)) {
+						if match(keyword) {
 							shouldInclude = true
 							break
 						}
@@ -198,12 +285,18 @@ func (m *SearchBrowserModel) performSearch() tea.Msg {
 				}
 
 				if shouldInclude {
-					results = append(results, SearchResult{
+					result := SearchResult{
 						Type:     "note",
-						Name:     note.Name,
+						Name:     note.DisplayName(),
 						FilePath: note.FilePath,
-						Preview:  strings.Join(note.Tags, ", "),
-					})
+					}
+					if snippet, ok := m.noteMatchSnippet(note.FilePath, query, opts); ok {
+						result.Match = snippet
+						result.Preview = snippet.Text
+					} else {
+						result.Preview = matchedFieldLabel(note, match)
+					}
+					results = append(results, result)
 				}
 			}
 		}
@@ -211,7 +304,7 @@ func (m *SearchBrowserModel) performSearch() tea.Msg {
 
 	// Search journals based on filter type
 	if m.filterType == FilterAll || m.filterType == FilterJournals || m.filterType == FilterContent {
-		journals, err := m.journalService.SearchJournals(query)
+		journals, err := m.journalService.SearchJournals(query, opts)
 		if err == nil {
 			for _, journal := range journals {
 				results = append(results, SearchResult{
@@ -220,25 +313,72 @@ func (m *SearchBrowserModel) performSearch() tea.Msg {
 					FilePath: journal.FilePath,
 					Date:     journal.Date.Format("2006-01-02"),
 					Preview:  journal.Preview,
+					Match:    journal.Match,
 				})
 			}
 		}
+
+		if m.opts.SearchSummaries {
+			summaries, err := m.journalService.SearchSummaries(query, opts)
+			if err == nil {
+				for _, summary := range summaries {
+					result := SearchResult{
+						Type:     "summary",
+						Name:     summary.Title,
+						FilePath: summary.FilePath,
+						Preview:  summary.Preview,
+						Match:    summary.Match,
+					}
+					if !summary.Date.IsZero() {
+						result.Date = summary.Date.Format("2006-01-02")
+					}
+					results = append(results, result)
+				}
+			}
+		}
 	}
 
-	// Sort results: journals first (by date desc), then notes (alphabetically)
+	// Sort results: journals first, then summaries (both by date desc), then notes (alphabetically)
+	typeOrder := map[string]int{"journal": 0, "summary": 1, "note": 2}
 	sort.Slice(results, func(i, j int) bool {
 		if results[i].Type == results[j].Type {
-			if results[i].Type == "journal" {
+			if results[i].Type != "note" && results[i].Date != results[j].Date {
 				return results[i].Date > results[j].Date
 			}
 			return results[i].Name < results[j].Name
 		}
-		return results[i].Type == "journal"
+		return typeOrder[results[i].Type] < typeOrder[results[j].Type]
 	})
 
 	return SearchCompletedMsg{results: results}
 }
 
+// noteMatchSnippet finds the first line of a note's searched content that matches the query
+func (m *SearchBrowserModel) noteMatchSnippet(filePath, query string, opts services.SearchOptions) (services.Snippet, bool) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return services.Snippet{}, false
+	}
+	text := string(content)
+	if !opts.FullFile {
+		text = services.StripFrontMatter(text)
+	}
+	return services.FindMatchSnippet(text, query, opts, services.SnippetWidth)
+}
+
+// matchedFieldLabel explains why a note matched when its content didn't
+func matchedFieldLabel(note services.Note, match func(string) bool) string {
+	if match(note.Name) || match(note.Title) {
+		return "[matched in filename]"
+	}
+	for _, tag := range note.Tags {
+		if match(tag) {
+			return "[matched in tags]"
+		}
+	}
+	return "[matched in frontmatter]"
+}
+
 func (m SearchBrowserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
@@ -250,6 +390,7 @@ func (m SearchBrowserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case SearchCompletedMsg:
 		m.results = msg.results
+		m.searchErr = msg.err
 		m.searching = false
 		m.hasSearched = true
 		m.cursor = 0
@@ -305,16 +446,25 @@ func (m SearchBrowserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.searching = true
 				m.hasSearched = false
 				m.searchInput.Blur()
+				m.historyIndex = -1
+				_ = m.history.Add(m.searchInput.Value())
 				return m, m.performSearch
 
+			case "up":
+				// Recall an earlier query
+				m.browseHistory(-1)
+				return m, nil
+
 			case "down":
-				// Move to results if we have any
-				if len(m.results) > 0 {
+				// Recall a later query, or move to results if we have any
+				if !m.browseHistory(1) && len(m.results) > 0 {
 					m.searchInput.Blur()
 				}
 				return m, nil
 
 			default:
+				// Typing ends history browsing
+				m.historyIndex = -1
 				// Update search input
 				m.searchInput, cmd = m.searchInput.Update(msg)
 				return m, cmd
@@ -337,6 +487,21 @@ func (m SearchBrowserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		// Toggle regex and case-sensitive matching
+		if msg.String() == "x" || msg.String() == "C" {
+			if msg.String() == "x" {
+				m.regexSearch = !m.regexSearch
+			} else {
+				m.caseSensitive = !m.caseSensitive
+			}
+			// Re-search if we already have a query
+			if m.hasSearched && m.searchInput.Value() != "" {
+				m.searching = true
+				return m, m.performSearch
+			}
+			return m, nil
+		}
+
 		switch msg.String() {
 		case "up", "k":
 			if m.cursor > 0 {
@@ -357,8 +522,19 @@ func (m SearchBrowserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if len(m.results) > 0 {
 				result := m.results[m.cursor]
 				if result.Type == "note" {
+					// Carry the search over so n/N step through its matches in the note
+					query := m.searchInput.Value()
+					opts := services.SearchOptions{
+						FullFile:      m.fullFileSearch,
+						CaseSensitive: m.caseSensitive,
+						Regex:         m.regexSearch,
+					}
+					return m, func() tea.Msg {
+						return OpenNoteMsg{filePath: result.FilePath, query: query, searchOpts: opts}
+					}
+				} else if result.Type == "summary" {
 					return m, func() tea.Msg {
-						return OpenNoteMsg{filePath: result.FilePath}
+						return OpenWeeklySummaryFileMsg{filePath: result.FilePath}
 					}
 				} else {
 					// Parse date and open journal
@@ -395,7 +571,14 @@ func (m SearchBrowserModel) View() string {
 	// Search input with filter indicator
 	b.WriteString(searchInputStyle.Render(m.searchInput.View()))
 	b.WriteString("  ")
-	filterIndicator := fmt.Sprintf("[Filter: %s]", m.filterType.String())
+	filterIndicator := fmt.Sprintf("[Filter: %s", m.filterType.String())
+	if m.regexSearch {
+		filterIndicator += " • regex"
+	}
+	if m.caseSensitive {
+		filterIndicator += " • case-sensitive"
+	}
+	filterIndicator += "]"
 	b.WriteString(searchTypeNoteStyle.Render(filterIndicator))
 	b.WriteString("\n\n")
 
@@ -423,6 +606,8 @@ func (m SearchBrowserModel) View() string {
 	// Status or results
 	if m.searching {
 		b.WriteString("Searching...\n")
+	} else if m.searchErr != nil {
+		b.WriteString(errorStyle.Render(fmt.Sprintf("❌ %v", m.searchErr)) + "\n")
 	} else if m.hasSearched {
 		if len(m.results) == 0 {
 			b.WriteString("No results found.\n")
@@ -470,19 +655,15 @@ func (m SearchBrowserModel) View() string {
 				if result.Type == "note" {
 					typeLabel := searchTypeNoteStyle.Render("[Note]")
 					resultLine = fmt.Sprintf("%s %s %s", cursor, typeLabel, result.Name)
-					if result.Preview != "" {
-						resultLine += " " + searchPreviewStyle.Render("("+result.Preview+")")
-					}
+				} else if result.Type == "summary" {
+					typeLabel := searchTypeSummaryStyle.Render("[Summary]")
+					resultLine = fmt.Sprintf("%s %s %s", cursor, typeLabel, result.Name)
 				} else {
 					typeLabel := searchTypeJournalStyle.Render("[Journal]")
 					resultLine = fmt.Sprintf("%s %s %s", cursor, typeLabel, result.Name)
-					if result.Preview != "" {
-						preview := result.Preview
-						if len(preview) > 60 {
-							preview = preview[:60] + "..."
-						}
-						resultLine += "\n    " + searchPreviewStyle.Render(preview)
-					}
+				}
+				if preview := renderSearchPreview(result); preview != "" {
+					resultLine += "\n    " + preview
 				}
 
 				if i == m.cursor && !m.searchInput.Focused() {
@@ -502,9 +683,9 @@ func (m SearchBrowserModel) View() string {
 	// Help text
 	var help string
 	if m.searchInput.Focused() {
-		help = "enter: search • down: results • esc: exit search box • f: filter (exit box first)"
+		help = "enter: search • ↑/↓: history • down: results • esc: exit search box • f: filter (exit box first)"
 	} else {
-		help = "↑/k: up • ↓/j: down • enter: open • /: edit search • f: filter • esc/q: back"
+		help = "↑/k: up • ↓/j: down • enter: open • /: edit search • f: filter • x: regex • C: case • esc/q: back"
 	}
 	b.WriteString(searchHelpStyle.Render(help))
 
@@ -522,6 +703,7 @@ func (m SearchBrowserModel) View() string {
 
 type SearchCompletedMsg struct {
 	results []SearchResult
+	err     error
 }
 
 // parseDate parses a date string in YYYY-MM-DD format
@@ -0,0 +1,122 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/redjax/notetkr/internal/services"
+)
+
+func TestSearchPreviewShowsMatchedLine(t *testing.T) {
+	notesDir := t.TempDir()
+	files := map[string]string{
+		"body.md":   "---\ntags: misc\n---\n# Weekly\n\nAgreed to move the launch to Friday\n",
+		"tagged.md": "---\ntags: launch\n---\n# Other\n\nnothing relevant here\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(notesDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := NewSearchBrowserWithQuery(services.NewJournalService(t.TempDir(), time.Sunday), services.NewNotesService(notesDir), DefaultOptions(), 80, 24, "launch")
+	msg := m.performSearch().(SearchCompletedMsg)
+
+	previews := make(map[string]SearchResult)
+	for _, result := range msg.results {
+		previews[filepath.Base(result.FilePath)] = result
+	}
+
+	body := previews["body.md"]
+	if body.Preview != "Agreed to move the launch to Friday" || body.Match.Match() != "launch" {
+		t.Errorf("content match preview = %q (match %q), want the matched line", body.Preview, body.Match.Match())
+	}
+
+	tagged := previews["tagged.md"]
+	if tagged.Preview != "[matched in tags]" || tagged.Match.Text != "" {
+		t.Errorf("tag-only match preview = %q, want [matched in tags]", tagged.Preview)
+	}
+}
+
+func TestSearchHistoryNavigation(t *testing.T) {
+	opts := DefaultOptions()
+	opts.SearchHistoryFile = filepath.Join(t.TempDir(), "search_history")
+
+	m := NewSearchBrowser(services.NewJournalService(t.TempDir(), time.Sunday), services.NewNotesService(t.TempDir()), opts, 80, 24)
+	for _, q := range []string{"first", "second", "first"} {
+		_ = m.history.Add(q)
+	}
+	// The repeated query is kept once, as the newest
+	if got := m.history.Queries(); len(got) != 2 || got[0] != "second" || got[1] != "first" {
+		t.Fatalf("history = %v, want [second first]", got)
+	}
+
+	m.searchInput.SetValue("draft")
+	press := func(key tea.KeyType) {
+		updated, _ := m.Update(tea.KeyMsg{Type: key})
+		m = updated.(SearchBrowserModel)
+	}
+
+	for _, step := range []struct {
+		key  tea.KeyType
+		want string
+	}{
+		{tea.KeyUp, "first"},
+		{tea.KeyUp, "second"},
+		{tea.KeyUp, "first"}, // wraps from the oldest back to the newest
+		{tea.KeyDown, "draft"},
+	} {
+		press(step.key)
+		if got := m.searchInput.Value(); got != step.want {
+			t.Fatalf("after %v input = %q, want %q", step.key, got, step.want)
+		}
+	}
+	if m.historyIndex != -1 {
+		t.Error("returning to the draft should stop browsing history")
+	}
+}
+
+func TestSearchIncludesSummaries(t *testing.T) {
+	journalDir := t.TempDir()
+	js := services.NewJournalService(journalDir, time.Sunday)
+	weekStart := time.Date(2025, 1, 5, 0, 0, 0, 0, time.Local)
+	if err := js.SaveWeeklySummary(weekStart, "# Week\n\n- shipped the roadmap\n"); err != nil {
+		t.Fatal(err)
+	}
+	summaryPath := js.GetWeeklySummaryPath(weekStart)
+
+	m := NewSearchBrowserWithQuery(js, services.NewNotesService(t.TempDir()), DefaultOptions(), 80, 24, "roadmap")
+	if msg := m.performSearch().(SearchCompletedMsg); len(msg.results) != 0 {
+		t.Fatalf("summaries searched with the setting off: %+v", msg.results)
+	}
+
+	m.opts.SearchSummaries = true
+	msg := m.performSearch().(SearchCompletedMsg)
+	if len(msg.results) != 1 {
+		t.Fatalf("got %d results, want the summary", len(msg.results))
+	}
+	result := msg.results[0]
+	if result.Type != "summary" || result.Name != "Week of January 5, 2025" || result.FilePath != summaryPath {
+		t.Errorf("summary result = %+v", result)
+	}
+
+	updated, _ := m.Update(msg)
+	m = updated.(SearchBrowserModel)
+	m.searchInput.Blur()
+	if view := m.View(); !strings.Contains(view, "[Summary]") {
+		t.Error("summary result not labeled in the view")
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter on a summary result returned no command")
+	}
+	open, ok := cmd().(OpenWeeklySummaryFileMsg)
+	if !ok || open.filePath != summaryPath {
+		t.Errorf("enter returned %#v, want OpenWeeklySummaryFileMsg for %s", open, summaryPath)
+	}
+}
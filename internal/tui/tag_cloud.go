@@ -0,0 +1,208 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/redjax/notetkr/internal/services"
+)
+
+// TagCloudModel is a read-only overview of every tag, most used first
+type TagCloudModel struct {
+	notesService *services.NotesService
+	opts         Options
+	tags         []services.TagCount
+	cursor       int
+	offset       int // Index of the first tag shown, scrolled to keep the cursor visible
+	width        int
+	height       int
+	err          error
+}
+
+// tagCloudBarWidth is the width of the bar drawn for the most used tag
+const tagCloudBarWidth = 20
+
+// tagCloudChrome is how many lines the tag cloud draws around its list: the padded
+// title, the gap after it and the gap above the help
+const tagCloudChrome = 5
+
+var (
+	tagCloudLargeStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("205")).
+				Bold(true)
+
+	tagCloudMediumStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("86"))
+
+	tagCloudSmallStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("241"))
+)
+
+// OpenTagCloudMsg opens the tag cloud view
+type OpenTagCloudMsg struct{}
+
+// FilterNotesByTagMsg opens the notes browser filtered by a tag
+type FilterNotesByTagMsg struct {
+	tag string
+}
+
+func NewTagCloud(notesService *services.NotesService, opts Options, width, height int) TagCloudModel {
+	m := TagCloudModel{
+		notesService: notesService,
+		opts:         opts,
+		width:        width,
+		height:       height,
+	}
+	m.loadTags()
+	return m
+}
+
+func (m *TagCloudModel) loadTags() {
+	tags, err := m.notesService.GetTagCounts()
+	if err != nil {
+		m.err = err
+		return
+	}
+	m.tags = tags
+}
+
+func (m TagCloudModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m TagCloudModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.scrollToCursor()
+		return m, nil
+
+	case tea.KeyMsg:
+		switch m.opts.Keys.Resolve(msg.String()) {
+		case ActionQuit, "ctrl+c":
+			return m, tea.Quit
+
+		case ActionUp:
+			return m, func() tea.Msg {
+				return BackToNotesBrowserMsg{}
+			}
+
+		case ActionPrev:
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			m.scrollToCursor()
+
+		case ActionNext:
+			if m.cursor < len(m.tags)-1 {
+				m.cursor++
+			}
+			m.scrollToCursor()
+
+		case ActionOpen:
+			if len(m.tags) == 0 {
+				return m, nil
+			}
+			tag := m.tags[m.cursor].Tag
+			return m, func() tea.Msg {
+				return FilterNotesByTagMsg{tag: tag}
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// visibleRows returns how many tags fit the window around the title and help,
+// leaving a line for the scroll position when they don't all fit
+func (m TagCloudModel) visibleRows() int {
+	if m.height <= 0 {
+		return len(m.tags)
+	}
+
+	// The padded help line wraps to the window width
+	style := helpStyle
+	if m.width > 0 {
+		style = style.Width(m.width)
+	}
+	rows := m.height - tagCloudChrome - lipgloss.Height(style.Render(m.help()))
+	if rows < len(m.tags) {
+		rows--
+	}
+	return max(rows, 1)
+}
+
+// scrollToCursor moves the visible window of tags just enough to show the cursor
+func (m *TagCloudModel) scrollToCursor() {
+	rows := m.visibleRows()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+rows {
+		m.offset = m.cursor - rows + 1
+	}
+	m.offset = max(min(m.offset, len(m.tags)-rows), 0)
+}
+
+// help returns the tag cloud's key help
+func (m TagCloudModel) help() string {
+	return renderHelp(
+		helpEntry{m.opts.Keys.HelpKeys(ActionPrev), "up"},
+		helpEntry{m.opts.Keys.HelpKeys(ActionNext), "down"},
+		helpEntry{m.opts.Keys.HelpKeys(ActionOpen), "filter notes by tag"},
+		helpEntry{m.opts.Keys.HelpKeys(ActionUp), "back"},
+		helpEntry{m.opts.Keys.HelpKeys(ActionQuit), "quit"},
+	)
+}
+
+// tagCloudStyle picks a tag's style from how often it's used relative to the most used tag
+func tagCloudStyle(count, maxCount int) lipgloss.Style {
+	switch {
+	case count*3 >= maxCount*2:
+		return tagCloudLargeStyle
+	case count*3 >= maxCount:
+		return tagCloudMediumStyle
+	default:
+		return tagCloudSmallStyle
+	}
+}
+
+func (m TagCloudModel) View() string {
+	if m.err != nil {
+		return fmt.Sprintf("\n  Error: %v\n\n  Press 'esc' to go back\n", m.err)
+	}
+
+	s := notesBrowserTitleStyle.Render("🏷  Tag Cloud") + "\n\n"
+
+	if len(m.tags) == 0 {
+		s += "  No tags found.\n\n"
+	} else {
+		maxCount := m.tags[0].Count
+		end := min(m.offset+m.visibleRows(), len(m.tags))
+		for i := m.offset; i < end; i++ {
+			tc := m.tags[i]
+			barLen := tc.Count * tagCloudBarWidth / maxCount
+			if barLen < 1 {
+				barLen = 1
+			}
+			line := fmt.Sprintf("%-20s %s %d", tc.Tag, strings.Repeat("█", barLen), tc.Count)
+
+			if i == m.cursor {
+				s += noteSelectedStyle.Render("▶ "+line) + "\n"
+			} else {
+				s += "  " + tagCloudStyle(tc.Count, maxCount).Render(line) + "\n"
+			}
+		}
+		if m.offset > 0 || end < len(m.tags) {
+			s += tagCloudSmallStyle.Render(fmt.Sprintf("  tags %d-%d of %d", m.offset+1, end, len(m.tags))) + "\n"
+		}
+		s += "\n"
+	}
+
+	s += helpStyle.Render(m.help())
+
+	return s
+}
@@ -0,0 +1,108 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/redjax/notetkr/internal/services"
+)
+
+func TestTagCloudCountsAndSelection(t *testing.T) {
+	notesDir := t.TempDir()
+	files := map[string]string{
+		"a.md": "---\ntags: work, meeting\n---\nbody\n",
+		"b.md": "---\ntags: work\n---\nbody\n",
+		"c.md": "---\ntags: work, personal\n---\nbody\n",
+		"d.md": "---\ntags: meeting\n---\nbody\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(notesDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	notesService := services.NewNotesService(notesDir)
+	m := NewTagCloud(notesService, DefaultOptions(), 80, 24)
+
+	want := []services.TagCount{{Tag: "work", Count: 3}, {Tag: "meeting", Count: 2}, {Tag: "personal", Count: 1}}
+	if !reflect.DeepEqual(m.tags, want) {
+		t.Fatalf("tag cloud = %v, want %v", m.tags, want)
+	}
+
+	// Select the second tag
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd := updated.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("selecting a tag should emit a filter message")
+	}
+	msg, ok := cmd().(FilterNotesByTagMsg)
+	if !ok || msg.tag != "meeting" {
+		t.Fatalf("selection emitted %#v, want FilterNotesByTagMsg{meeting}", msg)
+	}
+
+	// The app opens the notes browser filtered by that tag
+	app := NewNotesBrowserApp(testConfig(t, t.TempDir(), notesDir))
+	result, _ := app.Update(msg)
+	browser, ok := result.(AppModel).currentView.(NotesBrowserModel)
+	if !ok {
+		t.Fatalf("app switched to %T, want NotesBrowserModel", result.(AppModel).currentView)
+	}
+	if browser.filterMode != FilterTag || len(browser.filteredNotes) != 2 {
+		t.Errorf("browser filter = %v with %d notes, want FilterTag with 2", browser.filterMode, len(browser.filteredNotes))
+	}
+}
+
+func TestTagCloudScrollsToFitWindow(t *testing.T) {
+	notesDir := t.TempDir()
+	for i := 0; i < 60; i++ {
+		content := fmt.Sprintf("---\ntags: tag-%02d\n---\nbody\n", i)
+		if err := os.WriteFile(filepath.Join(notesDir, fmt.Sprintf("n%02d.md", i)), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := NewTagCloud(services.NewNotesService(notesDir), DefaultOptions(), 80, 24)
+	rows := m.visibleRows()
+	if rows < 1 || rows >= 24 {
+		t.Fatalf("visible rows = %d for a 24-line window", rows)
+	}
+	view := m.View()
+	if got := strings.Count(view, "tag-"); got != rows {
+		t.Errorf("view rendered %d tags, want %d", got, rows)
+	}
+	if got := lipgloss.Height(view); got != 24 {
+		t.Errorf("view is %d lines tall, want the window's 24", got)
+	}
+
+	// Moving past the last visible tag scrolls one line, keeping the cursor in view
+	for i := 0; i < rows; i++ {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+		m = updated.(TagCloudModel)
+	}
+	if m.offset != 1 {
+		t.Errorf("offset = %d after moving to tag %d, want 1", m.offset, rows)
+	}
+	view = m.View()
+	if !strings.Contains(view, "▶ "+m.tags[rows].Tag) || strings.Contains(view, m.tags[0].Tag+" ") {
+		t.Error("view should scroll to show the cursor and drop the first tag")
+	}
+	if want := fmt.Sprintf("tags 2-%d of 60", rows+1); !strings.Contains(view, want) {
+		t.Errorf("view should show %q", want)
+	}
+
+	// A taller window shows more tags without losing the cursor
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	m = updated.(TagCloudModel)
+	if got := m.visibleRows(); got != rows+16 {
+		t.Errorf("visible rows at 40 lines = %d, want %d", got, rows+16)
+	}
+	if !strings.Contains(m.View(), "▶ "+m.tags[rows].Tag) {
+		t.Error("cursor should stay visible after resizing")
+	}
+}
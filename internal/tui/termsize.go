@@ -0,0 +1,28 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Minimum terminal size the views are laid out for
+const (
+	MinTerminalWidth  = 40
+	MinTerminalHeight = 10
+)
+
+// tooSmallView returns a placeholder to render instead of a view while the
+// terminal is smaller than the minimum size. ok is false when the size is fine
+// or still unknown (width/height of 0 before the first resize).
+func tooSmallView(width, height int) (string, bool) {
+	if width <= 0 || height <= 0 {
+		return "", false
+	}
+	if width >= MinTerminalWidth && height >= MinTerminalHeight {
+		return "", false
+	}
+
+	msg := fmt.Sprintf("Terminal too small (%dx%d)\nneed at least %dx%d", width, height, MinTerminalWidth, MinTerminalHeight)
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, errorStyle.Render(msg)), true
+}
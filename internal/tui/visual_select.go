@@ -0,0 +1,97 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var visualLineStyle = lipgloss.NewStyle().Reverse(true)
+
+// lineRegister holds the lines last yanked or deleted in visual mode. It is shared
+// by the editors, so lines can be copied from a note into a journal entry.
+var lineRegister []string
+
+// visualSelection is a line-wise visual mode selection, from the line it started
+// on to the cursor line
+type visualSelection struct {
+	anchor int
+	top    int // First line shown, moved to keep the cursor in view
+}
+
+// newVisualSelection starts a selection on line, shown in height lines
+func newVisualSelection(line, height int) *visualSelection {
+	return &visualSelection{anchor: line, top: max(line-height/2, 0)}
+}
+
+// bounds returns the first and last selected lines for the cursor line
+func (v *visualSelection) bounds(cursor int) (from, to int) {
+	return min(v.anchor, cursor), max(v.anchor, cursor)
+}
+
+// scrollTo moves the shown lines so the cursor line is in view
+func (v *visualSelection) scrollTo(cursor, height int) {
+	if cursor < v.top {
+		v.top = cursor
+	} else if cursor >= v.top+height {
+		v.top = cursor - height + 1
+	}
+}
+
+// view renders height lines of the content from the scroll position, highlighting
+// the selected ones. Long lines are cut at width rather than wrapped.
+func (v *visualSelection) view(content string, cursor, width, height int) string {
+	lines := strings.Split(content, "\n")
+	from, to := v.bounds(cursor)
+	end := min(v.top+height, len(lines))
+	plain := lipgloss.NewStyle().MaxWidth(width)
+	selected := visualLineStyle.MaxWidth(width)
+
+	var b strings.Builder
+	for i := v.top; i < end; i++ {
+		if i >= from && i <= to {
+			// Keep empty lines visible in the highlight
+			b.WriteString(selected.Render(lines[i] + " "))
+		} else {
+			b.WriteString(plain.Render(lines[i]))
+		}
+		if i < end-1 {
+			b.WriteString("\n")
+		}
+	}
+	// Pad to the textarea's height so the layout doesn't jump
+	for i := end - v.top; i < height; i++ {
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// deleteLineRange removes lines from through to, returning the new content and
+// the line the cursor should move to
+func deleteLineRange(content string, from, to int) (newContent string, cursorLine int) {
+	lines := strings.Split(content, "\n")
+	from = max(from, 0)
+	to = min(to, len(lines)-1)
+	if from > to {
+		return content, from
+	}
+
+	lines = append(lines[:from], lines[to+1:]...)
+	if len(lines) == 0 {
+		return "", 0
+	}
+	return strings.Join(lines, "\n"), min(from, len(lines)-1)
+}
+
+// pasteLinesAfter inserts pasted below line, returning the new content and the
+// first pasted line
+func pasteLinesAfter(content string, line int, pasted []string) (newContent string, firstLine int) {
+	lines := strings.Split(content, "\n")
+	line = min(max(line, 0), len(lines)-1)
+
+	out := make([]string, 0, len(lines)+len(pasted))
+	out = append(out, lines[:line+1]...)
+	out = append(out, pasted...)
+	out = append(out, lines[line+1:]...)
+	return strings.Join(out, "\n"), line + 1
+}
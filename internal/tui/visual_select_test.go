@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestVisualLineDeleteYankAndPaste(t *testing.T) {
+	defer func() { lineRegister = nil }()
+
+	m := newTestNotesEditor(t)
+	m.textarea.SetValue("# Note\none\ntwo\nthree\nfour")
+	m.trackContentChange()
+	m.moveToLine(1)
+
+	keys := func(keys ...string) {
+		for _, key := range keys {
+			updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+			m = updated.(NotesEditorModel)
+		}
+	}
+
+	// Select "one" through "three" and delete them in one go
+	keys("v", "j", "j")
+	if from, to := m.visual.bounds(m.textarea.Line()); from != 1 || to != 3 {
+		t.Fatalf("selection = %d-%d, want 1-3", from, to)
+	}
+	if !strings.Contains(m.View(), "-- VISUAL LINE --") {
+		t.Error("visual mode should show in the mode indicator")
+	}
+	keys("d")
+	if got := m.textarea.Value(); got != "# Note\nfour" {
+		t.Fatalf("after d content = %q", got)
+	}
+	if m.visual != nil {
+		t.Error("d should leave visual mode")
+	}
+
+	// The deletion is a single undo step
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlZ})
+	m = updated.(NotesEditorModel)
+	if got := m.textarea.Value(); got != "# Note\none\ntwo\nthree\nfour" {
+		t.Fatalf("after undo content = %q", got)
+	}
+
+	// Yank the first two lines upwards and paste them below the last line
+	m.moveToLine(1)
+	keys("v", "k", "y")
+	if m.visual != nil || m.textarea.Line() != 0 {
+		t.Fatalf("y should leave visual mode at the top of the selection, cursor on line %d", m.textarea.Line())
+	}
+	keys("G", "p")
+	if got := m.textarea.Value(); got != "# Note\none\ntwo\nthree\nfour\n# Note\none" {
+		t.Errorf("after p content = %q", got)
+	}
+	if m.textarea.Line() != 5 {
+		t.Errorf("cursor on line %d after p, want the first pasted line 5", m.textarea.Line())
+	}
+}
@@ -35,7 +35,7 @@ func NewWeeklySummaryMenu(journalService *services.JournalService) WeeklySummary
 	return WeeklySummaryMenuModel{
 		journalService: journalService,
 		cursor:         0,
-		options:        []string{"Current Week", "Browse Past Weeks", "Browse Saved Summaries"},
+		options:        []string{"Current Week", "Current Month", "Browse Past Weeks", "Browse Saved Summaries"},
 	}
 }
 
@@ -58,7 +58,10 @@ func (m WeeklySummaryMenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case WeeklySummaryGeneratedMsg:
-		// Switch to weekly summary viewer
+		// Switch to the summary viewer
+		if msg.monthly {
+			return NewMonthlySummaryViewerWithSize(m.journalService, msg.summary, msg.weekStart, m.width, m.height), nil
+		}
 		return NewWeeklySummaryViewerWithSize(m.journalService, msg.summary, msg.weekStart, msg.weekEnd, m.width, m.height), nil
 
 	case WeeklySummaryErrorMsg:
@@ -87,13 +90,17 @@ func (m WeeklySummaryMenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 		case "enter", "l", "right", " ":
-			if m.cursor == 0 {
-				// Current Week - generate summary for current week
+			switch m.options[m.cursor] {
+			case "Current Week":
+				// Generate summary for current week
 				return m, m.generateCurrentWeekSummary
-			} else if m.cursor == 1 {
-				// Browse Past Weeks - go to week browser
+			case "Current Month":
+				// Generate summary for current month
+				return m, m.generateCurrentMonthSummary
+			case "Browse Past Weeks":
+				// Go to week browser
 				return NewWeekBrowserWithSize(m.journalService, m.width, m.height), nil
-			} else {
+			default:
 				// Browse Saved Summaries - go to saved summaries browser
 				return NewSavedSummariesBrowserWithSize(m.journalService, m.width, m.height), nil
 			}
@@ -118,6 +125,26 @@ func (m WeeklySummaryMenuModel) generateCurrentWeekSummary() tea.Msg {
 	}
 }
 
+func (m WeeklySummaryMenuModel) generateCurrentMonthSummary() tea.Msg {
+	return generateMonthlySummary(m.journalService, time.Now())
+}
+
+// generateMonthlySummary builds the summary for month and wraps it in a message for the viewer
+func generateMonthlySummary(journalService *services.JournalService, month time.Time) tea.Msg {
+	summary, err := journalService.GenerateMonthlySummary(month)
+	if err != nil {
+		return WeeklySummaryErrorMsg{err: err}
+	}
+
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
+	return WeeklySummaryGeneratedMsg{
+		summary:   summary,
+		weekStart: start,
+		weekEnd:   start.AddDate(0, 1, -1),
+		monthly:   true,
+	}
+}
+
 func (m WeeklySummaryMenuModel) View() string {
 	s := weeklySummaryMenuTitleStyle.Render("📊 Weekly Summary Options") + "\n\n"
 
@@ -150,6 +177,7 @@ type WeeklySummaryGeneratedMsg struct {
 	summary   string
 	weekStart time.Time
 	weekEnd   time.Time
+	monthly   bool // Summary covers the month starting at weekStart
 }
 
 type WeeklySummaryErrorMsg struct {
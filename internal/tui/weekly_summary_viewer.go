@@ -2,6 +2,7 @@ package tui
 
 import (
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
@@ -18,6 +19,7 @@ type WeeklySummaryViewerModel struct {
 	width          int
 	height         int
 	scrollOffset   int
+	monthly        bool // Showing a monthly summary for the month starting at weekStart
 	err            error
 }
 
@@ -47,6 +49,14 @@ func NewWeeklySummaryViewerWithSize(journalService *services.JournalService, sum
 	return m
 }
 
+// NewMonthlySummaryViewerWithSize creates a viewer for the monthly summary of month
+func NewMonthlySummaryViewerWithSize(journalService *services.JournalService, summary string, month time.Time, width, height int) WeeklySummaryViewerModel {
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
+	m := NewWeeklySummaryViewerWithSize(journalService, summary, start, start.AddDate(0, 1, -1), width, height)
+	m.monthly = true
+	return m
+}
+
 func (m WeeklySummaryViewerModel) Init() tea.Cmd {
 	return nil
 }
@@ -63,6 +73,7 @@ func (m WeeklySummaryViewerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.summary = msg.summary
 		m.weekStart = msg.weekStart
 		m.weekEnd = msg.weekEnd
+		m.monthly = msg.monthly
 		return m, nil
 
 	case WeeklySummaryErrorMsg:
@@ -81,6 +92,10 @@ func (m WeeklySummaryViewerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Regenerate summary
 			return m, m.regenerateSummary
 
+		case "e":
+			// Edit the saved summary file
+			return m, m.openSummaryFile
+
 		case "up", "k":
 			// Scroll up
 			if m.scrollOffset > 0 {
@@ -117,6 +132,10 @@ func (m WeeklySummaryViewerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m WeeklySummaryViewerModel) regenerateSummary() tea.Msg {
+	if m.monthly {
+		return generateMonthlySummary(m.journalService, m.weekStart)
+	}
+
 	summary, err := m.journalService.GenerateWeeklySummary(m.weekStart)
 	if err != nil {
 		return WeeklySummaryErrorMsg{err: err}
@@ -129,6 +148,33 @@ func (m WeeklySummaryViewerModel) regenerateSummary() tea.Msg {
 	}
 }
 
+// summaryPath returns where the summary being viewed is saved
+func (m WeeklySummaryViewerModel) summaryPath() string {
+	if m.monthly {
+		return m.journalService.GetMonthlySummaryPath(m.weekStart)
+	}
+	return m.journalService.GetWeeklySummaryPath(m.weekStart)
+}
+
+// openSummaryFile opens the saved summary in the notes editor, saving it first if needed
+func (m WeeklySummaryViewerModel) openSummaryFile() tea.Msg {
+	path := m.summaryPath()
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		var saveErr error
+		if m.monthly {
+			saveErr = m.journalService.SaveMonthlySummary(m.weekStart, m.summary)
+		} else {
+			saveErr = m.journalService.SaveWeeklySummary(m.weekStart, m.summary)
+		}
+		if saveErr != nil {
+			return WeeklySummaryErrorMsg{err: saveErr}
+		}
+	}
+
+	return OpenWeeklySummaryFileMsg{filePath: path}
+}
+
 func (m WeeklySummaryViewerModel) View() string {
 	if m.err != nil {
 		errMsg := fmt.Sprintf("\n  Error: %v\n\n  Press 'esc' to return to menu\n", m.err)
@@ -143,11 +189,24 @@ func (m WeeklySummaryViewerModel) View() string {
 		return errMsg
 	}
 
+	if m.monthly {
+		s := weeklySummaryViewerTitleStyle.Render("📊 Monthly Summary") + "\n"
+		s += lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render(
+			fmt.Sprintf("  Month: %s", m.weekStart.Format("January 2006"))) + "\n\n"
+		return m.renderSummary(s)
+	}
+
 	s := weeklySummaryViewerTitleStyle.Render("📊 Weekly Summary") + "\n"
 	s += lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render(
 		fmt.Sprintf("  Week: %s - %s",
 			m.weekStart.Format("Jan 2, 2006"),
 			m.weekEnd.Format("Jan 2, 2006"))) + "\n\n"
+	return m.renderSummary(s)
+}
+
+// renderSummary renders the scrollable summary body and help below header
+func (m WeeklySummaryViewerModel) renderSummary(header string) string {
+	s := header
 
 	// Calculate visible content area
 	headerLines := 4 // Title + week info + blank lines
@@ -192,7 +251,7 @@ func (m WeeklySummaryViewerModel) View() string {
 	}
 
 	// Help
-	s += helpStyle.Render("↑/k ↓/j: scroll • g/G: top/bottom • r: regenerate • esc/h: back • q: quit")
+	s += helpStyle.Render("↑/k ↓/j: scroll • g/G: top/bottom • r: regenerate • e: edit • esc/h: back • q: quit")
 
 	// Fill the screen
 	if m.width > 0 && m.height > 0 {
@@ -0,0 +1,41 @@
+package tui
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/redjax/notetkr/internal/services"
+)
+
+func TestWeeklySummaryViewerOpensSummaryFile(t *testing.T) {
+	journalService := services.NewJournalService(t.TempDir(), time.Sunday)
+	weekStart := time.Date(2024, time.May, 5, 0, 0, 0, 0, time.Local)
+	month := time.Date(2024, time.May, 1, 0, 0, 0, 0, time.Local)
+
+	for _, tt := range []struct {
+		name string
+		m    WeeklySummaryViewerModel
+		want string
+	}{
+		{"weekly", NewWeeklySummaryViewer(journalService, "# Week\n", weekStart, weekStart.AddDate(0, 0, 6)), journalService.GetWeeklySummaryPath(weekStart)},
+		{"monthly", NewMonthlySummaryViewerWithSize(journalService, "# Month\n", month, 80, 24), journalService.GetMonthlySummaryPath(month)},
+	} {
+		// The summary hasn't been saved yet, so e saves it before opening
+		_, cmd := tt.m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+		if cmd == nil {
+			t.Fatalf("%s: e should open the summary file", tt.name)
+		}
+		msg, ok := cmd().(OpenWeeklySummaryFileMsg)
+		if !ok {
+			t.Fatalf("%s: e should emit OpenWeeklySummaryFileMsg", tt.name)
+		}
+		if msg.filePath != tt.want {
+			t.Errorf("%s: opened %s, want %s", tt.name, msg.filePath, tt.want)
+		}
+		if data, err := os.ReadFile(tt.want); err != nil || string(data) != tt.m.summary {
+			t.Errorf("%s: summary file = %q, %v; want it saved first", tt.name, data, err)
+		}
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+
+	"golang.design/x/clipboard"
 )
 
 // CopyToClipboard copies the given text to the system clipboard
@@ -44,6 +46,47 @@ func CopyToClipboard(text string) error {
 	return nil
 }
 
+// ReadTextFromClipboard returns the text currently on the system clipboard
+func ReadTextFromClipboard() (string, error) {
+	if err := clipboard.Init(); err != nil {
+		return "", fmt.Errorf("failed to initialize clipboard: %w", err)
+	}
+
+	return string(clipboard.Read(clipboard.FmtText)), nil
+}
+
+// CopyHTMLToClipboard places html on the clipboard as rich text so it pastes
+// formatted into email and rich editors. Where no HTML clipboard tool is available
+// it falls back to copying the HTML as plain text. Reports whether it was copied as HTML.
+func CopyHTMLToClipboard(html string) (bool, error) {
+	if args := htmlClipboardCommand(); args != nil {
+		if _, err := exec.LookPath(args[0]); err == nil {
+			cmd := exec.Command(args[0], args[1:]...)
+			cmd.Stdin = strings.NewReader(html)
+			if err := cmd.Run(); err == nil {
+				return true, nil
+			}
+		}
+	}
+
+	if err := CopyToClipboard(html); err != nil {
+		return false, err
+	}
+	return false, nil
+}
+
+// htmlClipboardCommand returns the command that sets the clipboard's HTML
+// content from stdin on this platform, or nil if there isn't a simple one
+func htmlClipboardCommand() []string {
+	if runtime.GOOS != "linux" {
+		return nil
+	}
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		return []string{"wl-copy", "--type", "text/html"}
+	}
+	return []string{"xclip", "-selection", "clipboard", "-t", "text/html"}
+}
+
 // isRunningInWSL detects if we're running in Windows Subsystem for Linux
 func isRunningInWSL() bool {
 	if runtime.GOOS != "linux" {
@@ -6,22 +6,48 @@ import (
 	"encoding/hex"
 	"fmt"
 	"image"
+	"image/jpeg"
 	"image/png"
+	"net/url"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 
 	"golang.design/x/clipboard"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // Registers the WebP decoder with image.Decode
 )
 
+// DefaultJPEGQuality is the quality pasted JPEG images are saved at when none is configured
+const DefaultJPEGQuality = 90
+
+// ImageOptions controls how pasted images are saved
+type ImageOptions struct {
+	// JPEGQuality is the quality (1-100) JPEG images are re-encoded at.
+	// Values outside that range use DefaultJPEGQuality.
+	JPEGQuality int
+
+	// MaxWidth is the width wider images are scaled down to, keeping their
+	// aspect ratio. 0 (or less) keeps their size.
+	MaxWidth int
+}
+
 // ClipboardImageHandler handles clipboard image operations
 type ClipboardImageHandler struct {
 	initialized bool
+	options     ImageOptions
 }
 
-// NewClipboardImageHandler creates a new clipboard image handler
-func NewClipboardImageHandler() *ClipboardImageHandler {
-	return &ClipboardImageHandler{}
+// NewClipboardImageHandler creates a new clipboard image handler that saves images as opts asks
+func NewClipboardImageHandler(opts ImageOptions) *ClipboardImageHandler {
+	if opts.JPEGQuality < 1 || opts.JPEGQuality > 100 {
+		opts.JPEGQuality = DefaultJPEGQuality
+	}
+	if opts.MaxWidth < 0 {
+		opts.MaxWidth = 0
+	}
+	return &ClipboardImageHandler{options: opts}
 }
 
 // Initialize initializes the clipboard
@@ -49,12 +75,19 @@ func (h *ClipboardImageHandler) HasImage() bool {
 	}
 
 	data := clipboard.Read(clipboard.FmtImage)
-	return len(data) > 0
+	if len(data) > 0 {
+		return true
+	}
+	_, ok := ImagePathFromText(string(clipboard.Read(clipboard.FmtText)))
+	return ok
 }
 
 // SaveClipboardImage saves the clipboard image to a centralized attachments directory
-// Returns just the filename (since all images are in the same imgs directory)
-// If an identical image already exists, returns the existing filename
+// as "<baseName>-<hash>.<ext>", keeping its format: JPEG as .jpg, WebP as .webp and
+// anything else as .png. Returns just the filename (since all images are in the same imgs directory)
+//
+// Every call saves its own attachment, so pasting the same image twice links two files.
+// When the clipboard holds the path of an image file instead of image data, that file is saved.
 func (h *ClipboardImageHandler) SaveClipboardImage(imgsDir, baseName string) (string, error) {
 	if !h.initialized {
 		if err := h.Initialize(); err != nil {
@@ -65,43 +98,100 @@ func (h *ClipboardImageHandler) SaveClipboardImage(imgsDir, baseName string) (st
 	// Read image from clipboard
 	data := clipboard.Read(clipboard.FmtImage)
 	if len(data) == 0 {
-		return "", fmt.Errorf("no image data in clipboard")
+		path, ok := ImagePathFromText(string(clipboard.Read(clipboard.FmtText)))
+		if !ok {
+			return "", fmt.Errorf("no image data in clipboard")
+		}
+		return h.SaveImageFile(imgsDir, baseName, path)
 	}
 
-	// Decode the image
-	img, _, err := image.Decode(bytes.NewReader(data))
+	return h.saveImageData(imgsDir, baseName, data)
+}
+
+// SaveImageFile copies the image file at path into imgsDir the same way
+// SaveClipboardImage saves clipboard data, returning the saved filename
+func (h *ClipboardImageHandler) SaveImageFile(imgsDir, baseName, path string) (string, error) {
+	data, err := os.ReadFile(path)
 	if err != nil {
-		return "", fmt.Errorf("failed to decode clipboard image: %w", err)
+		return "", fmt.Errorf("failed to read image file: %w", err)
 	}
+	return h.saveImageData(imgsDir, baseName, data)
+}
 
-	// Encode image to bytes for hashing
-	var buf bytes.Buffer
-	if err := png.Encode(&buf, img); err != nil {
-		return "", fmt.Errorf("failed to encode image: %w", err)
+// imageFileExtensions are the file types ImagePathFromText accepts
+var imageFileExtensions = map[string]bool{
+	".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".webp": true,
+}
+
+// ImagePathFromText returns the image file named by clipboard text: a path (optionally
+// quoted or starting with ~/) or a file:// URI, as file managers copy them. It reports
+// false unless the text is a single existing file with an image extension.
+func ImagePathFromText(text string) (string, bool) {
+	text = strings.TrimSpace(text)
+	if text == "" || strings.Contains(text, "\n") {
+		return "", false
 	}
-	imageBytes := buf.Bytes()
+	text = strings.Trim(text, `"'`)
 
-	// Calculate SHA256 hash of the image
-	hash := sha256.Sum256(imageBytes)
-	hashString := hex.EncodeToString(hash[:])
+	if strings.HasPrefix(text, "file://") {
+		u, err := url.Parse(text)
+		if err != nil {
+			return "", false
+		}
+		text = u.Path
+		if runtime.GOOS == "windows" {
+			text = strings.TrimPrefix(text, "/")
+		}
+	} else if strings.HasPrefix(text, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", false
+		}
+		text = filepath.Join(home, text[2:])
+	}
 
-	// Generate filename with hash prefix
-	filename := fmt.Sprintf("%s-%s.png", baseName, hashString[:12])
-	imagePath := filepath.Join(imgsDir, filename)
+	if !imageFileExtensions[strings.ToLower(filepath.Ext(text))] {
+		return "", false
+	}
+	if info, err := os.Stat(text); err != nil || info.IsDir() {
+		return "", false
+	}
+	return text, true
+}
+
+// hashImageData returns the hex SHA256 of raw clipboard image data
+func hashImageData(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
 
-	// Check if file already exists (by name/hash)
-	if _, err := os.Stat(imagePath); err == nil {
-		// File already exists, return existing filename
-		return filename, nil
+// saveImageData encodes image data and writes it to imgsDir as "<baseName>-<hash>.<ext>",
+// numbering the name ("<baseName>-<hash>-2.<ext>", ...) when an earlier paste took it
+func (h *ClipboardImageHandler) saveImageData(imgsDir, baseName string, data []byte) (string, error) {
+	imageBytes, ext, err := encodeClipboardImage(data, h.options.JPEGQuality, h.options.MaxWidth)
+	if err != nil {
+		return "", err
 	}
 
+	// Calculate SHA256 hash of the image
+	hashString := hashImageData(imageBytes)
+
 	// Create imgs directory if it doesn't exist
 	if err := os.MkdirAll(imgsDir, 0755); err != nil {
 		return "", fmt.Errorf("failed to create imgs directory: %w", err)
 	}
 
-	// Create the file
-	file, err := os.Create(imagePath)
+	// Generate filename with hash prefix, creating it exclusively so each paste gets its own file
+	if baseName == "" {
+		baseName = "image"
+	}
+	stem := fmt.Sprintf("%s-%s", baseName, hashString[:12])
+	filename := stem + ext
+	file, err := os.OpenFile(filepath.Join(imgsDir, filename), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	for n := 2; os.IsExist(err); n++ {
+		filename = fmt.Sprintf("%s-%d%s", stem, n, ext)
+		file, err = os.OpenFile(filepath.Join(imgsDir, filename), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	}
 	if err != nil {
 		return "", fmt.Errorf("failed to create image file: %w", err)
 	}
@@ -115,6 +205,54 @@ func (h *ClipboardImageHandler) SaveClipboardImage(imgsDir, baseName string) (st
 	return filename, nil
 }
 
+// encodeClipboardImage returns the bytes to save for clipboard image data and their
+// file extension. Images wider than maxWidth (if set) are scaled down first. JPEGs are
+// re-encoded at quality, WebP images (which can't be encoded) are kept as they are
+// unless resized, and other formats become PNG.
+func encodeClipboardImage(data []byte, quality, maxWidth int) ([]byte, string, error) {
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decode clipboard image: %w", err)
+	}
+
+	if resized, ok := scaleToWidth(img, maxWidth); ok {
+		img = resized
+		if format == "webp" {
+			format = "png"
+		}
+	}
+
+	var buf bytes.Buffer
+	switch format {
+	case "jpeg":
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+			return nil, "", fmt.Errorf("failed to encode image: %w", err)
+		}
+		return buf.Bytes(), ".jpg", nil
+	case "webp":
+		return data, ".webp", nil
+	default:
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, "", fmt.Errorf("failed to encode image: %w", err)
+		}
+		return buf.Bytes(), ".png", nil
+	}
+}
+
+// scaleToWidth shrinks img to maxWidth pixels wide, keeping its aspect ratio.
+// It reports false, leaving img alone, if maxWidth is 0 or img already fits.
+func scaleToWidth(img image.Image, maxWidth int) (image.Image, bool) {
+	bounds := img.Bounds()
+	if maxWidth <= 0 || bounds.Dx() <= maxWidth {
+		return img, false
+	}
+
+	height := max(bounds.Dy()*maxWidth/bounds.Dx(), 1)
+	dst := image.NewRGBA(image.Rect(0, 0, maxWidth, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Over, nil)
+	return dst, true
+}
+
 // findImageByHashGlobal searches for an existing image file with the given hash
 // across all .attachments directories in the root directory
 func findImageByHashGlobal(rootDir, hash string) (string, error) {
@@ -172,26 +310,6 @@ func findImageByHashGlobal(rootDir, hash string) (string, error) {
 	return "", fmt.Errorf("no matching image found")
 }
 
-// findImageByHash searches for an existing image file with the given hash
-func findImageByHash(dir, hash string) (string, error) {
-	// List all PNG files in the directory
-	files, err := filepath.Glob(filepath.Join(dir, "*.png"))
-	if err != nil {
-		return "", err
-	}
-
-	// Check each file for the hash in the filename
-	hashPrefix := hash[:12]
-	for _, file := range files {
-		filename := filepath.Base(file)
-		if len(filename) >= 12 && filename[len(filename)-16:len(filename)-4] == hashPrefix {
-			return file, nil
-		}
-	}
-
-	return "", fmt.Errorf("no matching image found")
-}
-
 // getClipboardHelp returns platform-specific help for clipboard issues
 func getClipboardHelp() string {
 	switch runtime.GOOS {
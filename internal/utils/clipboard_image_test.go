@@ -0,0 +1,145 @@
+package utils
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testImage() image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, 16, 16))
+	for x := 0; x < 16; x++ {
+		for y := 0; y < 16; y++ {
+			img.Set(x, y, color.RGBA{uint8(x * 16), uint8(y * 16), 128, 255})
+		}
+	}
+	return img
+}
+
+func TestEncodeClipboardImageKeepsFormat(t *testing.T) {
+	var jpegData, pngData bytes.Buffer
+	if err := jpeg.Encode(&jpegData, testImage(), &jpeg.Options{Quality: 100}); err != nil {
+		t.Fatal(err)
+	}
+	if err := png.Encode(&pngData, testImage()); err != nil {
+		t.Fatal(err)
+	}
+
+	encoded, ext, err := encodeClipboardImage(jpegData.Bytes(), 50, 0)
+	if err != nil {
+		t.Fatalf("encodeClipboardImage(jpeg) returned error: %v", err)
+	}
+	if ext != ".jpg" {
+		t.Errorf("JPEG saved as %q, want .jpg", ext)
+	}
+	if _, format, err := image.Decode(bytes.NewReader(encoded)); err != nil || format != "jpeg" {
+		t.Errorf("JPEG re-encoded as %q (%v), want jpeg", format, err)
+	}
+
+	encoded, ext, err = encodeClipboardImage(pngData.Bytes(), 50, 0)
+	if err != nil {
+		t.Fatalf("encodeClipboardImage(png) returned error: %v", err)
+	}
+	if ext != ".png" || !bytes.Equal(encoded, pngData.Bytes()) {
+		t.Errorf("PNG saved as %q, want unchanged .png", ext)
+	}
+
+	if _, _, err := encodeClipboardImage([]byte("not an image"), 50, 0); err == nil {
+		t.Error("expected an error for undecodable data")
+	}
+}
+
+func TestEncodeClipboardImageResizesWideImages(t *testing.T) {
+	var pngData bytes.Buffer
+	if err := png.Encode(&pngData, image.NewRGBA(image.Rect(0, 0, 400, 100))); err != nil {
+		t.Fatal(err)
+	}
+
+	encoded, ext, err := encodeClipboardImage(pngData.Bytes(), 90, 200)
+	if err != nil {
+		t.Fatalf("encodeClipboardImage returned error: %v", err)
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(encoded))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ext != ".png" || cfg.Width != 200 || cfg.Height != 50 {
+		t.Errorf("resized image = %dx%d %s, want 200x50 .png", cfg.Width, cfg.Height, ext)
+	}
+
+	// Narrow images and a zero limit keep the original size
+	for _, maxWidth := range []int{0, 400, 1000} {
+		encoded, _, err := encodeClipboardImage(pngData.Bytes(), 90, maxWidth)
+		if err != nil {
+			t.Fatalf("encodeClipboardImage returned error: %v", err)
+		}
+		if !bytes.Equal(encoded, pngData.Bytes()) {
+			t.Errorf("image changed with max width %d", maxWidth)
+		}
+	}
+}
+
+func TestImagePathFromText(t *testing.T) {
+	dir := t.TempDir()
+	imagePath := filepath.Join(dir, "shot 1.PNG")
+	if err := os.WriteFile(imagePath, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	textPath := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(textPath, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, text := range []string{imagePath, "  " + imagePath + "\n", `"` + imagePath + `"`, (&url.URL{Scheme: "file", Path: filepath.ToSlash(imagePath)}).String()} {
+		if got, ok := ImagePathFromText(text); !ok || got != imagePath {
+			t.Errorf("ImagePathFromText(%q) = %q, %v; want %q", text, got, ok, imagePath)
+		}
+	}
+
+	for _, text := range []string{"", "just some text", textPath, filepath.Join(dir, "missing.png"), dir, imagePath + "\n" + imagePath} {
+		if got, ok := ImagePathFromText(text); ok {
+			t.Errorf("ImagePathFromText(%q) = %q, want no path", text, got)
+		}
+	}
+}
+
+func TestSaveImageFile(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "screenshot.png")
+	var pngData bytes.Buffer
+	if err := png.Encode(&pngData, testImage()); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(src, pngData.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	handler := NewClipboardImageHandler(ImageOptions{})
+	imgsDir := filepath.Join(t.TempDir(), ".attachments", "imgs")
+	filename, err := handler.SaveImageFile(imgsDir, "meeting", src)
+	if err != nil {
+		t.Fatalf("SaveImageFile returned error: %v", err)
+	}
+	if !strings.HasPrefix(filename, "meeting-") || filepath.Ext(filename) != ".png" {
+		t.Errorf("saved as %q, want meeting-<hash>.png", filename)
+	}
+	if _, err := os.Stat(filepath.Join(imgsDir, filename)); err != nil {
+		t.Errorf("saved image missing: %v", err)
+	}
+
+	// Saving the same file again gets its own attachment
+	again, err := handler.SaveImageFile(imgsDir, "meeting", src)
+	if want := strings.TrimSuffix(filename, ".png") + "-2.png"; err != nil || again != want {
+		t.Errorf("second SaveImageFile = %q, %v; want %q", again, err, want)
+	}
+
+	if _, err := handler.SaveImageFile(imgsDir, "meeting", filepath.Join(t.TempDir(), "missing.png")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
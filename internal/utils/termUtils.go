@@ -132,3 +132,12 @@ func visibleLength(s string) int {
 
 	return length
 }
+
+// EditorCommand returns the user's $EDITOR, defaulting to vi
+func EditorCommand() string {
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = "vi"
+	}
+	return editor
+}
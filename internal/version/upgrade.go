@@ -3,6 +3,7 @@ package version
 import (
 	"archive/tar"
 	"archive/zip"
+	"bytes"
 	"compress/gzip"
 	"encoding/json"
 	"fmt"
@@ -10,6 +11,7 @@ import (
 	"net/http"
 	"os"
 	"runtime"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -145,7 +147,7 @@ func UpgradeSelf(cmd *cobra.Command, args []string, checkOnly bool) error {
 	}
 
 	newPath := exePath + ".new"
-	if err := copyFile(binaryTmp, newPath); err != nil {
+	if err := saveUpgradeBinary(binaryTmp, newPath); err != nil {
 		if os.IsPermission(err) {
 			fmt.Fprintln(cmd.ErrOrStderr(), "Permission denied: try running with 'sudo nt self upgrade'")
 		}
@@ -352,6 +354,99 @@ func compareVersion(a, b string) int {
 	return 1
 }
 
+// executableMagic holds the leading bytes of the executable formats we release for
+var executableMagic = [][]byte{
+	{0x7f, 'E', 'L', 'F'},    // ELF (Linux)
+	{'M', 'Z'},               // PE (Windows)
+	{0xfe, 0xed, 0xfa, 0xce}, // Mach-O 32-bit
+	{0xfe, 0xed, 0xfa, 0xcf}, // Mach-O 64-bit
+	{0xce, 0xfa, 0xed, 0xfe}, // Mach-O 32-bit, little endian
+	{0xcf, 0xfa, 0xed, 0xfe}, // Mach-O 64-bit, little endian
+	{0xca, 0xfe, 0xba, 0xbe}, // Mach-O universal
+}
+
+// expectedSizePath is where the size of a downloaded "<binary>.new" is recorded
+func expectedSizePath(newPath string) string {
+	return newPath + ".size"
+}
+
+// saveUpgradeBinary puts the extracted binary at newPath for TrySelfUpgrade to apply.
+// It's copied under a temporary name and renamed into place once complete, with its
+// size recorded first, so newPath never holds a partial copy without a size to catch it.
+func saveUpgradeBinary(binaryTmp, newPath string) error {
+	partPath := newPath + ".part"
+	defer os.Remove(partPath)
+
+	if err := copyFile(binaryTmp, partPath); err != nil {
+		return err
+	}
+	if err := writeExpectedSize(binaryTmp, newPath); err != nil {
+		return fmt.Errorf("failed to record new binary size: %w", err)
+	}
+	return os.Rename(partPath, newPath)
+}
+
+// writeExpectedSize records the size of the extracted binary next to newPath
+func writeExpectedSize(binaryTmp, newPath string) error {
+	info, err := os.Stat(binaryTmp)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(expectedSizePath(newPath), []byte(strconv.FormatInt(info.Size(), 10)), 0644)
+}
+
+// validateUpgradeBinary checks that a downloaded binary looks complete: it must be
+// non-empty, start with an executable header, and match its recorded size
+func validateUpgradeBinary(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if info.Size() == 0 {
+		return fmt.Errorf("%s is empty", path)
+	}
+
+	// #nosec G304 - CLI tool reads its own pending upgrade by design
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	header := make([]byte, 4)
+	n, _ := io.ReadFull(f, header)
+	header = header[:n]
+
+	isExecutable := false
+	for _, magic := range executableMagic {
+		if bytes.HasPrefix(header, magic) {
+			isExecutable = true
+			break
+		}
+	}
+	if !isExecutable {
+		return fmt.Errorf("%s is not an executable", path)
+	}
+
+	// Without a recorded size there's no telling whether the copy finished
+	data, err := os.ReadFile(expectedSizePath(path))
+	if os.IsNotExist(err) {
+		return fmt.Errorf("%s has no recorded size, so it may be incomplete", path)
+	}
+	if err != nil {
+		return err
+	}
+	expected, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid recorded size for %s: %w", path, err)
+	}
+	if info.Size() != expected {
+		return fmt.Errorf("%s is incomplete: %d of %d bytes", path, info.Size(), expected)
+	}
+
+	return nil
+}
+
 // TrySelfUpgrade checks if "<binary>.new" exists and replaces current binary with it.
 func TrySelfUpgrade() {
 	exePath, err := os.Executable()
@@ -363,7 +458,16 @@ func TrySelfUpgrade() {
 	newPath := exePath + ".new"
 
 	if _, err := os.Stat(newPath); err == nil {
-		// New file exists: perform replacement
+		// New file exists: make sure it isn't a partial download before replacing
+		if err := validateUpgradeBinary(newPath); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠ Discarding invalid upgrade: %v\n", err)
+			os.Remove(newPath)
+			os.Remove(expectedSizePath(newPath))
+			return
+		}
+		os.Remove(expectedSizePath(newPath))
+
+		// Perform replacement
 
 		if runtime.GOOS == "windows" {
 			// Use Windows-specific updater (launches background script and exits)
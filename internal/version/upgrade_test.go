@@ -0,0 +1,61 @@
+package version
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateUpgradeBinary(t *testing.T) {
+	dir := t.TempDir()
+	elf := append([]byte{0x7f, 'E', 'L', 'F'}, make([]byte, 60)...)
+
+	write := func(name string, data []byte, size string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, data, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if size != "" {
+			if err := os.WriteFile(expectedSizePath(path), []byte(size), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return path
+	}
+
+	if err := validateUpgradeBinary(write("complete.new", elf, "64")); err != nil {
+		t.Errorf("complete binary rejected: %v", err)
+	}
+	if err := validateUpgradeBinary(write("truncated.new", elf[:32], "64")); err == nil {
+		t.Error("expected a truncated binary to be rejected")
+	}
+	if err := validateUpgradeBinary(write("truncated-unrecorded.new", elf[:32], "")); err == nil {
+		t.Error("expected a truncated binary without a recorded size to be rejected")
+	}
+	if err := validateUpgradeBinary(write("empty.new", nil, "")); err == nil {
+		t.Error("expected an empty binary to be rejected")
+	}
+	if err := validateUpgradeBinary(write("text.new", []byte("<html>Not Found</html>"), "")); err == nil {
+		t.Error("expected a non-executable file to be rejected")
+	}
+}
+
+func TestSaveUpgradeBinary(t *testing.T) {
+	dir := t.TempDir()
+	binaryTmp := filepath.Join(dir, "extracted")
+	elf := append([]byte{0x7f, 'E', 'L', 'F'}, make([]byte, 60)...)
+	if err := os.WriteFile(binaryTmp, elf, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	newPath := filepath.Join(dir, "nt.new")
+	if err := saveUpgradeBinary(binaryTmp, newPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := validateUpgradeBinary(newPath); err != nil {
+		t.Errorf("saved binary rejected: %v", err)
+	}
+	if _, err := os.Stat(newPath + ".part"); !os.IsNotExist(err) {
+		t.Error("the temporary copy should be renamed into place")
+	}
+}